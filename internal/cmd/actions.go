@@ -0,0 +1,224 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/twelvelabs/gh-setup/internal/gh"
+)
+
+// actionsSettings is the GitHub Actions settings of a repo.
+type actionsSettings struct {
+	Permissions *gh.ActionsPermissions
+	Selected    *gh.SelectedActions
+	Workflow    *gh.WorkflowPermissions
+}
+
+// ensureActionsPermissions applies the configured GitHub Actions settings
+// (the default GITHUB_TOKEN permissions, whether workflows can approve
+// pull requests, and the actions workflows may use) to the GitHub repo,
+// showing the settings that will change and prompting before applying them.
+// Only the settings that differ are updated.
+func (a *RootAction) ensureActionsPermissions() error {
+	if !a.Config.Actions.Enabled {
+		return nil
+	}
+	repo, err := a.remoteRepo()
+	if err != nil || repo == nil {
+		return err
+	}
+
+	current, err := a.actionsSettings(repo)
+	if a.isUnavailable("Actions settings", err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	desired := a.desiredActionsSettings(current)
+	changes := actionsChanges(current, desired)
+	if len(changes) == 0 {
+		return nil // already configured
+	}
+
+	a.Messenger.Info("The Actions settings of %s differ from the configured ones:\n", repo.FullName)
+	a.printChanges(changes)
+	ok, err := a.Prompter.Confirm("Update the Actions settings?", true, "")
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return nil // user wants to keep them
+	}
+
+	err = a.applyActionsSettings(repo, current, desired)
+	if a.isUnavailable("Actions settings", err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	a.Messenger.Success("Actions settings updated.\n")
+	return nil
+}
+
+// validateActionsConfig returns an error if the configured values are invalid
+// (checked before anything is created, rather than once the repo is pushed).
+func (a *RootAction) validateActionsConfig() error {
+	config := a.Config.Actions
+	if !config.Enabled {
+		return nil
+	}
+	switch config.DefaultPermissions {
+	case gh.WorkflowPermissionsRead, gh.WorkflowPermissionsWrite:
+	default:
+		return fmt.Errorf(
+			"actions: invalid default_permissions: %s (expected read or write)", config.DefaultPermissions,
+		)
+	}
+	switch config.AllowedActions {
+	case gh.AllowedActionsAll, gh.AllowedActionsLocalOnly, gh.AllowedActionsSelected:
+	default:
+		return fmt.Errorf(
+			"actions: invalid allowed_actions: %s (expected all, local_only or selected)", config.AllowedActions,
+		)
+	}
+	return nil
+}
+
+// actionsSettings returns the current Actions settings of repo.
+// The selected actions are only returned when they apply.
+func (a *RootAction) actionsSettings(repo *gh.Repository) (*actionsSettings, error) {
+	permissions, err := a.GhClient.GetActionsPermissions(repo.FullName)
+	if err != nil {
+		return nil, err
+	}
+	workflow, err := a.GhClient.GetWorkflowPermissions(repo.FullName)
+	if err != nil {
+		return nil, err
+	}
+	settings := &actionsSettings{
+		Permissions: permissions,
+		Workflow:    workflow,
+	}
+	if permissions.AllowedActions == gh.AllowedActionsSelected {
+		settings.Selected, err = a.GhClient.GetSelectedActions(repo.FullName)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return settings, nil
+}
+
+// desiredActionsSettings returns the configured Actions settings.
+// Actions stay disabled in repos that have them disabled.
+func (a *RootAction) desiredActionsSettings(current *actionsSettings) *actionsSettings {
+	config := a.Config.Actions
+	desired := &actionsSettings{
+		Permissions: &gh.ActionsPermissions{
+			Enabled:        current.Permissions.Enabled,
+			AllowedActions: config.AllowedActions,
+		},
+		Workflow: &gh.WorkflowPermissions{
+			DefaultWorkflowPermissions:   config.DefaultPermissions,
+			CanApprovePullRequestReviews: config.CanApprovePullRequests,
+		},
+	}
+	if !current.Permissions.Enabled {
+		desired.Permissions = current.Permissions // nothing to restrict
+	}
+	if desired.Permissions.AllowedActions == gh.AllowedActionsSelected {
+		desired.Selected = &gh.SelectedActions{
+			GithubOwnedAllowed: config.GithubOwnedAllowed,
+			VerifiedAllowed:    config.VerifiedAllowed,
+			PatternsAllowed:    append([]string{}, config.PatternsAllowed...),
+		}
+	}
+	return desired
+}
+
+// actionsChanges returns the settings in desired that differ from current.
+func actionsChanges(current *actionsSettings, desired *actionsSettings) []settingChange {
+	changes := []settingChange{}
+	add := func(key string, from string, value string) {
+		if from != value {
+			changes = append(changes, settingChange{Key: key, From: from, Value: value})
+		}
+	}
+	add(
+		"default_workflow_permissions",
+		current.Workflow.DefaultWorkflowPermissions,
+		desired.Workflow.DefaultWorkflowPermissions,
+	)
+	add(
+		"can_approve_pull_request_reviews",
+		strconv.FormatBool(current.Workflow.CanApprovePullRequestReviews),
+		strconv.FormatBool(desired.Workflow.CanApprovePullRequestReviews),
+	)
+	add("allowed_actions", current.Permissions.AllowedActions, desired.Permissions.AllowedActions)
+	if desired.Selected != nil {
+		selected := current.Selected
+		if selected == nil {
+			selected = &gh.SelectedActions{} // what the UI shows before any are selected
+		}
+		add(
+			"github_owned_allowed",
+			strconv.FormatBool(selected.GithubOwnedAllowed),
+			strconv.FormatBool(desired.Selected.GithubOwnedAllowed),
+		)
+		add(
+			"verified_allowed",
+			strconv.FormatBool(selected.VerifiedAllowed),
+			strconv.FormatBool(desired.Selected.VerifiedAllowed),
+		)
+		add(
+			"patterns_allowed",
+			strings.Join(sortedPatterns(selected), ", "),
+			strings.Join(sortedPatterns(desired.Selected), ", "),
+		)
+	}
+	return changes
+}
+
+// applyActionsSettings updates the Actions settings of repo
+// that differ from desired.
+func (a *RootAction) applyActionsSettings(repo *gh.Repository, current, desired *actionsSettings) error {
+	if *current.Workflow != *desired.Workflow {
+		if err := a.GhClient.SetWorkflowPermissions(repo.FullName, desired.Workflow); err != nil {
+			return err
+		}
+	}
+	if *current.Permissions != *desired.Permissions {
+		if err := a.GhClient.SetActionsPermissions(repo.FullName, desired.Permissions); err != nil {
+			return err
+		}
+	}
+	if desired.Selected != nil && !selectedActionsEqual(current.Selected, desired.Selected) {
+		// Only settable once the allowed actions are selected (above).
+		if err := a.GhClient.SetSelectedActions(repo.FullName, desired.Selected); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// selectedActionsEqual returns true if current (which may be nil)
+// allows the same actions as desired.
+func selectedActionsEqual(current *gh.SelectedActions, desired *gh.SelectedActions) bool {
+	if current == nil {
+		return false
+	}
+	return current.GithubOwnedAllowed == desired.GithubOwnedAllowed &&
+		current.VerifiedAllowed == desired.VerifiedAllowed &&
+		strings.Join(sortedPatterns(current), "\n") == strings.Join(sortedPatterns(desired), "\n")
+}
+
+// sortedPatterns returns a sorted copy of the allowed patterns in selected
+// (GitHub doesn't preserve their order).
+func sortedPatterns(selected *gh.SelectedActions) []string {
+	patterns := append([]string{}, selected.PatternsAllowed...)
+	sort.Strings(patterns)
+	return patterns
+}
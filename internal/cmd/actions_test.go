@@ -0,0 +1,197 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/twelvelabs/gh-setup/internal/core"
+	"github.com/twelvelabs/gh-setup/internal/gh"
+	"github.com/twelvelabs/gh-setup/internal/git"
+)
+
+func TestRootAction_EnsureActionsPermissions(t *testing.T) {
+	baseline := core.ActionsConfig{
+		Enabled:            true,
+		DefaultPermissions: "read",
+		AllowedActions:     "selected",
+		GithubOwnedAllowed: true,
+		PatternsAllowed:    []string{"some-org/*", "docker/*"},
+	}
+	compliant := actionsSettings{
+		Permissions: &gh.ActionsPermissions{Enabled: true, AllowedActions: gh.AllowedActionsSelected},
+		Selected: &gh.SelectedActions{
+			GithubOwnedAllowed: true,
+			PatternsAllowed:    []string{"docker/*", "some-org/*"},
+		},
+		Workflow: &gh.WorkflowPermissions{DefaultWorkflowPermissions: gh.WorkflowPermissionsRead},
+	}
+	permissive := actionsSettings{
+		Permissions: &gh.ActionsPermissions{Enabled: true, AllowedActions: gh.AllowedActionsAll},
+		Workflow: &gh.WorkflowPermissions{
+			DefaultWorkflowPermissions:   gh.WorkflowPermissionsWrite,
+			CanApprovePullRequestReviews: true,
+		},
+	}
+
+	tests := []struct {
+		desc     string
+		config   core.ActionsConfig
+		current  actionsSettings
+		getErr   error
+		setErr   error
+		confirm  bool
+		prompted []string
+		updated  []string
+		output   []string
+		err      string
+	}{
+		{
+			desc:    "does nothing when disabled",
+			config:  core.ActionsConfig{},
+			current: permissive,
+		},
+		{
+			desc:    "does nothing when the settings already match",
+			config:  baseline,
+			current: compliant,
+		},
+		{
+			desc:     "updates the settings that differ when confirmed",
+			config:   baseline,
+			current:  permissive,
+			confirm:  true,
+			prompted: []string{"Update the Actions settings?"},
+			updated: []string{
+				"workflow=read/false",
+				"permissions=true/selected",
+				"selected-actions=true/false/some-org/*,docker/*",
+			},
+			output: []string{
+				"- default_workflow_permissions = write",
+				"+ default_workflow_permissions = read",
+				"+ can_approve_pull_request_reviews = false",
+				"+ allowed_actions = selected",
+				"+ patterns_allowed = docker/*, some-org/*",
+				"Actions settings updated.",
+			},
+		},
+		{
+			desc:     "leaves the settings alone when declined",
+			config:   baseline,
+			current:  permissive,
+			prompted: []string{"Update the Actions settings?"},
+		},
+		{
+			desc: "only updates the selected actions when they differ",
+			config: core.ActionsConfig{
+				Enabled:            true,
+				DefaultPermissions: "read",
+				AllowedActions:     "selected",
+				VerifiedAllowed:    true,
+			},
+			current:  compliant,
+			confirm:  true,
+			prompted: []string{"Update the Actions settings?"},
+			updated:  []string{"selected-actions=false/true/"},
+			output: []string{
+				"+ github_owned_allowed = false",
+				"+ verified_allowed = true",
+			},
+		},
+		{
+			desc:   "leaves the allowed actions of repos with Actions disabled alone",
+			config: baseline,
+			current: actionsSettings{
+				Permissions: &gh.ActionsPermissions{Enabled: false},
+				Workflow:    compliant.Workflow,
+			},
+		},
+		{
+			desc:    "reports settings that are unavailable",
+			config:  baseline,
+			current: permissive,
+			getErr:  &gh.UnavailableError{Reason: "Not Found"},
+			output:  []string{"Actions settings: unavailable (Not Found)"},
+		},
+		{
+			desc:     "reports settings enforced by the org",
+			config:   baseline,
+			current:  permissive,
+			setErr:   &gh.UnavailableError{Reason: "The organization's policy does not allow this setting."},
+			confirm:  true,
+			prompted: []string{"Update the Actions settings?"},
+			updated:  []string{"workflow=read/false"},
+			output: []string{
+				"Actions settings: unavailable (The organization's policy does not allow this setting)",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			app := core.NewTestApp()
+			app.Config.Actions = tt.config
+			app.GitClient = &git.ClientMock{
+				HasRemoteFunc: func(name string) bool {
+					return true
+				},
+				RemoteURLFunc: func(name string) (string, error) {
+					return "https://github.com/some-org/some-repo.git", nil
+				},
+			}
+			updated := []string{}
+			app.GhClient = &gh.ClientMock{
+				GetRepoFunc: func(name string) (*gh.Repository, error) {
+					return &gh.Repository{FullName: name}, nil
+				},
+				GetActionsPermissionsFunc: func(name string) (*gh.ActionsPermissions, error) {
+					assert.Equal(t, "some-org/some-repo", name)
+					return tt.current.Permissions, tt.getErr
+				},
+				GetSelectedActionsFunc: func(name string) (*gh.SelectedActions, error) {
+					return tt.current.Selected, nil
+				},
+				GetWorkflowPermissionsFunc: func(name string) (*gh.WorkflowPermissions, error) {
+					return tt.current.Workflow, nil
+				},
+				SetActionsPermissionsFunc: func(name string, permissions *gh.ActionsPermissions) error {
+					updated = append(updated, fmt.Sprintf("permissions=%v/%s", permissions.Enabled, permissions.AllowedActions))
+					return tt.setErr
+				},
+				SetSelectedActionsFunc: func(name string, actions *gh.SelectedActions) error {
+					updated = append(updated, fmt.Sprintf(
+						"selected-actions=%v/%v/%s",
+						actions.GithubOwnedAllowed, actions.VerifiedAllowed, strings.Join(actions.PatternsAllowed, ","),
+					))
+					return tt.setErr
+				},
+				SetWorkflowPermissionsFunc: func(name string, permissions *gh.WorkflowPermissions) error {
+					updated = append(updated, fmt.Sprintf(
+						"workflow=%s/%v",
+						permissions.DefaultWorkflowPermissions, permissions.CanApprovePullRequestReviews,
+					))
+					return tt.setErr
+				},
+			}
+			p := stubConfirm(app, tt.confirm)
+			action := NewRootAction(app)
+
+			err := action.ensureActionsPermissions()
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+			assert.Equal(t, tt.prompted, confirmed(p))
+			if tt.updated == nil {
+				tt.updated = []string{}
+			}
+			assert.Equal(t, tt.updated, updated)
+			for _, s := range tt.output {
+				assert.Contains(t, app.IO.Out.String()+app.IO.Err.String(), s)
+			}
+		})
+	}
+}
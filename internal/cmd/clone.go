@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+func NewCloneCmd(root *RootAction) *cobra.Command {
+	action := NewCloneAction(root)
+
+	cmd := &cobra.Command{
+		Use:   "clone <owner/repo> [dir]",
+		Short: "Clone a GitHub repo and set it up locally",
+		Args:  cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := action.Setup(cmd, args); err != nil {
+				return err
+			}
+			if err := action.Validate(); err != nil {
+				return err
+			}
+			if err := action.Authenticate(cmd.Context()); err != nil {
+				return err
+			}
+			if err := action.Run(cmd.Context()); err != nil {
+				return err
+			}
+			return nil
+		},
+		SilenceUsage: true,
+	}
+
+	return cmd
+}
+
+// NewCloneAction returns a CloneAction sharing the flags
+// (and steps) of root.
+func NewCloneAction(root *RootAction) *CloneAction {
+	return &CloneAction{
+		RootAction: root,
+	}
+}
+
+type CloneAction struct {
+	*RootAction
+
+	// The repo to clone ("owner/name").
+	Repo string
+
+	// Whether the dir is created by the clone (and so should be
+	// removed if it fails).
+	created bool
+}
+
+func (a *CloneAction) Setup(cmd *cobra.Command, args []string) error {
+	if a.NoPrompt {
+		a.IO.SetInteractive(false)
+	}
+	a.Repo = args[0]
+	if err := a.validateRepo(); err != nil {
+		return err
+	}
+	dir := a.Repo[strings.Index(a.Repo, "/")+1:]
+	if len(args) > 1 {
+		dir = args[1]
+	}
+
+	entries, err := os.ReadDir(dir)
+	switch {
+	case os.IsNotExist(err):
+		// Created when cloning (after validation and auth).
+		a.created = true
+		return a.InitCloneGit(dir)
+	case err != nil:
+		return err
+	case len(entries) > 0:
+		return fmt.Errorf("destination path '%s' already exists and is not an empty directory", dir)
+	}
+	return a.InitGit(dir)
+}
+
+// validateRepo checks that the repo is in the form owner/name.
+func (a *CloneAction) validateRepo() error {
+	parts := strings.Split(a.Repo, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return fmt.Errorf("the repo to clone must be in the form owner/name")
+	}
+	return nil
+}
+
+func (a *CloneAction) Run(ctx context.Context) error {
+	return a.handleError(a.run(ctx))
+}
+
+func (a *CloneAction) run(ctx context.Context) error {
+	if err := a.ensureGitInstalled(); err != nil {
+		return err
+	}
+
+	if err := a.clone(ctx); err != nil {
+		return err
+	}
+
+	if err := a.ensureGitConfig(ctx); err != nil {
+		return err
+	}
+
+	if err := a.ensureHooks(ctx); err != nil {
+		return err
+	}
+
+	if err := a.runPostSetup(ctx); err != nil {
+		return err
+	}
+
+	a.Messenger.Success("Setup complete.\n")
+	return nil
+}
+
+// clone clones the repo using the preferred git protocol of the user,
+// adding the upstream remote if it is a fork.
+func (a *CloneAction) clone(ctx context.Context) error {
+	dir, err := a.workingDir()
+	if err != nil {
+		return err
+	}
+	cloned := false
+	defer func() {
+		// Like git, don't leave an empty dir behind.
+		if !cloned && a.created {
+			_ = os.RemoveAll(dir)
+		}
+	}()
+
+	repo, err := a.GhClient.GetRepo(a.Repo)
+	if err != nil {
+		return err
+	}
+	if repo == nil {
+		a.Messenger.Failure("Unable to clone: the '%s' repo does not exist.\n", a.Repo)
+		return ErrAborted
+	}
+	user, err := a.currentUser()
+	if err != nil {
+		return err
+	}
+	protocol, err := a.ensureSSHKey(user.GitProtocol)
+	if err != nil {
+		return err
+	}
+
+	a.IO.StartProgressIndicatorWithLabel("Cloning")
+	err = a.GitClient.Clone(ctx, repo.RemoteURL(protocol), a.gitProgress("Cloning"))
+	a.IO.StopProgressIndicator()
+	if err != nil {
+		return err
+	}
+	cloned = true
+	a.Messenger.Success("Cloned %s into %s\n", repo.FullName, filepath.Base(dir))
+
+	if repo.Fork && repo.Parent != nil {
+		if err := a.ensureRemoteURL(ctx, upstreamRemote, repo.Parent.RemoteURL(protocol)); err != nil {
+			return err
+		}
+		if err := a.trackUpstream(ctx, repo.Parent.DefaultBranch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
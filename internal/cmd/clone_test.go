@@ -0,0 +1,237 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twelvelabs/termite/testutil"
+	uimock "github.com/twelvelabs/termite/ui/mock"
+
+	"github.com/twelvelabs/gh-setup/internal/core"
+	"github.com/twelvelabs/gh-setup/internal/gh"
+	"github.com/twelvelabs/gh-setup/internal/git"
+)
+
+func TestCloneAction_Setup(t *testing.T) {
+	tests := []struct {
+		desc    string
+		args    []string
+		setup   func(t *testing.T)
+		dir     string
+		created bool
+		err     string
+	}{
+		{
+			desc:    "clones into a dir named after the repo by default",
+			args:    []string{"some-org/some-repo"},
+			dir:     "some-repo",
+			created: true,
+		},
+		{
+			desc:    "clones into the dir when given",
+			args:    []string{"some-org/some-repo", "some-project"},
+			dir:     "some-project",
+			created: true,
+		},
+		{
+			desc: "allows existing empty dirs",
+			args: []string{"some-org/some-repo"},
+			setup: func(t *testing.T) {
+				t.Helper()
+				require.NoError(t, os.Mkdir("some-repo", 0750))
+			},
+			dir: "some-repo",
+		},
+		{
+			desc: "returns an error if the dir is not empty",
+			args: []string{"some-org/some-repo"},
+			setup: func(t *testing.T) {
+				t.Helper()
+				require.NoError(t, os.Mkdir("some-repo", 0750))
+				require.NoError(t, os.WriteFile(filepath.Join("some-repo", "foo.txt"), []byte("aaa"), 0600))
+			},
+			err: "destination path 'some-repo' already exists and is not an empty directory",
+		},
+		{
+			desc: "returns an error for invalid repo names",
+			args: []string{"some-repo"},
+			err:  "the repo to clone must be in the form owner/name",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			testutil.InTempDir(t, func(tmpDir string) {
+				if tt.setup != nil {
+					tt.setup(t)
+				}
+				app := core.NewTestApp()
+				action := NewCloneAction(NewRootAction(app))
+
+				err := action.Setup(NewCloneCmd(action.RootAction), tt.args)
+
+				if tt.err != "" {
+					assert.ErrorContains(t, err, tt.err)
+					return
+				}
+				assert.NoError(t, err)
+				assert.True(t, filepath.IsAbs(action.Dir))
+				assert.Equal(t, tt.dir, filepath.Base(action.Dir))
+				assert.Equal(t, tt.created, action.created)
+				if tt.created {
+					// not until cloning
+					assert.NoDirExists(t, action.Dir)
+				} else {
+					assert.DirExists(t, action.Dir)
+				}
+			})
+		})
+	}
+}
+
+func TestCloneAction_Run(t *testing.T) {
+	ctx := context.Background()
+	identity := git.WithEnv(
+		"GIT_AUTHOR_NAME=Test User", "GIT_AUTHOR_EMAIL=test@example.com",
+		"GIT_COMMITTER_NAME=Test User", "GIT_COMMITTER_EMAIL=test@example.com",
+	)
+
+	tests := []struct {
+		desc       string
+		fork       bool
+		missing    bool
+		config     map[string]string
+		postSetup  []string
+		assertions func(t *testing.T, dir string, client git.Client)
+		output     []string
+		err        string
+	}{
+		{
+			desc:    "aborts when the repo does not exist",
+			missing: true,
+			output:  []string{"Unable to clone: the 'some-org/some-repo' repo does not exist."},
+			err:     "aborted",
+			assertions: func(t *testing.T, dir string, client git.Client) {
+				t.Helper()
+				assert.NoDirExists(t, dir)
+			},
+		},
+		{
+			desc: "clones the repo",
+			assertions: func(t *testing.T, dir string, client git.Client) {
+				t.Helper()
+				assert.True(t, client.HasCommits())
+				assert.FileExists(t, filepath.Join(dir, "README.md"))
+				assert.False(t, client.HasRemote("upstream"))
+			},
+			output: []string{
+				"Cloned some-org/some-repo into some-repo",
+				"Setup complete.",
+			},
+		},
+		{
+			desc: "adds the upstream remote for forks",
+			fork: true,
+			assertions: func(t *testing.T, dir string, client git.Client) {
+				t.Helper()
+				url, err := client.RemoteURL("upstream")
+				assert.NoError(t, err)
+				assert.Equal(t, "https://github.com/other-org/some-repo.git", url)
+			},
+			output: []string{"Remote added: upstream https://github.com/other-org/some-repo.git"},
+		},
+		{
+			desc: "applies the git config and runs the post-setup scripts",
+			config: map[string]string{
+				"pull.rebase":     "true",
+				"commit.template": ".gitmessage",
+			},
+			postSetup: []string{"echo done > post-setup.txt"},
+			assertions: func(t *testing.T, dir string, client git.Client) {
+				t.Helper()
+				value, err := client.Config("pull.rebase")
+				assert.NoError(t, err)
+				assert.Equal(t, "true", value)
+				value, err = client.Config("commit.template")
+				assert.NoError(t, err)
+				assert.Equal(t, ".gitmessage", value)
+				assert.FileExists(t, filepath.Join(dir, "post-setup.txt"))
+			},
+			output: []string{
+				"Git config updated.",
+				"Running: echo done > post-setup.txt",
+			},
+		},
+		{
+			desc:      "aborts when a post-setup script fails",
+			postSetup: []string{"exit 3", "echo done > post-setup.txt"},
+			assertions: func(t *testing.T, dir string, client git.Client) {
+				t.Helper()
+				assert.NoFileExists(t, filepath.Join(dir, "post-setup.txt"))
+			},
+			output: []string{"The post-setup script failed (exit status 3): exit 3"},
+			err:    "aborted",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			testutil.InTempDir(t, func(tmpDir string) {
+				// A "GitHub" repo with a commit.
+				srcDir := filepath.Join(tmpDir, "src")
+				require.NoError(t, os.Mkdir(srcDir, 0750))
+				src := git.NewClient(srcDir, identity)
+				require.NoError(t, src.Init(ctx))
+				require.NoError(t, os.WriteFile(filepath.Join(srcDir, "README.md"), []byte("# hi"), 0600))
+				require.NoError(t, src.Add(ctx, "."))
+				require.NoError(t, src.Commit(ctx, "Initial commit", git.CommitOptions{NoSign: true, NoVerify: true}))
+				repo := &gh.Repository{
+					Name:     "some-repo",
+					FullName: "some-org/some-repo",
+					CloneURL: "file://" + filepath.ToSlash(srcDir),
+					Fork:     tt.fork,
+				}
+				if tt.fork {
+					repo.Parent = &gh.Repository{
+						FullName: "other-org/some-repo",
+						CloneURL: "https://github.com/other-org/some-repo.git",
+					}
+				}
+
+				app := core.NewTestApp()
+				app.Config.Git.Config = tt.config
+				app.Config.PostSetup = tt.postSetup
+				app.GhClient = NewClientMock()
+				ghc := app.GhClient.(*gh.ClientMock)
+				ghc.GetRepoFunc = func(name string) (*gh.Repository, error) {
+					assert.Equal(t, "some-org/some-repo", name)
+					if tt.missing {
+						return nil, nil
+					}
+					return repo, nil
+				}
+				p := app.Prompter.(*uimock.PrompterMock)
+				p.ConfirmFunc = func(msg string, value bool, help string) (bool, error) {
+					return true, nil
+				}
+				action := NewCloneAction(NewRootAction(app))
+				require.NoError(t, action.Setup(NewCloneCmd(action.RootAction), []string{"some-org/some-repo"}))
+
+				err := action.Run(ctx)
+				if tt.err == "" {
+					assert.NoError(t, err)
+				} else {
+					assert.ErrorContains(t, err, tt.err)
+				}
+				for _, s := range tt.output {
+					assert.Contains(t, app.IO.Out.String(), s)
+				}
+				if tt.assertions != nil {
+					tt.assertions(t, action.Dir, action.GitClient)
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,232 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/twelvelabs/gh-setup/internal/core"
+	"github.com/twelvelabs/gh-setup/internal/gh"
+)
+
+const (
+	codeOwnersPath = ".github/CODEOWNERS"
+)
+
+var (
+	// The locations GitHub looks for CODEOWNERS in (the first found is used).
+	codeOwnersPaths = []string{codeOwnersPath, "CODEOWNERS", "docs/CODEOWNERS"}
+)
+
+// codeOwnersEntry is an owner referenced on a line of a CODEOWNERS file.
+type codeOwnersEntry struct {
+	Line  int
+	Owner string
+}
+
+// ensureCodeOwners generates the configured CODEOWNERS file for org repos,
+// then checks that every team and user it references is one GitHub will
+// honor (GitHub silently ignores the rest), offering to grant write access
+// to teams that lack it.
+func (a *RootAction) ensureCodeOwners() error {
+	repo, err := a.remoteRepo()
+	if err != nil {
+		return err
+	}
+	if repo == nil || repo.Owner == nil || repo.Owner.Type != gh.AccountTypeOrg {
+		return nil // only org repos have teams
+	}
+	root, err := a.workingDir()
+	if err != nil {
+		return err
+	}
+	if err := a.ensureCodeOwnersFile(root); err != nil {
+		return err
+	}
+	return a.checkCodeOwners(root, repo)
+}
+
+// ensureCodeOwnersFile writes the configured rules to .github/CODEOWNERS,
+// previewing the file and prompting first.
+func (a *RootAction) ensureCodeOwnersFile(root string) error {
+	if len(a.Config.CodeOwners) == 0 {
+		return nil // nothing configured
+	}
+	content := renderCodeOwners(a.Config.CodeOwners)
+	path := filepath.Join(root, filepath.FromSlash(codeOwnersPath))
+	current, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if bytes.Equal(current, content) {
+		return nil // already generated
+	}
+
+	exists := err == nil
+	if exists {
+		a.Messenger.Info("The %s file differs from the configured rules:\n", codeOwnersPath)
+	} else {
+		a.Messenger.Info("Generated a %s file:\n", codeOwnersPath)
+	}
+	fmt.Fprintf(a.IO.Err, "\n%s\n", content)
+	msg := fmt.Sprintf("Add %s?", codeOwnersPath)
+	if exists {
+		msg = fmt.Sprintf("Overwrite %s?", codeOwnersPath)
+	}
+	// Don't replace hand edited files unless asked.
+	ok, err := a.Prompter.Confirm(msg, !exists, "")
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, content, 0o644); err != nil { //nolint: gosec // not a secret
+		return err
+	}
+	a.Messenger.Success("Code owners added: %s\n", codeOwnersPath)
+	return nil
+}
+
+// checkCodeOwners warns about the owners in the CODEOWNERS file of repo
+// that GitHub will ignore.
+func (a *RootAction) checkCodeOwners(root string, repo *gh.Repository) error {
+	path, entries, err := readCodeOwners(root)
+	if err != nil || path == "" {
+		return err
+	}
+	org := repo.Owner.Login
+
+	var repoTeams []*gh.Team
+	teams := map[string]*gh.Team{}
+	collaborators := map[string]bool{}
+	needsAccess := []string{}
+	for _, entry := range entries {
+		owner := entry.Owner
+		switch {
+		case strings.HasPrefix(owner, "@") && strings.Contains(owner, "/"):
+			teamOrg, slug, _ := strings.Cut(owner[1:], "/")
+			if !strings.EqualFold(teamOrg, org) {
+				a.codeOwnersWarning(path, entry, "%s is not a team in the %s org", owner, org)
+				continue
+			}
+			team, ok := teams[slug]
+			if !ok {
+				team, err = a.GhClient.GetTeam(org, slug)
+				if err != nil {
+					return err
+				}
+				teams[slug] = team
+			}
+			if team == nil {
+				a.codeOwnersWarning(path, entry, "the %s team does not exist", owner)
+				continue
+			}
+			if repoTeams == nil {
+				repoTeams, err = a.GhClient.ListRepoTeams(repo.FullName)
+				if err != nil {
+					return err
+				}
+			}
+			if !teamCanWrite(repoTeams, team.Slug) {
+				a.codeOwnersWarning(path, entry, "the %s team does not have write access to %s", owner, repo.FullName)
+				if !contains(needsAccess, team.Slug) {
+					needsAccess = append(needsAccess, team.Slug)
+				}
+			}
+		case strings.HasPrefix(owner, "@"):
+			login := owner[1:]
+			ok, checked := collaborators[login]
+			if !checked {
+				ok, err = a.GhClient.IsCollaborator(repo.FullName, login)
+				if err != nil {
+					return err
+				}
+				collaborators[login] = ok
+			}
+			if !ok {
+				a.codeOwnersWarning(path, entry, "%s is not a collaborator on %s", owner, repo.FullName)
+			}
+		case strings.Contains(owner, "@"):
+			// An email - only matched to users with it verified (which can't be checked).
+		default:
+			a.codeOwnersWarning(path, entry, "%s is not a valid owner (expected @user, @org/team or an email)", owner)
+		}
+	}
+
+	for _, slug := range needsAccess {
+		ok, err := a.Prompter.Confirm(
+			fmt.Sprintf("Grant the @%s/%s team write access to %s?", org, slug, repo.FullName), true, "",
+		)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		if err := a.GhClient.SetTeamPermission(org, slug, repo.FullName, "push"); err != nil {
+			return err
+		}
+		a.Messenger.Success("Granted the @%s/%s team write access.\n", org, slug)
+	}
+	return nil
+}
+
+func (a *RootAction) codeOwnersWarning(path string, entry codeOwnersEntry, format string, args ...any) {
+	msg := fmt.Sprintf(format, args...)
+	a.Messenger.Warning("%s:%d: %s, so GitHub will ignore it.\n", path, entry.Line, msg)
+}
+
+// renderCodeOwners returns the content of a CODEOWNERS file for rules.
+func renderCodeOwners(rules []core.CodeOwnersRule) []byte {
+	buf := &bytes.Buffer{}
+	for _, rule := range rules {
+		fmt.Fprintf(buf, "%s %s\n", rule.Path, strings.Join(rule.Owners, " "))
+	}
+	return buf.Bytes()
+}
+
+// readCodeOwners returns the path (relative to root) of the CODEOWNERS file
+// GitHub uses, and the owners on each of its lines.
+// The path is empty if there is no CODEOWNERS file.
+func readCodeOwners(root string) (string, []codeOwnersEntry, error) {
+	for _, path := range codeOwnersPaths {
+		content, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(path)))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return "", nil, err
+		}
+		entries := []codeOwnersEntry{}
+		for i, line := range strings.Split(string(content), "\n") {
+			if before, _, found := strings.Cut(line, "#"); found {
+				line = before
+			}
+			fields := strings.Fields(line)
+			if len(fields) < 2 {
+				continue // blank, a comment, or a path without owners
+			}
+			for _, owner := range fields[1:] {
+				entries = append(entries, codeOwnersEntry{Line: i + 1, Owner: owner})
+			}
+		}
+		return path, entries, nil
+	}
+	return "", nil, nil
+}
+
+// teamCanWrite returns true if the team slug has write access in teams.
+func teamCanWrite(teams []*gh.Team, slug string) bool {
+	for _, team := range teams {
+		if strings.EqualFold(team.Slug, slug) {
+			return team.CanWrite()
+		}
+	}
+	return false
+}
@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/twelvelabs/gh-setup/internal/core"
+	"github.com/twelvelabs/gh-setup/internal/gh"
+	"github.com/twelvelabs/gh-setup/internal/git"
+)
+
+func TestRootAction_EnsureCodeOwners(t *testing.T) {
+	orgRepo := &gh.Repository{
+		FullName: "some-org/some-repo",
+		Owner:    &gh.Account{Login: "some-org", Type: gh.AccountTypeOrg},
+	}
+	userRepo := &gh.Repository{
+		FullName: "some-user/some-repo",
+		Owner:    &gh.Account{Login: "some-user", Type: gh.AccountTypeUser},
+	}
+	rules := []core.CodeOwnersRule{
+		{Path: "*", Owners: []string{"@some-org/core"}},
+		{Path: "/docs/", Owners: []string{"@some-org/admins", "@some-user"}},
+	}
+	generated := "* @some-org/core\n/docs/ @some-org/admins @some-user\n"
+
+	tests := []struct {
+		desc     string
+		repo     *gh.Repository
+		rules    []core.CodeOwnersRule
+		files    map[string]string
+		confirm  bool
+		prompted []string
+		granted  []string
+		expected string
+		output   []string
+		err      string
+	}{
+		{
+			desc:     "does nothing for user repos",
+			repo:     userRepo,
+			rules:    rules,
+			expected: "",
+		},
+		{
+			desc:     "generates the file when confirmed",
+			repo:     orgRepo,
+			rules:    rules,
+			confirm:  true,
+			prompted: []string{"Add .github/CODEOWNERS?"},
+			expected: generated,
+			output:   []string{"Code owners added: .github/CODEOWNERS"},
+		},
+		{
+			desc:     "does not overwrite an edited file unless confirmed",
+			repo:     orgRepo,
+			rules:    rules,
+			files:    map[string]string{".github/CODEOWNERS": "* @some-org/core\n"},
+			prompted: []string{"Overwrite .github/CODEOWNERS?"},
+			expected: "* @some-org/core\n",
+		},
+		{
+			desc:     "does nothing when the file is up to date and valid",
+			repo:     orgRepo,
+			rules:    rules,
+			files:    map[string]string{".github/CODEOWNERS": generated},
+			expected: generated,
+		},
+		{
+			desc: "flags owners that GitHub will ignore",
+			repo: orgRepo,
+			files: map[string]string{
+				"docs/CODEOWNERS": "# Owners\n" +
+					"* @some-org/core @some-org/missing  # inline comment\n" +
+					"/docs/ @some-org/docs @other-org/docs docs@example.com\n" +
+					"/src/ @some-user @other-user nobody\n",
+			},
+			prompted: []string{"Grant the @some-org/docs team write access to some-org/some-repo?"},
+			output: []string{
+				"docs/CODEOWNERS:2: the @some-org/missing team does not exist, so GitHub will ignore it.",
+				"docs/CODEOWNERS:3: the @some-org/docs team does not have write access to some-org/some-repo",
+				"docs/CODEOWNERS:3: @other-org/docs is not a team in the some-org org",
+				"docs/CODEOWNERS:4: @other-user is not a collaborator on some-org/some-repo",
+				"docs/CODEOWNERS:4: nobody is not a valid owner",
+			},
+		},
+		{
+			desc:     "grants write access when confirmed",
+			repo:     orgRepo,
+			files:    map[string]string{".github/CODEOWNERS": "* @some-org/docs\n/docs/ @some-org/docs\n"},
+			confirm:  true,
+			prompted: []string{"Grant the @some-org/docs team write access to some-org/some-repo?"},
+			granted:  []string{"some-org/docs/some-org/some-repo=push"},
+			expected: "* @some-org/docs\n/docs/ @some-org/docs\n",
+			output:   []string{"Granted the @some-org/docs team write access."},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			root := t.TempDir()
+			for name, content := range tt.files {
+				path := filepath.Join(root, name)
+				require.NoError(t, os.MkdirAll(filepath.Dir(path), 0750))
+				require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+			}
+
+			app := core.NewTestApp()
+			app.Dir = root
+			app.Config.CodeOwners = tt.rules
+			app.GitClient = &git.ClientMock{
+				HasRemoteFunc: func(name string) bool {
+					return true
+				},
+				RemoteURLFunc: func(name string) (string, error) {
+					return "https://github.com/" + tt.repo.FullName + ".git", nil
+				},
+			}
+			granted := []string{}
+			app.GhClient = &gh.ClientMock{
+				GetRepoFunc: func(name string) (*gh.Repository, error) {
+					assert.Equal(t, tt.repo.FullName, name)
+					return tt.repo, nil
+				},
+				GetTeamFunc: func(org string, slug string) (*gh.Team, error) {
+					if slug == "missing" {
+						return nil, nil
+					}
+					return &gh.Team{Slug: slug}, nil
+				},
+				ListRepoTeamsFunc: func(name string) ([]*gh.Team, error) {
+					return []*gh.Team{
+						{Slug: "admins", Permission: "admin"},
+						{Slug: "core", Permission: "maintain"},
+						{Slug: "docs", Permission: "pull"},
+					}, nil
+				},
+				IsCollaboratorFunc: func(name string, login string) (bool, error) {
+					return login == "some-user", nil
+				},
+				SetTeamPermissionFunc: func(org string, slug string, name string, permission string) error {
+					granted = append(granted, org+"/"+slug+"/"+name+"="+permission)
+					return nil
+				},
+			}
+			p := stubConfirm(app, tt.confirm)
+			action := NewRootAction(app)
+
+			err := action.ensureCodeOwners()
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+			assert.Equal(t, tt.prompted, confirmed(p))
+			if tt.granted == nil {
+				tt.granted = []string{}
+			}
+			assert.Equal(t, tt.granted, granted)
+			if tt.files == nil || tt.files[".github/CODEOWNERS"] != "" {
+				content, _ := os.ReadFile(filepath.Join(root, ".github", "CODEOWNERS"))
+				assert.Equal(t, tt.expected, string(content))
+			}
+			for _, s := range tt.output {
+				assert.Contains(t, app.IO.Out.String(), s)
+			}
+		})
+	}
+}
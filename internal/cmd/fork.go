@@ -0,0 +1,214 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/twelvelabs/gh-setup/internal/gh"
+)
+
+const (
+	// The name of the remote for the repo being forked.
+	upstreamRemote = "upstream"
+)
+
+var (
+	// for stubbing
+	forkPollInterval = 2 * time.Second
+	forkTimeout      = 2 * time.Minute
+)
+
+// validateFork checks the --fork flag.
+func (a *RootAction) validateFork() error {
+	if a.Fork == "" {
+		return nil
+	}
+	parts := strings.Split(a.Fork, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return fmt.Errorf("--fork must be a repo in the form owner/name")
+	}
+	if a.Config.Remote == upstreamRemote {
+		return fmt.Errorf("--remote can not be '%s' when using --fork", upstreamRemote)
+	}
+	return nil
+}
+
+// ensureFork configures the remote as a fork of the --fork repo
+// (forking it if needed) and the upstream remote as the source repo,
+// which it returns.
+func (a *RootAction) ensureFork(ctx context.Context, remote string) (*gh.Repository, error) {
+	source, err := a.GhClient.GetRepo(a.Fork)
+	if err != nil {
+		return nil, err
+	}
+	if source == nil {
+		a.Messenger.Failure("Unable to fork: the '%s' repo does not exist.\n", a.Fork)
+		return nil, ErrAborted
+	}
+
+	exists := false
+	if a.GitClient.HasRemote(remote) {
+		exists, err = a.checkRemote(ctx, remote)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !exists {
+		if err := a.createFork(ctx, remote, source); err != nil {
+			return nil, err
+		}
+	}
+
+	url, err := a.GitClient.RemoteURL(remote)
+	if err != nil {
+		return nil, err
+	}
+	protocol := gh.URLProtocol(url)
+	if protocol == gh.ProtocolUnknown {
+		protocol = gh.ProtocolHTTPS
+	}
+	if err := a.ensureRemoteURL(ctx, upstreamRemote, source.RemoteURL(protocol)); err != nil {
+		return nil, err
+	}
+
+	if exists {
+		// Only existing forks can be behind - new ones are already in sync.
+		return source, a.promptToSyncFork(url, source)
+	}
+	return source, nil
+}
+
+// createFork forks source into an owner chosen by the user,
+// waits for it to be ready, and adds it as the remote.
+func (a *RootAction) createFork(ctx context.Context, remote string, source *gh.Repository) error {
+	user, err := a.currentUser()
+	if err != nil {
+		return err
+	}
+	owners := []string{user.Login}
+	for _, org := range user.Orgs {
+		owners = append(owners, org.Login)
+	}
+	defaultOwner := user.Login
+	if a.Config.Owner != "" && contains(owners, a.Config.Owner) {
+		defaultOwner = a.Config.Owner
+	}
+
+	ok, err := a.Prompter.Confirm(fmt.Sprintf("Fork %s on GitHub?", source.FullName), true, "")
+	if err != nil {
+		return err
+	}
+	if !ok {
+		a.Messenger.Failure("Unable to continue until a remote has been configured.\n")
+		return ErrAborted
+	}
+	owner, err := a.Prompter.Select("GitHub fork owner", owners, defaultOwner, "")
+	if err != nil {
+		return err
+	}
+	name, err := a.Prompter.Input("GitHub fork name", source.Name, "")
+	if err != nil {
+		return err
+	}
+	org := ""
+	if owner != user.Login {
+		org = owner
+	}
+
+	a.IO.StartProgressIndicatorWithLabel("Forking repo")
+	fork, err := a.GhClient.CreateFork(source.FullName, org, name)
+	if err == nil {
+		err = a.waitForFork(ctx, fork)
+	}
+	a.IO.StopProgressIndicator()
+	if err != nil {
+		return err
+	}
+	a.Messenger.Success("Repo forked: %s\n", fork.URL)
+
+	return a.setRemote(ctx, remote, fork, user)
+}
+
+// waitForFork waits until the git objects of a new fork are available.
+func (a *RootAction) waitForFork(ctx context.Context, fork *gh.Repository) error {
+	if fork.DefaultBranch == "" {
+		return nil // empty repo - nothing to copy
+	}
+	timeout := time.After(forkTimeout)
+	for {
+		ok, err := a.GhClient.HasBranch(fork.FullName, fork.DefaultBranch)
+		if err != nil {
+			return err
+		}
+		if ok {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timeout:
+			return fmt.Errorf("timed out waiting for the %s fork to be ready", fork.FullName)
+		case <-time.After(forkPollInterval):
+		}
+	}
+}
+
+// promptToSyncFork offers to sync the default branch of the fork at url
+// with the source repo.
+func (a *RootAction) promptToSyncFork(url string, source *gh.Repository) error {
+	_, fullName, err := gh.ParseRemoteURL(url)
+	if err != nil || source.DefaultBranch == "" {
+		return nil // not a GitHub repo (or nothing to sync)
+	}
+	fork, err := a.GhClient.GetRepo(fullName)
+	if err != nil {
+		return err
+	}
+	if fork == nil || !fork.Fork {
+		a.Messenger.Warning("The %s repo is not a fork of %s.\n", fullName, source.FullName)
+		return nil
+	}
+	ok, err := a.Prompter.Confirm(
+		fmt.Sprintf("Sync %s with the latest changes from %s?", fork.FullName, source.FullName), false, "",
+	)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return nil
+	}
+	a.IO.StartProgressIndicatorWithLabel("Syncing fork")
+	err = a.GhClient.SyncFork(fork.FullName, source.DefaultBranch)
+	a.IO.StopProgressIndicator()
+	if err != nil {
+		return err
+	}
+	a.Messenger.Success("Fork synced: %s\n", fork.FullName)
+	return nil
+}
+
+// trackUpstream fetches the upstream remote and sets the current branch
+// to track its default branch. In a repo without commits, that branch is
+// checked out from upstream first, so that local commits build on it
+// (and can be pushed to the fork).
+func (a *RootAction) trackUpstream(ctx context.Context, branch string) error {
+	if os.Getenv("APP_ENV") != EnvTest {
+		a.IO.StartProgressIndicatorWithLabel("Fetching")
+		err := a.GitClient.Fetch(ctx, upstreamRemote, a.gitProgress("Fetching"))
+		a.IO.StopProgressIndicator()
+		if err != nil {
+			return err
+		}
+	}
+	if branch != "" && !a.GitClient.HasCommits() {
+		err := a.GitClient.Checkout(ctx, branch, fmt.Sprintf("%s/%s", upstreamRemote, branch))
+		if err != nil {
+			return err
+		}
+		a.Messenger.Success("Checked out %s from %s\n", branch, upstreamRemote)
+	}
+	return a.setRemoteHead(ctx, upstreamRemote)
+}
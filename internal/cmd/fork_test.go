@@ -0,0 +1,377 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twelvelabs/termite/testutil"
+	uimock "github.com/twelvelabs/termite/ui/mock"
+
+	"github.com/twelvelabs/gh-setup/internal/core"
+	"github.com/twelvelabs/gh-setup/internal/gh"
+	"github.com/twelvelabs/gh-setup/internal/git"
+)
+
+func TestRootAction_ValidateFork(t *testing.T) {
+	tests := []struct {
+		desc    string
+		fork    string
+		remote  string
+		remotes []core.RemoteConfig
+		err     string
+	}{
+		{
+			desc: "passes when not forking",
+		},
+		{
+			desc: "passes for a repo name",
+			fork: "other-org/some-repo",
+		},
+		{
+			desc: "requires a full repo name",
+			fork: "some-repo",
+			err:  "--fork must be a repo in the form owner/name",
+		},
+		{
+			desc:   "does not allow forking into the upstream remote",
+			fork:   "other-org/some-repo",
+			remote: "upstream",
+			err:    "--remote can not be 'upstream' when using --fork",
+		},
+		{
+			desc:    "does not allow declaring the upstream remote",
+			fork:    "other-org/some-repo",
+			remotes: []core.RemoteConfig{{Name: "upstream", Repo: "other-org/some-repo"}},
+			err:     "the 'upstream' remote is declared more than once",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			app := core.NewTestApp()
+			if tt.remote != "" {
+				app.Config.Remote = tt.remote
+			}
+			app.Config.Remotes = tt.remotes
+			action := NewRootAction(app)
+			action.Fork = tt.fork
+
+			err := action.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestRootAction_EnsureFork(t *testing.T) {
+	ctx := context.Background()
+	source := &gh.Repository{
+		Name:          "some-repo",
+		FullName:      "other-org/some-repo",
+		CloneURL:      "https://github.com/other-org/some-repo.git",
+		DefaultBranch: "main",
+	}
+	fork := &gh.Repository{
+		Name:          "some-repo",
+		FullName:      "org1/some-repo",
+		URL:           "https://github.com/org1/some-repo",
+		CloneURL:      "https://github.com/org1/some-repo.git",
+		Fork:          true,
+		DefaultBranch: "main",
+	}
+
+	tests := []struct {
+		desc     string
+		existing map[string]string
+		source   *gh.Repository
+		confirm  bool
+		owner    string
+		forkOrg  string
+		synced   bool
+		expected map[string]string
+		output   []string
+		err      string
+	}{
+		{
+			desc:   "aborts when the source repo does not exist",
+			output: []string{"Unable to fork: the 'other-org/some-repo' repo does not exist."},
+			err:    "aborted",
+		},
+		{
+			desc:    "aborts when the fork is declined",
+			source:  source,
+			confirm: false,
+			output:  []string{"Unable to continue until a remote has been configured."},
+			err:     "aborted",
+		},
+		{
+			desc:    "forks into the chosen owner and adds both remotes",
+			source:  source,
+			confirm: true,
+			owner:   "org1",
+			forkOrg: "org1",
+			expected: map[string]string{
+				"origin":   "https://github.com/org1/some-repo.git",
+				"upstream": "https://github.com/other-org/some-repo.git",
+			},
+			output: []string{
+				"Repo forked: https://github.com/org1/some-repo",
+				"Remote added: origin https://github.com/org1/some-repo.git",
+				"Remote added: upstream https://github.com/other-org/some-repo.git",
+			},
+		},
+		{
+			desc:    "forks into the current user",
+			source:  source,
+			confirm: true,
+			owner:   "test-user",
+			forkOrg: "",
+			expected: map[string]string{
+				"origin":   "https://github.com/org1/some-repo.git",
+				"upstream": "https://github.com/other-org/some-repo.git",
+			},
+		},
+		{
+			desc: "syncs an existing fork when confirmed",
+			existing: map[string]string{
+				"origin": "https://github.com/org1/some-repo.git",
+			},
+			source:  source,
+			confirm: true,
+			synced:  true,
+			expected: map[string]string{
+				"origin":   "https://github.com/org1/some-repo.git",
+				"upstream": "https://github.com/other-org/some-repo.git",
+			},
+			output: []string{"Fork synced: org1/some-repo"},
+		},
+		{
+			desc: "does not sync an existing fork when declined",
+			existing: map[string]string{
+				"origin": "https://github.com/org1/some-repo.git",
+			},
+			source:  source,
+			confirm: false,
+			expected: map[string]string{
+				"origin":   "https://github.com/org1/some-repo.git",
+				"upstream": "https://github.com/other-org/some-repo.git",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			testutil.InTempDir(t, func(dir string) {
+				app := core.NewTestApp()
+				app.GitClient = git.NewClient(dir)
+				_, _, err := app.GitClient.Exec("init")
+				require.NoError(t, err)
+				for name, url := range tt.existing {
+					require.NoError(t, app.GitClient.AddRemote(ctx, name, url))
+				}
+
+				app.GhClient = NewClientMock()
+				ghc := app.GhClient.(*gh.ClientMock)
+				ghc.GetRepoFunc = func(name string) (*gh.Repository, error) {
+					if name == fork.FullName {
+						return fork, nil
+					}
+					return tt.source, nil
+				}
+				ghc.CreateForkFunc = func(name string, org string, forkName string) (*gh.Repository, error) {
+					assert.Equal(t, source.FullName, name)
+					assert.Equal(t, tt.forkOrg, org)
+					assert.Equal(t, source.Name, forkName)
+					return fork, nil
+				}
+				ghc.HasBranchFunc = func(name string, branch string) (bool, error) {
+					return true, nil
+				}
+				ghc.SyncForkFunc = func(name string, branch string) error {
+					assert.Equal(t, fork.FullName, name)
+					assert.Equal(t, "main", branch)
+					return nil
+				}
+				p := app.Prompter.(*uimock.PrompterMock)
+				p.ConfirmFunc = func(msg string, value bool, help string) (bool, error) {
+					return tt.confirm, nil
+				}
+				p.SelectFunc = func(msg string, options []string, value string, help string) (string, error) {
+					return tt.owner, nil
+				}
+				p.InputFunc = func(msg string, value string, help string) (string, error) {
+					return value, nil
+				}
+				action := NewRootAction(app)
+				action.Fork = "other-org/some-repo"
+
+				_, err = action.ensureFork(ctx, "origin")
+				if tt.err == "" {
+					assert.NoError(t, err)
+				} else {
+					assert.ErrorContains(t, err, tt.err)
+				}
+				for name, url := range tt.expected {
+					actual, err := app.GitClient.RemoteURL(name)
+					assert.NoError(t, err)
+					assert.Equal(t, url, actual)
+				}
+				for _, s := range tt.output {
+					assert.Contains(t, app.IO.Out.String(), s)
+				}
+				assert.Equal(t, tt.synced, len(ghc.SyncForkCalls()) == 1)
+			})
+		})
+	}
+}
+
+func TestRootAction_WaitForFork(t *testing.T) {
+	defer func(interval, timeout time.Duration) {
+		forkPollInterval, forkTimeout = interval, timeout
+	}(forkPollInterval, forkTimeout)
+	forkPollInterval = time.Millisecond
+	fork := &gh.Repository{FullName: "org1/some-repo", DefaultBranch: "main"}
+
+	tests := []struct {
+		desc    string
+		fork    *gh.Repository
+		timeout time.Duration
+		ready   int
+		err     error
+		calls   int
+		errMsg  string
+	}{
+		{
+			desc:  "returns immediately for empty repos",
+			fork:  &gh.Repository{FullName: "org1/some-repo"},
+			calls: 0,
+		},
+		{
+			desc:    "polls until the default branch exists",
+			fork:    fork,
+			timeout: time.Second,
+			ready:   3,
+			calls:   3,
+		},
+		{
+			desc:    "returns api errors",
+			fork:    fork,
+			timeout: time.Second,
+			err:     errors.New("boom"),
+			calls:   1,
+			errMsg:  "boom",
+		},
+		{
+			desc:    "times out",
+			fork:    fork,
+			timeout: 10 * time.Millisecond,
+			ready:   -1,
+			errMsg:  "timed out waiting for the org1/some-repo fork to be ready",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			forkTimeout = tt.timeout
+			app := core.NewTestApp()
+			ghc := &gh.ClientMock{}
+			ghc.HasBranchFunc = func(name string, branch string) (bool, error) {
+				if tt.err != nil {
+					return false, tt.err
+				}
+				return len(ghc.HasBranchCalls()) == tt.ready, nil
+			}
+			app.GhClient = ghc
+			action := NewRootAction(app)
+
+			err := action.waitForFork(context.Background(), tt.fork)
+			if tt.errMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.errMsg)
+			}
+			if tt.calls > 0 || tt.errMsg == "" {
+				assert.Equal(t, tt.calls, len(ghc.HasBranchCalls()))
+			}
+		})
+	}
+}
+
+func TestRootAction_TrackUpstream(t *testing.T) {
+	ctx := context.Background()
+	opts := git.CommitOptions{NoSign: true, NoVerify: true}
+
+	tests := []struct {
+		desc     string
+		branch   string
+		commits  bool
+		expected string
+		output   []string
+	}{
+		{
+			desc:     "checks out the default branch of upstream in a repo without commits",
+			branch:   "main",
+			expected: "add foo",
+			output:   []string{"Checked out main from upstream"},
+		},
+		{
+			desc:     "keeps existing commits",
+			branch:   "main",
+			commits:  true,
+			expected: "add bar",
+		},
+		{
+			desc:   "does nothing when upstream is empty",
+			branch: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			// The "GitHub" repo being forked.
+			srcDir := t.TempDir()
+			src := git.NewClient(srcDir, testGitIdentity)
+			_, _, err := src.Exec("init", "-b", "main")
+			require.NoError(t, err)
+			require.NoError(t, os.WriteFile(filepath.Join(srcDir, "foo.txt"), []byte("foo"), 0600))
+			require.NoError(t, src.Add(ctx, "."))
+			require.NoError(t, src.Commit(ctx, "add foo", opts))
+
+			testutil.InTempDir(t, func(dir string) {
+				app := core.NewTestApp()
+				app.GitClient = newTestGitClient()
+				require.NoError(t, app.GitClient.Init(ctx))
+				require.NoError(t, app.GitClient.AddRemote(ctx, "upstream", "file://"+filepath.ToSlash(srcDir)))
+				// Fetching is skipped in tests.
+				require.NoError(t, app.GitClient.Fetch(ctx, "upstream", nil))
+				if tt.commits {
+					require.NoError(t, os.WriteFile("bar.txt", []byte("bar"), 0600))
+					require.NoError(t, app.GitClient.Add(ctx, "."))
+					require.NoError(t, app.GitClient.Commit(ctx, "add bar", opts))
+				}
+				require.NoError(t, os.WriteFile("notes.txt", []byte("notes"), 0600))
+				action := NewRootAction(app)
+
+				assert.NoError(t, action.trackUpstream(ctx, tt.branch))
+				assert.FileExists(t, "notes.txt", "keeps untracked files")
+				stdout, _, _ := app.GitClient.Exec("log", "-1", "--format=%s")
+				assert.Equal(t, tt.expected, strings.TrimSpace(stdout.String()))
+				if tt.expected == "add foo" {
+					assert.FileExists(t, "foo.txt")
+					remote, err := app.GitClient.Config("branch.main.remote")
+					assert.NoError(t, err)
+					assert.Equal(t, "upstream", remote)
+				}
+				for _, s := range tt.output {
+					assert.Contains(t, app.IO.Out.String(), s)
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"context"
+	"sort"
+)
+
+// ensureGitConfig applies the configured repo-local git config
+// (pull.rebase, core.hooksPath, url.<base>.insteadOf, etc),
+// showing the keys that will change and prompting before applying them.
+// Keys already set to the configured value are left alone,
+// so re-running is a no-op.
+func (a *RootAction) ensureGitConfig(ctx context.Context) error {
+	changes, err := a.gitConfigChanges()
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		return nil // already configured
+	}
+
+	a.Messenger.Info("The repo git config differs from the configured profile:\n")
+	a.printChanges(changes)
+	ok, err := a.Prompter.Confirm("Update the git config?", true, "")
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return nil // user wants to keep it
+	}
+
+	for _, change := range changes {
+		if err := a.GitClient.SetConfig(ctx, change.Key, change.Value); err != nil {
+			return err
+		}
+	}
+	a.Messenger.Success("Git config updated.\n")
+	return nil
+}
+
+// gitConfigChanges returns the configured git config keys
+// that are not set to their configured value in the repo config (sorted by key).
+func (a *RootAction) gitConfigChanges() ([]settingChange, error) {
+	keys := make([]string, 0, len(a.Config.Git.Config))
+	for key := range a.Config.Git.Config {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	changes := []settingChange{}
+	for _, key := range keys {
+		value := a.Config.Git.Config[key]
+		current, err := a.GitClient.LocalConfig(key)
+		if err != nil {
+			return nil, err
+		}
+		if current == value {
+			continue // already set
+		}
+		changes = append(changes, settingChange{
+			Key:   key,
+			From:  current,
+			Value: value,
+		})
+	}
+	return changes, nil
+}
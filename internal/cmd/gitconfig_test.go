@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	uimock "github.com/twelvelabs/termite/ui/mock"
+
+	"github.com/twelvelabs/gh-setup/internal/core"
+	"github.com/twelvelabs/gh-setup/internal/git"
+)
+
+func TestRootAction_EnsureGitConfig(t *testing.T) {
+	profile := map[string]string{
+		"pull.rebase":                   "true",
+		"fetch.prune":                   "true",
+		"core.autocrlf":                 "input",
+		"url.git@github.com:.insteadOf": "https://github.com/",
+		"commit.template":               ".gitmessage",
+		"rerere.enabled":                "true",
+		"core.hooksPath":                ".githooks",
+	}
+
+	tests := []struct {
+		desc      string
+		profile   map[string]string
+		current   map[string]string
+		configErr error
+		setErr    error
+		confirm   bool
+		prompted  bool
+		set       []string
+		output    []string
+		notOutput []string
+		err       string
+	}{
+		{
+			desc: "does nothing without a profile",
+		},
+		{
+			desc:    "does nothing when the config already matches",
+			profile: profile,
+			current: profile,
+		},
+		{
+			desc:     "shows the changes and applies them when confirmed",
+			profile:  profile,
+			current:  map[string]string{"fetch.prune": "false", "core.autocrlf": "input", "rerere.enabled": "true"},
+			confirm:  true,
+			prompted: true,
+			set: []string{
+				"commit.template=.gitmessage",
+				"core.hooksPath=.githooks",
+				"fetch.prune=true",
+				"pull.rebase=true",
+				"url.git@github.com:.insteadOf=https://github.com/",
+			},
+			output: []string{
+				"+ commit.template = .gitmessage\n",
+				"- fetch.prune = false\n+ fetch.prune = true\n",
+				"+ url.git@github.com:.insteadOf = https://github.com/\n",
+			},
+			notOutput: []string{"core.autocrlf", "rerere.enabled"},
+		},
+		{
+			desc:     "leaves the config alone when declined",
+			profile:  profile,
+			confirm:  false,
+			prompted: true,
+		},
+		{
+			desc:      "returns config errors",
+			profile:   profile,
+			configErr: errors.New("boom"),
+			err:       "boom",
+		},
+		{
+			desc:     "returns set errors",
+			profile:  profile,
+			setErr:   errors.New("boom"),
+			confirm:  true,
+			prompted: true,
+			set:      []string{"commit.template=.gitmessage"},
+			err:      "boom",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			app := core.NewTestApp()
+			app.Config.Git.Config = tt.profile
+			set := []string{}
+			app.GitClient = &git.ClientMock{
+				LocalConfigFunc: func(key string) (string, error) {
+					return tt.current[key], tt.configErr
+				},
+				SetConfigFunc: func(ctx context.Context, key string, value string) error {
+					set = append(set, key+"="+value)
+					return tt.setErr
+				},
+			}
+			p := app.Prompter.(*uimock.PrompterMock)
+			p.ConfirmFunc = func(msg string, value bool, help string) (bool, error) {
+				assert.Equal(t, "Update the git config?", msg)
+				return tt.confirm, nil
+			}
+			action := NewRootAction(app)
+
+			err := action.ensureGitConfig(context.Background())
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+			assert.Equal(t, tt.prompted, len(p.ConfirmCalls()) == 1)
+			if tt.set == nil {
+				tt.set = []string{}
+			}
+			assert.Equal(t, tt.set, set)
+			for _, s := range tt.output {
+				assert.Contains(t, app.IO.Err.String(), s)
+			}
+			for _, s := range tt.notOutput {
+				assert.NotContains(t, app.IO.Err.String(), s)
+			}
+		})
+	}
+}
@@ -0,0 +1,298 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/cli/safeexec"
+)
+
+const (
+	HooksModeHooksPath = "hooks-path"
+	HooksModeCopy      = "copy"
+	HooksModeSymlink   = "symlink"
+)
+
+var (
+	// for stubbing
+	lookPath    = safeexec.LookPath
+	execCommand = exec.CommandContext
+)
+
+// hookFramework is a tool that manages the git hooks of a repo.
+type hookFramework struct {
+	Name string
+	// Files (any of which) mark the repo as using the framework.
+	Files []string
+	// The installer command.
+	Command []string
+	// A marker found in the hooks (or core.hooksPath) once installed.
+	Marker string
+	// Whether the framework installs by setting core.hooksPath
+	// (rather than writing to .git/hooks).
+	HooksPath bool
+	URL       string
+}
+
+var hookFrameworks = []hookFramework{
+	{
+		Name:    "pre-commit",
+		Files:   []string{".pre-commit-config.yaml"},
+		Command: []string{"pre-commit", "install"},
+		Marker:  "pre-commit",
+		URL:     "https://pre-commit.com/#install",
+	},
+	{
+		Name:    "lefthook",
+		Files:   []string{"lefthook.yml", ".lefthook.yml", "lefthook.yaml", ".lefthook.yaml"},
+		Command: []string{"lefthook", "install"},
+		Marker:  "lefthook",
+		URL:     "https://github.com/evilmartians/lefthook#install",
+	},
+	{
+		Name:      "husky",
+		Files:     []string{".husky"},
+		Command:   []string{"npx", "--no-install", "husky", "install"},
+		Marker:    ".husky",
+		HooksPath: true,
+		URL:       "https://typicode.github.io/husky/",
+	},
+}
+
+// validateHooks checks the hooks config.
+func (a *RootAction) validateHooks() error {
+	switch a.Config.Hooks.Mode {
+	case "", HooksModeHooksPath, HooksModeCopy, HooksModeSymlink:
+		return nil
+	default:
+		return fmt.Errorf("hooks: invalid mode: %s (expected hooks-path, copy or symlink)", a.Config.Hooks.Mode)
+	}
+}
+
+// ensureHooks installs the hooks from the configured dir,
+// and runs the installers of any hook frameworks used by the repo.
+func (a *RootAction) ensureHooks(ctx context.Context) error {
+	if a.Config.Hooks.Dir != "" {
+		if err := a.ensureHooksDir(ctx); err != nil {
+			return err
+		}
+	}
+	if a.Config.Hooks.Frameworks {
+		if err := a.ensureHookFrameworks(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ensureHooksDir installs the hooks in the configured dir.
+func (a *RootAction) ensureHooksDir(ctx context.Context) error {
+	root, err := a.workingDir()
+	if err != nil {
+		return err
+	}
+	dir, err := expandHome(a.Config.Hooks.Dir)
+	if err != nil {
+		return err
+	}
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(root, dir)
+	}
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		a.Messenger.Failure("Unable to install the git hooks: %s is not a directory.\n", a.Config.Hooks.Dir)
+		return ErrAborted
+	}
+
+	if a.Config.Hooks.Mode != HooksModeCopy && a.Config.Hooks.Mode != HooksModeSymlink {
+		return a.ensureHooksPath(ctx, a.Config.Hooks.Dir)
+	}
+
+	hooks, err := a.pendingHooks(dir, filepath.Join(root, ".git", "hooks"))
+	if err != nil {
+		return err
+	}
+	if len(hooks) == 0 {
+		return nil // all installed
+	}
+	ok, err := a.Prompter.Confirm(
+		fmt.Sprintf("Install the git hooks from %s (%s)?", a.Config.Hooks.Dir, strings.Join(hooks, ", ")), true, "",
+	)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return nil
+	}
+	for _, name := range hooks {
+		src := filepath.Join(dir, name)
+		dst := filepath.Join(root, ".git", "hooks", name)
+		if err := installHook(src, dst, a.Config.Hooks.Mode); err != nil {
+			return fmt.Errorf("hooks: %w", err)
+		}
+	}
+	a.Messenger.Success("Git hooks installed: %s\n", strings.Join(hooks, ", "))
+
+	hooksPath, err := a.GitClient.Config("core.hooksPath")
+	if err != nil {
+		return err
+	}
+	if hooksPath != "" {
+		a.Messenger.Warning("core.hooksPath is set to %s, so git will not run the hooks in .git/hooks.\n", hooksPath)
+	}
+	return nil
+}
+
+// ensureHooksPath sets core.hooksPath to dir.
+func (a *RootAction) ensureHooksPath(ctx context.Context, dir string) error {
+	current, err := a.GitClient.Config("core.hooksPath")
+	if err != nil {
+		return err
+	}
+	if current == dir {
+		return nil // already set
+	}
+	ok, err := a.Prompter.Confirm(fmt.Sprintf("Use the git hooks in %s (set core.hooksPath)?", dir), true, "")
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return nil
+	}
+	if err := a.GitClient.SetConfig(ctx, "core.hooksPath", dir); err != nil {
+		return err
+	}
+	a.Messenger.Success("Git config set: core.hooksPath = %s\n", dir)
+	return nil
+}
+
+// pendingHooks returns the names of the hooks in dir
+// that are missing from (or differ in) hooksDir.
+func (a *RootAction) pendingHooks(dir string, hooksDir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	pending := []string{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || strings.HasPrefix(name, ".") || strings.HasSuffix(name, ".sample") {
+			continue
+		}
+		src := filepath.Join(dir, name)
+		dst := filepath.Join(hooksDir, name)
+		if a.Config.Hooks.Mode == HooksModeSymlink {
+			if target, err := os.Readlink(dst); err == nil && target == src {
+				continue
+			}
+		} else {
+			want, err := os.ReadFile(src)
+			if err != nil {
+				return nil, err
+			}
+			if have, err := os.ReadFile(dst); err == nil && bytes.Equal(have, want) {
+				continue
+			}
+		}
+		pending = append(pending, name)
+	}
+	return pending, nil
+}
+
+// installHook copies (or symlinks) the hook at src to dst,
+// replacing anything already there.
+func installHook(src string, dst string, mode string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return err
+	}
+	if err := os.Remove(dst); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if mode == HooksModeSymlink {
+		return os.Symlink(src, dst)
+	}
+	content, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(dst, content, 0o755) //nolint: gosec // hooks must be executable
+}
+
+// ensureHookFrameworks runs the installers of the hook frameworks
+// used by the repo (unless their hooks are already installed).
+func (a *RootAction) ensureHookFrameworks(ctx context.Context) error {
+	root, err := a.workingDir()
+	if err != nil {
+		return err
+	}
+	for _, framework := range hookFrameworks {
+		if !framework.usedIn(root) {
+			continue
+		}
+		hooksPath, err := a.GitClient.Config("core.hooksPath")
+		if err != nil {
+			return err
+		}
+		if !framework.HooksPath && hooksPath != "" {
+			a.Messenger.Warning("The repo uses %s, but core.hooksPath is set to %s, so its hooks can not be installed.\n",
+				framework.Name, hooksPath)
+			continue
+		}
+		if framework.installed(root, hooksPath) {
+			continue
+		}
+		if _, err := lookPath(framework.Command[0]); err != nil {
+			a.Messenger.Warning("The repo uses %s, but %s is not installed.\n", framework.Name, framework.Command[0])
+			a.Messenger.Info("To install it, see: %s\n", framework.URL)
+			continue
+		}
+		ok, err := a.Prompter.Confirm(fmt.Sprintf("Install the %s hooks?", framework.Name), true, "")
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		cmd := execCommand(ctx, framework.Command[0], framework.Command[1:]...)
+		cmd.Dir = root
+		cmd.Stdout = a.IO.Out
+		cmd.Stderr = a.IO.Err
+		if err := cmd.Run(); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			a.Messenger.Failure("Unable to install the %s hooks (%s): %s\n",
+				framework.Name, err, strings.Join(framework.Command, " "))
+			return ErrAborted
+		}
+		a.Messenger.Success("Installed the %s hooks.\n", framework.Name)
+	}
+	return nil
+}
+
+// usedIn returns true if the repo in root uses the framework.
+func (f hookFramework) usedIn(root string) bool {
+	for _, name := range f.Files {
+		if _, err := os.Stat(filepath.Join(root, name)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// installed returns true if the hooks of the framework
+// are installed in the repo in root.
+func (f hookFramework) installed(root string, hooksPath string) bool {
+	if f.HooksPath {
+		return strings.Contains(hooksPath, f.Marker)
+	}
+	content, err := os.ReadFile(filepath.Join(root, ".git", "hooks", "pre-commit"))
+	if err != nil {
+		return false
+	}
+	return strings.Contains(string(content), f.Marker)
+}
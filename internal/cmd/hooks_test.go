@@ -0,0 +1,276 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	uimock "github.com/twelvelabs/termite/ui/mock"
+
+	"github.com/twelvelabs/gh-setup/internal/core"
+	"github.com/twelvelabs/gh-setup/internal/git"
+)
+
+func TestRootAction_EnsureHooksPath(t *testing.T) {
+	tests := []struct {
+		desc     string
+		dir      string
+		current  string
+		confirm  bool
+		prompted bool
+		set      string
+		output   []string
+		err      string
+	}{
+		{
+			desc:     "sets core.hooksPath when confirmed",
+			dir:      ".githooks",
+			confirm:  true,
+			prompted: true,
+			set:      ".githooks",
+			output:   []string{"Git config set: core.hooksPath = .githooks"},
+		},
+		{
+			desc:     "leaves core.hooksPath alone when declined",
+			dir:      ".githooks",
+			current:  ".husky",
+			prompted: true,
+		},
+		{
+			desc:    "does nothing when core.hooksPath is already set",
+			dir:     ".githooks",
+			current: ".githooks",
+		},
+		{
+			desc:   "aborts when the dir does not exist",
+			dir:    "missing",
+			output: []string{"Unable to install the git hooks: missing is not a directory."},
+			err:    "aborted",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			root := t.TempDir()
+			require.NoError(t, os.Mkdir(filepath.Join(root, ".githooks"), 0750))
+
+			app := core.NewTestApp()
+			app.Dir = root
+			app.Config.Hooks = core.HooksConfig{Dir: tt.dir, Mode: HooksModeHooksPath}
+			set := ""
+			app.GitClient = &git.ClientMock{
+				ConfigFunc: func(key string) (string, error) {
+					assert.Equal(t, "core.hooksPath", key)
+					return tt.current, nil
+				},
+				SetConfigFunc: func(ctx context.Context, key string, value string) error {
+					set = value
+					return nil
+				},
+			}
+			p := app.Prompter.(*uimock.PrompterMock)
+			p.ConfirmFunc = func(msg string, value bool, help string) (bool, error) {
+				return tt.confirm, nil
+			}
+			action := NewRootAction(app)
+
+			err := action.ensureHooks(context.Background())
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+			assert.Equal(t, tt.prompted, len(p.ConfirmCalls()) == 1)
+			assert.Equal(t, tt.set, set)
+			for _, s := range tt.output {
+				assert.Contains(t, app.IO.Out.String(), s)
+			}
+		})
+	}
+}
+
+func TestRootAction_EnsureHooks_Install(t *testing.T) {
+	for _, mode := range []string{HooksModeCopy, HooksModeSymlink} {
+		t.Run(mode, func(t *testing.T) {
+			root := t.TempDir()
+			hooksDir := filepath.Join(t.TempDir(), "hooks")
+			require.NoError(t, os.Mkdir(hooksDir, 0750))
+			require.NoError(t, os.WriteFile(filepath.Join(hooksDir, "pre-commit"), []byte("#!/bin/sh\nexit 0\n"), 0700))
+			require.NoError(t, os.WriteFile(filepath.Join(hooksDir, "commit-msg"), []byte("#!/bin/sh\nexit 0\n"), 0700))
+			require.NoError(t, os.WriteFile(filepath.Join(hooksDir, "post-merge.sample"), []byte("#!/bin/sh\n"), 0600))
+			// An outdated copy of one of the hooks.
+			require.NoError(t, os.MkdirAll(filepath.Join(root, ".git", "hooks"), 0750))
+			require.NoError(t, os.WriteFile(filepath.Join(root, ".git", "hooks", "pre-commit"), []byte("old"), 0600))
+
+			app := core.NewTestApp()
+			app.Dir = root
+			app.Config.Hooks = core.HooksConfig{Dir: hooksDir, Mode: mode}
+			app.GitClient = &git.ClientMock{
+				ConfigFunc: func(key string) (string, error) {
+					return "", nil
+				},
+			}
+			p := app.Prompter.(*uimock.PrompterMock)
+			p.ConfirmFunc = func(msg string, value bool, help string) (bool, error) {
+				assert.Equal(t, "Install the git hooks from "+hooksDir+" (commit-msg, pre-commit)?", msg)
+				return true, nil
+			}
+			action := NewRootAction(app)
+
+			err := action.ensureHooks(context.Background())
+			assert.NoError(t, err)
+			assert.Contains(t, app.IO.Out.String(), "Git hooks installed: commit-msg, pre-commit")
+			for _, name := range []string{"pre-commit", "commit-msg"} {
+				path := filepath.Join(root, ".git", "hooks", name)
+				content, err := os.ReadFile(path)
+				assert.NoError(t, err)
+				assert.Equal(t, "#!/bin/sh\nexit 0\n", string(content))
+				info, err := os.Stat(path)
+				assert.NoError(t, err)
+				assert.NotZero(t, info.Mode()&0100, "hook should be executable")
+				if mode == HooksModeSymlink {
+					target, err := os.Readlink(path)
+					assert.NoError(t, err)
+					assert.Equal(t, filepath.Join(hooksDir, name), target)
+				}
+			}
+			assert.NoFileExists(t, filepath.Join(root, ".git", "hooks", "post-merge.sample"))
+
+			// re-running is a no-op
+			err = action.ensureHooks(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, 1, len(p.ConfirmCalls()))
+		})
+	}
+}
+
+func TestRootAction_EnsureHookFrameworks(t *testing.T) {
+	tests := []struct {
+		desc       string
+		files      map[string]string
+		hooksPath  string
+		notFound   bool
+		installErr bool
+		installed  []string
+		output     []string
+		err        string
+	}{
+		{
+			desc: "does nothing when no frameworks are used",
+		},
+		{
+			desc:      "runs the installers of the frameworks used",
+			files:     map[string]string{".pre-commit-config.yaml": "repos: []", "lefthook.yml": ""},
+			installed: []string{"pre-commit install", "lefthook install"},
+			output:    []string{"Installed the pre-commit hooks.", "Installed the lefthook hooks."},
+		},
+		{
+			desc: "skips frameworks that are already installed",
+			files: map[string]string{
+				".pre-commit-config.yaml": "repos: []",
+				".git/hooks/pre-commit":   "# File generated by pre-commit: https://pre-commit.com",
+				".husky/pre-commit":       "npm test",
+				".husky/_/husky.sh":       "",
+			},
+			hooksPath: "",
+			installed: []string{"npx --no-install husky install"},
+		},
+		{
+			desc:      "skips husky when core.hooksPath points to it",
+			files:     map[string]string{".husky/pre-commit": "npm test"},
+			hooksPath: ".husky/_",
+		},
+		{
+			desc:      "warns when core.hooksPath prevents installing",
+			files:     map[string]string{".pre-commit-config.yaml": "repos: []"},
+			hooksPath: ".githooks",
+			output:    []string{"The repo uses pre-commit, but core.hooksPath is set to .githooks"},
+		},
+		{
+			desc:     "warns when the installer is not installed",
+			files:    map[string]string{".pre-commit-config.yaml": "repos: []"},
+			notFound: true,
+			output: []string{
+				"The repo uses pre-commit, but pre-commit is not installed.",
+				"https://pre-commit.com/#install",
+			},
+		},
+		{
+			desc:       "aborts when the installer fails",
+			files:      map[string]string{".pre-commit-config.yaml": "repos: []"},
+			installErr: true,
+			installed:  []string{"pre-commit install"},
+			output:     []string{"Unable to install the pre-commit hooks (exit status 1): pre-commit install"},
+			err:        "aborted",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			root := t.TempDir()
+			for name, content := range tt.files {
+				path := filepath.Join(root, name)
+				require.NoError(t, os.MkdirAll(filepath.Dir(path), 0750))
+				require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+			}
+
+			defer func(lp func(string) (string, error), ec func(context.Context, string, ...string) *exec.Cmd) {
+				lookPath, execCommand = lp, ec
+			}(lookPath, execCommand)
+			lookPath = func(file string) (string, error) {
+				if tt.notFound {
+					return "", errors.New("not found")
+				}
+				return "/usr/bin/" + file, nil
+			}
+			installed := []string{}
+			execCommand = func(ctx context.Context, name string, args ...string) *exec.Cmd {
+				installed = append(installed, name+" "+strings.Join(args, " "))
+				if tt.installErr {
+					return exec.CommandContext(ctx, "sh", "-c", "exit 1")
+				}
+				return exec.CommandContext(ctx, "sh", "-c", "true")
+			}
+
+			app := core.NewTestApp()
+			app.Dir = root
+			app.GitClient = &git.ClientMock{
+				ConfigFunc: func(key string) (string, error) {
+					return tt.hooksPath, nil
+				},
+			}
+			p := app.Prompter.(*uimock.PrompterMock)
+			p.ConfirmFunc = func(msg string, value bool, help string) (bool, error) {
+				return true, nil
+			}
+			action := NewRootAction(app)
+
+			err := action.ensureHooks(context.Background())
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+			if tt.installed == nil {
+				tt.installed = []string{}
+			}
+			assert.Equal(t, tt.installed, installed)
+			for _, s := range tt.output {
+				assert.Contains(t, app.IO.Out.String(), s)
+			}
+		})
+	}
+}
+
+func TestRootAction_CommitOptions(t *testing.T) {
+	action := NewRootAction(core.NewTestApp())
+	assert.Equal(t, git.CommitOptions{NoSign: true, NoVerify: true}, action.commitOptions())
+
+	// Hooks (and signing) are only skipped when testing.
+	t.Setenv("APP_ENV", "")
+	assert.Equal(t, git.CommitOptions{}, action.commitOptions())
+}
@@ -0,0 +1,261 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/twelvelabs/gh-setup/internal/gh"
+	"github.com/twelvelabs/gh-setup/internal/git"
+)
+
+var (
+	gpgKeyIDRE = regexp.MustCompile(`^(?:0x)?[0-9A-Fa-f]{8,40}!?$`)
+)
+
+// ensureCommitIdentity checks (before anything is created on GitHub) that
+// the commit made by ensureWorkingDirClean will succeed, and that it will be
+// attributed to, and verified for, the GitHub user.
+func (a *RootAction) ensureCommitIdentity() error {
+	if !a.GitClient.IsDirty() {
+		return nil // nothing to commit
+	}
+
+	email := ""
+	for _, role := range []string{"AUTHOR", "COMMITTER"} {
+		name, roleEmail, err := a.gitIdentity(role)
+		if err != nil {
+			return err
+		}
+		if name == "" || roleEmail == "" {
+			a.Messenger.Failure("Unable to commit until your git name and email are configured.\n")
+			a.Messenger.Info(
+				"To configure them, run: git config --global user.name \"Your Name\" && " +
+					"git config --global user.email you@example.com\n",
+			)
+			return ErrAborted
+		}
+		if email == "" {
+			email = roleEmail
+		}
+	}
+	if a.Config.App.ID == "" {
+		// Apps have no emails (and commit as whoever is configured locally).
+		a.checkCommitEmail(email)
+	}
+
+	format, err := a.signingFormat()
+	if err != nil || format == "" {
+		return err // or not signing
+	}
+	key, err := a.GitClient.Config("user.signingkey")
+	if err != nil {
+		return err
+	}
+	switch format {
+	case "ssh":
+		return a.checkSSHSigningKey(key)
+	case "x509":
+		return nil // S/MIME signatures are verified by email
+	default:
+		return a.checkGPGSigningKey(key, email)
+	}
+}
+
+// signingFormat returns the format of the signatures git adds to commits
+// ("openpgp", "ssh" or "x509"), or an empty string if they aren't signed.
+func (a *RootAction) signingFormat() (string, error) {
+	if a.commitOptions().NoSign {
+		return "", nil
+	}
+	gpgSign, err := a.GitClient.Config("commit.gpgsign")
+	if err != nil || !isTrue(gpgSign) {
+		return "", err
+	}
+	format, err := a.GitClient.Config("gpg.format")
+	if err != nil {
+		return "", err
+	}
+	if format == "" {
+		format = "openpgp" // the git default
+	}
+	return format, nil
+}
+
+// gitIdentity returns the name and email git will use for role
+// (AUTHOR or COMMITTER): the GIT_<role>_* env vars of the git client,
+// falling back to config.
+func (a *RootAction) gitIdentity(role string) (string, string, error) {
+	name := a.GitClient.Getenv("GIT_" + role + "_NAME")
+	if name == "" {
+		value, err := a.GitClient.Config("user.name")
+		if err != nil {
+			return "", "", err
+		}
+		name = value
+	}
+	email := a.GitClient.Getenv("GIT_" + role + "_EMAIL")
+	if email == "" {
+		value, err := a.GitClient.Config("user.email")
+		if err != nil {
+			return "", "", err
+		}
+		email = value
+	}
+	if email == "" {
+		email = a.GitClient.Getenv("EMAIL")
+	}
+	return name, email, nil
+}
+
+// checkCommitEmail warns if commits by email won't be linked to the GitHub user.
+func (a *RootAction) checkCommitEmail(email string) {
+	if strings.HasSuffix(strings.ToLower(email), "@users.noreply."+a.Host()) {
+		return // the GitHub provided no-reply address
+	}
+	emails, err := a.GhClient.ListEmails()
+	if err != nil {
+		a.skipCheck("the commit email", err)
+		return
+	}
+	for _, e := range emails {
+		if e.Verified && strings.EqualFold(e.Email, email) {
+			return
+		}
+	}
+	a.Messenger.Warning("Commits by %s will not be linked to your GitHub account (it is not a verified email).\n", email)
+	a.Messenger.Info("To fix, verify it at https://%s/settings/emails or change user.email.\n", a.Host())
+}
+
+// checkSSHSigningKey aborts if the SSH signing key can't be read,
+// and warns if it isn't registered on GitHub.
+func (a *RootAction) checkSSHSigningKey(key string) error {
+	if key == "" {
+		a.Messenger.Failure("Commit signing is enabled, but user.signingkey is not set.\n")
+		a.Messenger.Info("To set it, run: git config --global user.signingkey ~/.ssh/id_ed25519.pub\n")
+		return ErrAborted
+	}
+	pub, path, err := readSSHPublicKey(key)
+	if err != nil {
+		a.Messenger.Failure("Unable to read the SSH signing key: %s\n", err)
+		return ErrAborted
+	}
+	if a.Config.App.ID != "" {
+		return nil // apps have no signing keys
+	}
+	keys, err := a.GhClient.ListSSHSigningKeys()
+	if err != nil {
+		a.skipCheck("the SSH signing key", err)
+		return nil
+	}
+	for _, k := range keys {
+		if gh.SameSSHKey(k.Key, pub) {
+			return nil
+		}
+	}
+	a.Messenger.Warning("The SSH signing key is not registered on GitHub, so commits will be shown as unverified.\n")
+	if path != "" {
+		a.Messenger.Info("To register it, run: gh ssh-key add %s --type signing\n", path)
+	} else {
+		a.Messenger.Info("To register it, visit: https://%s/settings/ssh/new\n", a.Host())
+	}
+	return nil
+}
+
+// checkGPGSigningKey warns if the GPG signing key isn't registered on GitHub.
+// When key is unset, gpg picks a key for the committer email.
+// Keys given by user ID (e.g. "Some User <some@example.com>") are matched by email.
+func (a *RootAction) checkGPGSigningKey(key string, email string) error {
+	if a.Config.App.ID != "" {
+		return nil // apps have no signing keys
+	}
+	keys, err := a.GhClient.ListGPGKeys()
+	if err != nil {
+		a.skipCheck("the GPG signing key", err)
+		return nil
+	}
+	byKeyID := gpgKeyIDRE.MatchString(key)
+	if strings.Contains(key, "@") {
+		email = key // a user ID rather than a key ID
+	}
+	for _, k := range keys {
+		if byKeyID && k.HasKeyID(key) {
+			return nil
+		}
+		if !byKeyID && k.HasEmail(email) {
+			return nil
+		}
+	}
+	if key == "" {
+		key = email
+	}
+	a.Messenger.Warning("The GPG signing key is not registered on GitHub, so commits will be shown as unverified.\n")
+	a.Messenger.Info("To register it, run: gpg --armor --export %s | gh gpg-key add -\n", key)
+	return nil
+}
+
+// skipCheck warns that a (best effort) check could not be made.
+func (a *RootAction) skipCheck(name string, err error) {
+	apiErr := &gh.APIError{}
+	if errors.As(err, &apiErr) {
+		a.Messenger.Warning("Unable to check %s. %s\n", name, apiErr.Message)
+		if apiErr.Hint != "" {
+			a.Messenger.Info("%s\n", apiErr.Hint)
+		}
+		return
+	}
+	a.Messenger.Warning("Unable to check %s: %s\n", name, err)
+}
+
+// readSSHPublicKey returns the public key for user.signingkey, which is either
+// a literal key or the path to a public (or private) key file.
+// The path is empty for literal keys.
+func readSSHPublicKey(key string) (string, string, error) {
+	if strings.HasPrefix(key, "key::") {
+		return strings.TrimPrefix(key, "key::"), "", nil
+	}
+	if strings.HasPrefix(key, "ssh-") || strings.HasPrefix(key, "ecdsa-") || strings.HasPrefix(key, "sk-") {
+		return key, "", nil
+	}
+	path, err := expandHome(key)
+	if err != nil {
+		return "", "", err
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", "", err
+	}
+	if strings.HasPrefix(string(content), "-----BEGIN") {
+		// A private key - use the public key alongside it.
+		path += ".pub"
+		content, err = os.ReadFile(path)
+		if err != nil {
+			return "", "", err
+		}
+	}
+	return strings.TrimSpace(string(content)), path, nil
+}
+
+// isTrue returns true if value is a git config boolean true.
+func isTrue(value string) bool {
+	switch strings.ToLower(value) {
+	case "true", "yes", "on", "1":
+		return true
+	}
+	return false
+}
+
+// commitOptions returns the options used when committing.
+func (a *RootAction) commitOptions() git.CommitOptions {
+	opts := git.CommitOptions{}
+	if os.Getenv("APP_ENV") == EnvTest {
+		// Don't sign when testing because some folks (:raise_hand:)
+		// use a YubiKey and it will block waiting for approval.
+		opts.NoSign = true
+		// Hooks installed by setup must run for real commits,
+		// so only skip them when testing.
+		opts.NoVerify = true
+	}
+	return opts
+}
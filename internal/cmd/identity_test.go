@@ -0,0 +1,246 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/twelvelabs/gh-setup/internal/core"
+	"github.com/twelvelabs/gh-setup/internal/gh"
+	"github.com/twelvelabs/gh-setup/internal/git"
+)
+
+const testSSHKey = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIHlXq9WZcGmfMuXpPzDq8Ni1xFgoAq0LT0j2d+/Vwp9E"
+
+func TestRootAction_EnsureCommitIdentity(t *testing.T) {
+	keyDir := t.TempDir()
+	keyPath := filepath.Join(keyDir, "id_ed25519.pub")
+	_ = os.WriteFile(keyPath, []byte(testSSHKey+" test@example.com\n"), 0600)
+
+	identity := map[string]string{
+		"user.name":  "Test User",
+		"user.email": "test@example.com",
+	}
+	with := func(config map[string]string, extra map[string]string) map[string]string {
+		merged := map[string]string{}
+		for k, v := range config {
+			merged[k] = v
+		}
+		for k, v := range extra {
+			merged[k] = v
+		}
+		return merged
+	}
+	verified := func() ([]*gh.Email, error) {
+		return []*gh.Email{{Email: "test@example.com", Verified: true}}, nil
+	}
+
+	tests := []struct {
+		desc      string
+		clean     bool
+		signing   bool
+		config    map[string]string
+		env       map[string]string
+		configErr error
+		emails    func() ([]*gh.Email, error)
+		gpgKeys   []*gh.GPGKey
+		sshKeys   []*gh.SSHSigningKey
+		output    []string
+		notOutput []string
+		err       string
+	}{
+		{
+			desc:  "does nothing when there is nothing to commit",
+			clean: true,
+		},
+		{
+			desc:      "returns config errors",
+			configErr: errors.New("boom"),
+			err:       "boom",
+		},
+		{
+			desc:   "aborts when the identity is not configured",
+			config: map[string]string{"user.name": "Test User"},
+			output: []string{"Unable to commit until your git name and email are configured."},
+			err:    "aborted",
+		},
+		{
+			desc: "uses the identity in the git client env",
+			env: map[string]string{
+				"GIT_AUTHOR_NAME":     "Test User",
+				"GIT_AUTHOR_EMAIL":    "test@example.com",
+				"GIT_COMMITTER_NAME":  "Test User",
+				"GIT_COMMITTER_EMAIL": "test@example.com",
+			},
+			emails:    verified,
+			notOutput: []string{"Unable to commit", "will not be linked"},
+		},
+		{
+			desc:      "falls back to EMAIL in the git client env",
+			config:    map[string]string{"user.name": "Test User"},
+			env:       map[string]string{"EMAIL": "test@example.com"},
+			emails:    verified,
+			notOutput: []string{"Unable to commit", "will not be linked"},
+		},
+		{
+			desc:      "passes when the email is verified",
+			config:    identity,
+			emails:    verified,
+			notOutput: []string{"Commits by"},
+		},
+		{
+			desc:   "warns when the email is not verified",
+			config: identity,
+			emails: func() ([]*gh.Email, error) {
+				return []*gh.Email{{Email: "test@example.com", Verified: false}}, nil
+			},
+			output: []string{
+				"Commits by test@example.com will not be linked to your GitHub account",
+				"https://github.com/settings/emails",
+			},
+		},
+		{
+			desc:   "skips the email lookup for no-reply emails",
+			config: with(identity, map[string]string{"user.email": "123+test-user@users.noreply.github.com"}),
+		},
+		{
+			desc:   "warns when the email can not be checked",
+			config: identity,
+			emails: func() ([]*gh.Email, error) {
+				return nil, &gh.APIError{
+					Message: "The GitHub token is missing the 'user:email' scope.",
+					Hint:    "To add it, run: gh auth refresh -h github.com -s user:email",
+				}
+			},
+			output: []string{
+				"Unable to check the commit email. The GitHub token is missing the 'user:email' scope.",
+				"gh auth refresh -h github.com -s user:email",
+			},
+		},
+		{
+			desc:      "does not check signing keys when testing",
+			config:    with(identity, map[string]string{"commit.gpgsign": "true", "gpg.format": "ssh"}),
+			emails:    verified,
+			notOutput: []string{"signing"},
+		},
+		{
+			desc:    "aborts when the ssh signing key is not set",
+			signing: true,
+			config:  with(identity, map[string]string{"commit.gpgsign": "true", "gpg.format": "ssh"}),
+			emails:  verified,
+			output:  []string{"Commit signing is enabled, but user.signingkey is not set."},
+			err:     "aborted",
+		},
+		{
+			desc:    "aborts when the ssh signing key is missing",
+			signing: true,
+			config: with(identity, map[string]string{
+				"commit.gpgsign":  "true",
+				"gpg.format":      "ssh",
+				"user.signingkey": filepath.Join(keyDir, "missing.pub"),
+			}),
+			emails: verified,
+			output: []string{"Unable to read the SSH signing key"},
+			err:    "aborted",
+		},
+		{
+			desc:    "passes when the ssh signing key is registered",
+			signing: true,
+			config: with(identity, map[string]string{
+				"commit.gpgsign":  "true",
+				"gpg.format":      "ssh",
+				"user.signingkey": keyPath,
+			}),
+			emails:    verified,
+			sshKeys:   []*gh.SSHSigningKey{{Key: testSSHKey}},
+			notOutput: []string{"not registered"},
+		},
+		{
+			desc:    "warns when the ssh signing key is not registered",
+			signing: true,
+			config: with(identity, map[string]string{
+				"commit.gpgsign":  "true",
+				"gpg.format":      "ssh",
+				"user.signingkey": "key::" + testSSHKey,
+			}),
+			emails:  verified,
+			sshKeys: []*gh.SSHSigningKey{},
+			output: []string{
+				"The SSH signing key is not registered on GitHub",
+				"https://github.com/settings/ssh/new",
+			},
+		},
+		{
+			desc:    "passes when the gpg signing key is registered",
+			signing: true,
+			config: with(identity, map[string]string{
+				"commit.gpgsign":  "true",
+				"user.signingkey": "4A595D4C72EE49C7",
+			}),
+			emails: verified,
+			gpgKeys: []*gh.GPGKey{
+				{KeyID: "3262EFF25BA0D270", Subkeys: []*gh.GPGKey{{KeyID: "4A595D4C72EE49C7"}}},
+			},
+			notOutput: []string{"not registered"},
+		},
+		{
+			desc:    "warns when the gpg signing key is not registered",
+			signing: true,
+			config:  with(identity, map[string]string{"commit.gpgsign": "true"}),
+			emails:  verified,
+			gpgKeys: []*gh.GPGKey{
+				{KeyID: "3262EFF25BA0D270", Emails: []*gh.Email{{Email: "other@example.com", Verified: true}}},
+			},
+			output: []string{
+				"The GPG signing key is not registered on GitHub",
+				"gpg --armor --export test@example.com | gh gpg-key add -",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if tt.signing {
+				t.Setenv("APP_ENV", "")
+			}
+
+			app := core.NewTestApp()
+			app.GitClient = &git.ClientMock{
+				IsDirtyFunc: func() bool {
+					return !tt.clean
+				},
+				ConfigFunc: func(key string) (string, error) {
+					return tt.config[key], tt.configErr
+				},
+				GetenvFunc: func(key string) string {
+					return tt.env[key]
+				},
+			}
+			app.GhClient = &gh.ClientMock{
+				ListEmailsFunc: tt.emails,
+				ListGPGKeysFunc: func() ([]*gh.GPGKey, error) {
+					return tt.gpgKeys, nil
+				},
+				ListSSHSigningKeysFunc: func() ([]*gh.SSHSigningKey, error) {
+					return tt.sshKeys, nil
+				},
+			}
+			action := NewRootAction(app)
+
+			err := action.ensureCommitIdentity()
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+			for _, s := range tt.output {
+				assert.Contains(t, app.IO.Out.String(), s)
+			}
+			for _, s := range tt.notOutput {
+				assert.NotContains(t, app.IO.Out.String(), s)
+			}
+		})
+	}
+}
@@ -0,0 +1,214 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/twelvelabs/gh-setup/internal/core"
+	"github.com/twelvelabs/gh-setup/internal/gh"
+)
+
+// validateRemotes checks the additional remotes declared in the config.
+func (a *RootAction) validateRemotes() error {
+	names := map[string]bool{a.Config.Remote: true}
+	if a.Fork != "" {
+		names[upstreamRemote] = true
+	}
+	for _, remote := range a.Config.Remotes {
+		if remote.Name == "" {
+			return fmt.Errorf("remotes: name is required")
+		}
+		if (remote.URL == "") == (remote.Repo == "") {
+			return fmt.Errorf("remotes: the '%s' remote needs either a url or a repo", remote.Name)
+		}
+		if names[remote.Name] {
+			return fmt.Errorf("remotes: the '%s' remote is declared more than once", remote.Name)
+		}
+		names[remote.Name] = true
+	}
+	return nil
+}
+
+// checkRemote resolves the repo that remote points to through the API and
+// offers to fix any drift: a renamed or transferred repo, an archived repo,
+// or a URL using a different protocol than the user prefers.
+// Returns false if the repo no longer exists.
+func (a *RootAction) checkRemote(ctx context.Context, remote string) (bool, error) {
+	current, err := a.GitClient.RemoteURL(remote)
+	if err != nil {
+		return false, err
+	}
+	host, fullName, err := gh.ParseRemoteURL(current)
+	if err != nil || !strings.EqualFold(host, a.Host()) {
+		return true, nil // not a repo on the GitHub host - leave it alone
+	}
+	repo, err := a.GhClient.GetRepo(fullName)
+	if err != nil {
+		return false, err
+	}
+	if repo == nil {
+		a.Messenger.Warning("The '%s' remote points to %s, which no longer exists on GitHub.\n", remote, fullName)
+		return false, nil
+	}
+	if repo.Archived {
+		if err := a.promptToUnarchive(repo); err != nil {
+			return false, err
+		}
+	}
+
+	user, err := a.currentUser()
+	if err != nil {
+		return false, err
+	}
+	protocol := user.GitProtocol
+	if protocol == gh.ProtocolUnknown {
+		protocol = gh.ProtocolHTTPS
+	}
+	if protocol != gh.URLProtocol(current) {
+		protocol, err = a.ensureSSHKey(protocol)
+		if err != nil {
+			return false, err
+		}
+	}
+	expected := repo.RemoteURL(protocol)
+	if sameRemoteURL(current, expected) {
+		return true, nil // all good
+	}
+	if !strings.EqualFold(repo.FullName, fullName) {
+		a.Messenger.Warning("The %s repo has moved to %s.\n", fullName, repo.FullName)
+	} else {
+		a.Messenger.Info("The '%s' remote uses %s, but your preferred git protocol is %s.\n",
+			remote, gh.URLProtocol(current), protocol)
+	}
+	ok, err := a.Prompter.Confirm(fmt.Sprintf("Update the '%s' remote to %s?", remote, expected), true, "")
+	if err != nil {
+		return false, err
+	}
+	if !ok {
+		return true, nil // user wants to keep it
+	}
+	if err := a.GitClient.SetRemoteURL(ctx, remote, expected); err != nil {
+		return false, err
+	}
+	a.Messenger.Success("Remote updated: %s %s\n", remote, expected)
+	return true, nil
+}
+
+// promptToUnarchive warns that repo is archived (and so can't be pushed to)
+// and offers to unarchive it.
+func (a *RootAction) promptToUnarchive(repo *gh.Repository) error {
+	a.Messenger.Warning("The %s repo is archived, so it is read-only.\n", repo.FullName)
+	ok, err := a.Prompter.Confirm("Unarchive it?", true, "")
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return nil
+	}
+	a.IO.StartProgressIndicatorWithLabel("Unarchiving repo")
+	_, err = a.GhClient.UnarchiveRepo(repo.FullName)
+	a.IO.StopProgressIndicator()
+	if err != nil {
+		return err
+	}
+	a.Messenger.Success("Repo unarchived: %s\n", repo.FullName)
+	return nil
+}
+
+// remoteRepo returns the GitHub repo the remote points to,
+// or nil if there is no remote (or it isn't a GitHub repo).
+func (a *RootAction) remoteRepo() (*gh.Repository, error) {
+	if !a.GitClient.HasRemote(a.Config.Remote) {
+		return nil, nil //nolint: nilnil
+	}
+	url, err := a.GitClient.RemoteURL(a.Config.Remote)
+	if err != nil {
+		return nil, err
+	}
+	_, fullName, err := gh.ParseRemoteURL(url)
+	if err != nil {
+		return nil, nil //nolint: nilnil
+	}
+	return a.GhClient.GetRepo(fullName)
+}
+
+// ensureExtraRemotes adds the additional remotes declared in the config,
+// offering to update any that have a different URL.
+func (a *RootAction) ensureExtraRemotes(ctx context.Context) error {
+	for _, remote := range a.Config.Remotes {
+		url, err := a.extraRemoteURL(remote)
+		if err != nil {
+			return err
+		}
+		if err := a.ensureRemoteURL(ctx, remote.Name, url); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// extraRemoteURL returns the URL for an additional remote.
+func (a *RootAction) extraRemoteURL(remote core.RemoteConfig) (string, error) {
+	if remote.Repo == "" {
+		return remote.URL, nil
+	}
+	repo, err := a.GhClient.GetRepo(remote.Repo)
+	if err != nil {
+		return "", err
+	}
+	if repo == nil {
+		a.Messenger.Failure("Unable to add the '%s' remote: the '%s' repo does not exist.\n", remote.Name, remote.Repo)
+		return "", ErrAborted
+	}
+	user, err := a.currentUser()
+	if err != nil {
+		return "", err
+	}
+	return repo.RemoteURL(user.GitProtocol), nil
+}
+
+// ensureRemoteURL adds the remote named name for url,
+// or offers to update it if it already exists with a different URL.
+func (a *RootAction) ensureRemoteURL(ctx context.Context, name string, url string) error {
+	if !a.GitClient.HasRemote(name) {
+		a.IO.StartProgressIndicatorWithLabel("Adding remote")
+		err := a.GitClient.AddRemote(ctx, name, url)
+		a.IO.StopProgressIndicator()
+		if err != nil {
+			return err
+		}
+		a.Messenger.Success("Remote added: %s %s\n", name, url)
+		return nil
+	}
+
+	current, err := a.GitClient.RemoteURL(name)
+	if err != nil {
+		return err
+	}
+	if sameRemoteURL(current, url) {
+		return nil // already configured
+	}
+	a.Messenger.Warning("The '%s' remote is %s (expected %s).\n", name, current, url)
+	ok, err := a.Prompter.Confirm(fmt.Sprintf("Update the '%s' remote?", name), true, "")
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return nil // user wants to keep it
+	}
+	if err := a.GitClient.SetRemoteURL(ctx, name, url); err != nil {
+		return err
+	}
+	a.Messenger.Success("Remote updated: %s %s\n", name, url)
+	return nil
+}
+
+// sameRemoteURL returns true if a and b refer to the same repo,
+// ignoring case, trailing slashes and the ".git" suffix.
+func sameRemoteURL(a string, b string) bool {
+	normalize := func(url string) string {
+		return strings.TrimSuffix(strings.TrimSuffix(url, "/"), ".git")
+	}
+	return strings.EqualFold(normalize(a), normalize(b))
+}
@@ -0,0 +1,324 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twelvelabs/termite/testutil"
+	uimock "github.com/twelvelabs/termite/ui/mock"
+
+	"github.com/twelvelabs/gh-setup/internal/core"
+	"github.com/twelvelabs/gh-setup/internal/gh"
+	"github.com/twelvelabs/gh-setup/internal/git"
+)
+
+func TestRootAction_ValidateRemotes(t *testing.T) {
+	tests := []struct {
+		desc    string
+		remotes []core.RemoteConfig
+		err     string
+	}{
+		{
+			desc: "passes for valid remotes",
+			remotes: []core.RemoteConfig{
+				{Name: "upstream", Repo: "other-org/some-repo"},
+				{Name: "backup", URL: "git@git.example.com:some-org/some-repo.git"},
+			},
+		},
+		{
+			desc:    "requires a name",
+			remotes: []core.RemoteConfig{{URL: "git@git.example.com:some-org/some-repo.git"}},
+			err:     "remotes: name is required",
+		},
+		{
+			desc:    "requires a url or a repo",
+			remotes: []core.RemoteConfig{{Name: "backup"}},
+			err:     "the 'backup' remote needs either a url or a repo",
+		},
+		{
+			desc:    "does not allow both a url and a repo",
+			remotes: []core.RemoteConfig{{Name: "backup", URL: "git@git.example.com:a/b.git", Repo: "a/b"}},
+			err:     "the 'backup' remote needs either a url or a repo",
+		},
+		{
+			desc:    "does not allow duplicates of the main remote",
+			remotes: []core.RemoteConfig{{Name: "origin", Repo: "other-org/some-repo"}},
+			err:     "the 'origin' remote is declared more than once",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			app := core.NewTestApp()
+			app.Config.Remotes = tt.remotes
+			action := NewRootAction(app)
+
+			err := action.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestRootAction_EnsureExtraRemotes(t *testing.T) {
+	ctx := context.Background()
+	remotes := []core.RemoteConfig{
+		{Name: "upstream", Repo: "other-org/some-repo"},
+		{Name: "backup", URL: "git@git.example.com:some-org/some-repo.git"},
+	}
+	upstreamURL := "https://github.com/other-org/some-repo.git"
+
+	tests := []struct {
+		desc     string
+		existing map[string]string
+		update   bool
+		prompts  int
+		expected map[string]string
+		output   []string
+		err      string
+	}{
+		{
+			desc: "adds missing remotes",
+			expected: map[string]string{
+				"upstream": upstreamURL,
+				"backup":   "git@git.example.com:some-org/some-repo.git",
+			},
+			output: []string{
+				"Remote added: upstream " + upstreamURL,
+				"Remote added: backup git@git.example.com:some-org/some-repo.git",
+			},
+		},
+		{
+			desc: "leaves matching remotes alone",
+			existing: map[string]string{
+				"upstream": "https://github.com/other-org/some-repo",
+				"backup":   "git@git.example.com:some-org/some-repo.git",
+			},
+			expected: map[string]string{
+				"upstream": "https://github.com/other-org/some-repo",
+				"backup":   "git@git.example.com:some-org/some-repo.git",
+			},
+		},
+		{
+			desc: "updates mismatched remotes when confirmed",
+			existing: map[string]string{
+				"backup": "git@git.example.com:some-org/old-repo.git",
+			},
+			update:  true,
+			prompts: 1,
+			expected: map[string]string{
+				"upstream": upstreamURL,
+				"backup":   "git@git.example.com:some-org/some-repo.git",
+			},
+			output: []string{
+				"The 'backup' remote is git@git.example.com:some-org/old-repo.git",
+				"Remote updated: backup git@git.example.com:some-org/some-repo.git",
+			},
+		},
+		{
+			desc: "keeps mismatched remotes when declined",
+			existing: map[string]string{
+				"backup": "git@git.example.com:some-org/old-repo.git",
+			},
+			update:  false,
+			prompts: 1,
+			expected: map[string]string{
+				"upstream": upstreamURL,
+				"backup":   "git@git.example.com:some-org/old-repo.git",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			testutil.InTempDir(t, func(dir string) {
+				app := core.NewTestApp()
+				app.Config.Remotes = remotes
+				app.GitClient = git.NewClient(dir)
+				_, _, err := app.GitClient.Exec("init")
+				require.NoError(t, err)
+				for name, url := range tt.existing {
+					require.NoError(t, app.GitClient.AddRemote(ctx, name, url))
+				}
+
+				app.GhClient = NewClientMock()
+				ghc := app.GhClient.(*gh.ClientMock)
+				ghc.GetRepoFunc = func(name string) (*gh.Repository, error) {
+					return &gh.Repository{CloneURL: "https://github.com/" + name + ".git"}, nil
+				}
+				p := app.Prompter.(*uimock.PrompterMock)
+				p.ConfirmFunc = func(msg string, value bool, help string) (bool, error) {
+					if msg != "Update the 'backup' remote?" {
+						panic(fmt.Errorf("unexpected confirm call: %s", msg))
+					}
+					return tt.update, nil
+				}
+				action := NewRootAction(app)
+
+				err = action.ensureExtraRemotes(ctx)
+				if tt.err == "" {
+					assert.NoError(t, err)
+				} else {
+					assert.ErrorContains(t, err, tt.err)
+				}
+				for name, url := range tt.expected {
+					actual, err := app.GitClient.RemoteURL(name)
+					assert.NoError(t, err)
+					assert.Equal(t, url, actual)
+				}
+				for _, s := range tt.output {
+					assert.Contains(t, app.IO.Out.String(), s)
+				}
+				assert.Equal(t, tt.prompts, len(p.ConfirmCalls()))
+			})
+		})
+	}
+}
+
+func TestRootAction_EnsureExtraRemotes_MissingRepo(t *testing.T) {
+	app := core.NewTestApp()
+	app.Config.Remotes = []core.RemoteConfig{{Name: "upstream", Repo: "other-org/missing"}}
+	app.GhClient = NewClientMock()
+	action := NewRootAction(app)
+
+	err := action.ensureExtraRemotes(context.Background())
+	assert.ErrorIs(t, err, ErrAborted)
+	assert.Contains(t, app.IO.Out.String(), "the 'other-org/missing' repo does not exist")
+}
+
+func TestRootAction_CheckRemote(t *testing.T) {
+	ctx := context.Background()
+	repo := func(fullName string, archived bool) *gh.Repository {
+		return &gh.Repository{
+			FullName: fullName,
+			CloneURL: "https://github.com/" + fullName + ".git",
+			SSHURL:   "git@github.com:" + fullName + ".git",
+			Archived: archived,
+		}
+	}
+
+	tests := []struct {
+		desc       string
+		url        string
+		repo       *gh.Repository
+		confirm    bool
+		exists     bool
+		expected   string
+		prompts    []string
+		unarchived bool
+		output     []string
+	}{
+		{
+			desc:     "leaves non-GitHub remotes alone",
+			url:      "git@git.example.com:some-org/some-repo.git",
+			exists:   true,
+			expected: "git@git.example.com:some-org/some-repo.git",
+		},
+		{
+			desc:     "leaves matching remotes alone",
+			url:      "https://github.com/test-user/repo",
+			repo:     repo("test-user/repo", false),
+			exists:   true,
+			expected: "https://github.com/test-user/repo",
+		},
+		{
+			desc:     "updates the URL of a renamed repo when confirmed",
+			url:      "https://github.com/test-user/old-name.git",
+			repo:     repo("test-user/new-name", false),
+			confirm:  true,
+			exists:   true,
+			expected: "https://github.com/test-user/new-name.git",
+			prompts:  []string{"Update the 'origin' remote to https://github.com/test-user/new-name.git?"},
+			output: []string{
+				"The test-user/old-name repo has moved to test-user/new-name.",
+				"Remote updated: origin https://github.com/test-user/new-name.git",
+			},
+		},
+		{
+			desc:     "keeps the URL of a transferred repo when declined",
+			url:      "https://github.com/test-user/repo.git",
+			repo:     repo("org1/repo", false),
+			exists:   true,
+			expected: "https://github.com/test-user/repo.git",
+			prompts:  []string{"Update the 'origin' remote to https://github.com/org1/repo.git?"},
+			output:   []string{"The test-user/repo repo has moved to org1/repo."},
+		},
+		{
+			desc:     "switches to the preferred protocol when confirmed",
+			url:      "git@github.com:test-user/repo.git",
+			repo:     repo("test-user/repo", false),
+			confirm:  true,
+			exists:   true,
+			expected: "https://github.com/test-user/repo.git",
+			prompts:  []string{"Update the 'origin' remote to https://github.com/test-user/repo.git?"},
+			output:   []string{"The 'origin' remote uses ssh, but your preferred git protocol is https."},
+		},
+		{
+			desc:       "offers to unarchive archived repos",
+			url:        "https://github.com/test-user/repo.git",
+			repo:       repo("test-user/repo", true),
+			confirm:    true,
+			exists:     true,
+			expected:   "https://github.com/test-user/repo.git",
+			prompts:    []string{"Unarchive it?"},
+			unarchived: true,
+			output: []string{
+				"The test-user/repo repo is archived, so it is read-only.",
+				"Repo unarchived: test-user/repo",
+			},
+		},
+		{
+			desc:     "reports deleted repos as missing",
+			url:      "https://github.com/test-user/repo.git",
+			expected: "https://github.com/test-user/repo.git",
+			output:   []string{"The 'origin' remote points to test-user/repo, which no longer exists on GitHub."},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			testutil.InTempDir(t, func(dir string) {
+				app := core.NewTestApp()
+				app.GitClient = git.NewClient(dir)
+				_, _, err := app.GitClient.Exec("init")
+				require.NoError(t, err)
+				require.NoError(t, app.GitClient.AddRemote(ctx, "origin", tt.url))
+
+				app.GhClient = NewClientMock()
+				ghc := app.GhClient.(*gh.ClientMock)
+				ghc.GetRepoFunc = func(name string) (*gh.Repository, error) {
+					return tt.repo, nil
+				}
+				ghc.UnarchiveRepoFunc = func(name string) (*gh.Repository, error) {
+					return tt.repo, nil
+				}
+				p := app.Prompter.(*uimock.PrompterMock)
+				prompts := []string{}
+				p.ConfirmFunc = func(msg string, value bool, help string) (bool, error) {
+					prompts = append(prompts, msg)
+					return tt.confirm, nil
+				}
+				action := NewRootAction(app)
+
+				exists, err := action.checkRemote(ctx, "origin")
+				assert.NoError(t, err)
+				assert.Equal(t, tt.exists, exists)
+
+				actual, err := app.GitClient.RemoteURL("origin")
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, actual)
+				if tt.prompts == nil {
+					tt.prompts = []string{}
+				}
+				assert.Equal(t, tt.prompts, prompts)
+				assert.Equal(t, tt.unarchived, len(ghc.UnarchiveRepoCalls()) == 1)
+				for _, s := range tt.output {
+					assert.Contains(t, app.IO.Out.String(), s)
+				}
+			})
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
@@ -8,8 +9,6 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
-	"github.com/twelvelabs/termite/ioutil"
-	"github.com/twelvelabs/termite/ui"
 
 	"github.com/twelvelabs/gh-setup/internal/core"
 	"github.com/twelvelabs/gh-setup/internal/gh"
@@ -21,15 +20,17 @@ const (
 )
 
 var (
-	ErrAborted = errors.New("aborted")
+	ErrAborted       = errors.New("aborted")
+	ErrMissingScopes = errors.New("missing token scopes")
 )
 
 func NewRootCmd(app *core.App) *cobra.Command {
 	action := NewRootAction(app)
 
 	cmd := &cobra.Command{
-		Use:   "gh-setup",
+		Use:   "gh-setup [path]",
 		Short: "Setup new GitHub repositories",
+		Args:  cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := action.Setup(cmd, args); err != nil {
 				return err
@@ -37,7 +38,10 @@ func NewRootCmd(app *core.App) *cobra.Command {
 			if err := action.Validate(); err != nil {
 				return err
 			}
-			if err := action.Run(); err != nil {
+			if err := action.Authenticate(cmd.Context()); err != nil {
+				return err
+			}
+			if err := action.Run(cmd.Context()); err != nil {
 				return err
 			}
 			return nil
@@ -46,61 +50,204 @@ func NewRootCmd(app *core.App) *cobra.Command {
 		SilenceUsage: true,
 	}
 
-	cmd.Flags().BoolVar(&action.NoPrompt, "no-prompt", false, "Do not prompt for input")
-	cmd.Flags().Lookup("no-prompt").NoOptDefVal = "true"
+	// Flags shared with the subcommands.
+	flags := cmd.PersistentFlags()
+	flags.StringVar(&app.Config.Host, "hostname", app.Config.Host, "The GitHub host to use")
+	flags.StringVar(&app.Config.Account, "account", app.Config.Account, "The gh account to use")
+	flags.StringVar(&app.Config.Owner, "owner", app.Config.Owner, "The default owner for new repos")
+	flags.StringVar(&app.Config.App.ID, "app-id", app.Config.App.ID, "Authenticate as the GitHub App with this ID")
+	flags.StringVar(&app.Config.App.PrivateKeyPath, "app-key", app.Config.App.PrivateKeyPath,
+		"Path to the GitHub App private key")
+	flags.Int64Var(&app.Config.App.InstallationID, "app-installation-id", app.Config.App.InstallationID,
+		"The GitHub App installation ID (defaults to the installation for --owner)")
+	flags.StringVar(&app.Config.Git.Backend, "git-backend", app.Config.Git.Backend,
+		"The git implementation to use (auto, system, or go)")
+	flags.BoolVar(&action.NoPrompt, "no-prompt", false, "Do not prompt for input")
+	flags.Lookup("no-prompt").NoOptDefVal = "true"
+
+	cmd.Flags().StringVar(&app.Config.Remote, "remote", app.Config.Remote,
+		"The name of the git remote for the GitHub repo")
+	cmd.Flags().StringVar(&action.Fork, "fork", "",
+		"Fork this repo (owner/name) and add it as the upstream remote")
+	cmd.Flags().StringVar(&app.Config.Template, "template", app.Config.Template,
+		"Render this template (a dir, owner/name repo, or owner) into new repos")
+
+	cmd.AddCommand(NewCloneCmd(action))
 
 	return cmd
 }
 
 func NewRootAction(app *core.App) *RootAction {
 	return &RootAction{
-		IO:        app.IO,
-		Messenger: app.Messenger,
-		Prompter:  app.Prompter,
-		GhClient:  app.GhClient,
-		GitClient: app.GitClient,
+		App: app,
 	}
 }
 
 type RootAction struct {
-	IO        *ioutil.IOStreams
-	Messenger *ui.Messenger
-	Prompter  ui.Prompter
-	GhClient  gh.Client
-	GitClient git.Client
+	*core.App
 
+	Fork     string
 	NoPrompt bool
+
+	user *gh.User
 }
 
 func (a *RootAction) Setup(cmd *cobra.Command, args []string) error {
 	if a.NoPrompt {
 		a.IO.SetInteractive(false)
 	}
-	return nil
+	dir := ""
+	if len(args) > 0 {
+		dir = args[0]
+	}
+	return a.InitGit(dir)
 }
 
 func (a *RootAction) Validate() error {
-	return nil
+	if a.Config.App.ID != "" {
+		if a.Config.App.PrivateKeyPath == "" {
+			return fmt.Errorf("--app-key is required when using --app-id")
+		}
+		if a.Config.Owner == "" {
+			return fmt.Errorf("--owner is required when using --app-id")
+		}
+	}
+	if err := a.validateFork(); err != nil {
+		return err
+	}
+	if err := a.validateHooks(); err != nil {
+		return err
+	}
+	if err := a.validateActionsConfig(); err != nil {
+		return err
+	}
+	return a.validateRemotes()
+}
+
+// Authenticate creates the GitHub clients for the chosen account (or app).
+func (a *RootAction) Authenticate(ctx context.Context) error {
+	if a.Config.App.ID == "" {
+		if err := a.selectAccount(); err != nil {
+			return err
+		}
+	}
+	return a.InitGitHub(ctx)
+}
+
+func (a *RootAction) Run(ctx context.Context) error {
+	return a.handleError(a.run(ctx))
 }
 
-func (a *RootAction) Run() error {
+// handleError reports the errors that have a friendlier message
+// (returning ErrAborted in their place) and stops the progress indicator.
+func (a *RootAction) handleError(err error) error {
+	if err == nil {
+		return nil
+	}
+	// Whatever went wrong, don't leave the spinner running.
+	a.IO.StopProgressIndicator()
+
+	apiErr := &gh.APIError{}
+	switch {
+	case errors.As(err, &apiErr):
+		a.Messenger.Failure("%s\n", apiErr.Message)
+		if apiErr.Hint != "" {
+			a.Messenger.Info("%s\n", apiErr.Hint)
+		}
+		return ErrAborted
+	case errors.Is(err, git.ErrTimeout):
+		a.Messenger.Failure("%s.\n", capitalize(err.Error()))
+		a.Messenger.Info("Git may be waiting for input (a credential prompt, SSH host key, or security key touch).\n")
+		return ErrAborted
+	case errors.Is(err, context.Canceled):
+		a.Messenger.Failure("Interrupted.\n")
+		return ErrAborted
+	}
+	return err
+}
+
+func (a *RootAction) run(ctx context.Context) error {
 	if err := a.ensureGitInstalled(); err != nil {
 		return err
 	}
 
-	if err := a.ensureWorkingDirInit(); err != nil {
+	if err := a.ensureScopes(); err != nil {
 		return err
 	}
 
-	if err := a.ensureRemote("origin"); err != nil {
+	if err := a.ensureWorkingDirInit(ctx); err != nil {
 		return err
 	}
 
-	if err := a.ensureWorkingDirClean(); err != nil {
+	if err := a.ensureGitConfig(ctx); err != nil {
 		return err
 	}
 
-	if err := a.ensurePush("origin"); err != nil {
+	if err := a.ensureHooks(ctx); err != nil {
+		return err
+	}
+
+	if a.Fork == "" {
+		// Forks build on the upstream repo instead.
+		if err := a.ensureTemplate(ctx); err != nil {
+			return err
+		}
+		if err := a.ensureWorkflows(ctx); err != nil {
+			return err
+		}
+	}
+
+	if err := a.ensureCommitIdentity(); err != nil {
+		return err
+	}
+
+	if a.Fork != "" {
+		source, err := a.ensureFork(ctx, a.Config.Remote)
+		if err != nil {
+			return err
+		}
+		// Before committing, so that local commits build on upstream.
+		if err := a.trackUpstream(ctx, source.DefaultBranch); err != nil {
+			return err
+		}
+	} else {
+		if err := a.ensureRemote(ctx, a.Config.Remote); err != nil {
+			return err
+		}
+	}
+
+	if err := a.ensureExtraRemotes(ctx); err != nil {
+		return err
+	}
+
+	if err := a.ensureCodeOwners(); err != nil {
+		return err
+	}
+
+	if err := a.ensureWorkingDirClean(ctx); err != nil {
+		return err
+	}
+
+	if err := a.ensurePush(ctx, a.Config.Remote); err != nil {
+		return err
+	}
+
+	if err := a.ensureSecurityFeatures(); err != nil {
+		return err
+	}
+
+	if err := a.ensureActionsPermissions(); err != nil {
+		return err
+	}
+
+	if a.Fork != "" {
+		// Pushing set the upstream to the fork.
+		if err := a.setRemoteHead(ctx, upstreamRemote); err != nil {
+			return err
+		}
+	}
+
+	if err := a.runPostSetup(ctx); err != nil {
 		return err
 	}
 
@@ -112,10 +259,79 @@ func (a *RootAction) ensureGitInstalled() error {
 	if a.GitClient.IsInstalled() {
 		return nil // git installed
 	}
-	return fmt.Errorf("could not find git executable in PATH")
+	return fmt.Errorf("could not find git executable in PATH (use --git-backend=go to run without it)")
+}
+
+func (a *RootAction) ensureScopes() error {
+	required, err := a.requiredScopes()
+	if err != nil {
+		return err
+	}
+	return a.checkScopes(required)
+}
+
+// checkScopes returns ErrMissingScopes (with instructions for adding them)
+// if the token is missing any of the required OAuth scopes.
+func (a *RootAction) checkScopes(required []string) error {
+	if a.Config.App.ID != "" {
+		return nil // installation tokens use permissions rather than scopes
+	}
+	granted, err := a.GhClient.TokenScopes()
+	if err != nil {
+		return err
+	}
+	if granted == nil {
+		return nil // fine-grained token - nothing to check
+	}
+	missing := gh.MissingScopes(granted, required)
+	if len(missing) == 0 {
+		return nil // all good
+	}
+	a.Messenger.Failure("The GitHub token is missing scopes needed for setup: %s\n", strings.Join(missing, ", "))
+	a.Messenger.Info("To add them, run: gh auth refresh -h %s -s %s\n", a.Config.Host, strings.Join(missing, ","))
+	return ErrMissingScopes
 }
 
-func (a *RootAction) ensureWorkingDirInit() error {
+// requiredScopes returns the OAuth scopes needed by the enabled steps.
+func (a *RootAction) requiredScopes() ([]string, error) {
+	// Creating (private) repos and pushing to them,
+	// and enabling their security features and Actions settings.
+	// Listing the orgs the user can create repos in (and the CODEOWNERS teams).
+	scopes := []string{"repo", "read:org"}
+	if _, err := os.Stat(filepath.Join(a.Dir, ".github", "workflows")); err == nil {
+		// Pushing workflow files.
+		scopes = append(scopes, "workflow")
+	}
+	user, err := a.currentUser()
+	if err != nil {
+		return nil, err
+	}
+	if user.GitProtocol == gh.ProtocolSSH {
+		// Checking for (and uploading) the SSH key of the remotes.
+		scopes = append(scopes, "write:public_key")
+	}
+	if !a.GitClient.HasCommits() || a.GitClient.IsDirty() {
+		// Checking the email and signing key of the commit.
+		scopes = append(scopes, "user:email")
+		format, err := a.signingFormat()
+		if err != nil {
+			return nil, err
+		}
+		switch format {
+		case "openpgp":
+			scopes = append(scopes, "read:gpg_key")
+		case "ssh":
+			scopes = append(scopes, "read:ssh_signing_key")
+		}
+	}
+	if len(a.Config.CodeOwners) > 0 {
+		// Granting the CODEOWNERS teams write access.
+		scopes = append(scopes, "admin:org")
+	}
+	return scopes, nil
+}
+
+func (a *RootAction) ensureWorkingDirInit(ctx context.Context) error {
 	if a.GitClient.IsInitialized() {
 		return nil // working dir already initialized
 	}
@@ -127,14 +343,30 @@ func (a *RootAction) ensureWorkingDirInit() error {
 		a.Messenger.Failure("Unable to continue until the working directory is initialized.\n")
 		return ErrAborted
 	}
-	_, _, err = a.GitClient.Exec("init")
+	err = a.GitClient.Init(ctx)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (a *RootAction) selectAccount() error {
+	if a.Config.Account != "" {
+		return nil // account already chosen
+	}
+	logins := gh.Logins(a.Config.Host)
+	if len(logins) < 2 {
+		return nil // nothing to choose from - use the gh default
+	}
+	login, err := a.Prompter.Select("GitHub account", logins, logins[0], "")
 	if err != nil {
 		return err
 	}
+	a.Config.Account = login
 	return nil
 }
 
-func (a *RootAction) ensureWorkingDirClean() error {
+func (a *RootAction) ensureWorkingDirClean(ctx context.Context) error {
 	if !a.GitClient.IsDirty() {
 		return nil // working dir clean
 	}
@@ -151,33 +383,52 @@ func (a *RootAction) ensureWorkingDirClean() error {
 		a.Messenger.Failure("Unable to continue until the working directory is clean.\n")
 		return ErrAborted
 	}
-	err = a.commit()
+	err = a.commit(ctx)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func (a *RootAction) ensureRemote(remote string) error {
-	repo, _ := a.GhClient.CurrentRemote()
-	if repo != nil {
-		return nil // remote already configured - nothing to do
+func (a *RootAction) ensureRemote(ctx context.Context, remote string) error {
+	if a.GitClient.HasRemote(remote) {
+		exists, err := a.checkRemote(ctx, remote)
+		if err != nil || exists {
+			return err
+		}
+		// The repo is gone, so set it up as if there were no remote.
 	}
 
 	// 1. Resolve the assumed repoName of the working directory.
-	user, err := a.GhClient.CurrentUser()
+	user, err := a.currentUser()
 	if err != nil {
 		return err
 	}
-	dir, err := os.Getwd()
+	dir, err := a.workingDir()
 	if err != nil {
 		return err
 	}
 	dir = filepath.Base(dir)
-	repoName := fmt.Sprintf("%s/%s", user.Login, dir)
+
+	owners := []string{user.Login}
+	for _, org := range user.Orgs {
+		owners = append(owners, org.Login)
+	}
+	defaultOwner := user.Login
+	if a.Config.Owner != "" {
+		if contains(owners, a.Config.Owner) {
+			defaultOwner = a.Config.Owner
+		} else {
+			a.Messenger.Warning(
+				"The '%s' owner is not visible to the '%s' account (try a different --account).\n",
+				a.Config.Owner, user.Login,
+			)
+		}
+	}
+	repoName := fmt.Sprintf("%s/%s", defaultOwner, dir)
 
 	// 2. Check to see if a repo already exists with that name.
-	repo, err = a.GhClient.GetRepo(repoName)
+	repo, err := a.GhClient.GetRepo(repoName)
 	if err != nil {
 		return err
 	}
@@ -190,7 +441,7 @@ func (a *RootAction) ensureRemote(remote string) error {
 		}
 		if ok {
 			// 2b. Set remote...
-			if err := a.setRemote(remote, repo, user); err != nil {
+			if err := a.setRemote(ctx, remote, repo, user); err != nil {
 				return err
 			}
 			return nil
@@ -207,11 +458,7 @@ func (a *RootAction) ensureRemote(remote string) error {
 		return ErrAborted
 	}
 
-	owners := []string{user.Login}
-	for _, org := range user.Orgs {
-		owners = append(owners, org.Login)
-	}
-	owner, err := a.Prompter.Select("GitHub repo owner", owners, user.Login, "")
+	owner, err := a.Prompter.Select("GitHub repo owner", owners, defaultOwner, "")
 	if err != nil {
 		return err
 	}
@@ -238,13 +485,43 @@ func (a *RootAction) ensureRemote(remote string) error {
 	}
 	a.Messenger.Success("Repo created: %s\n", repo.URL)
 
-	if err := a.setRemote(remote, repo, user); err != nil {
+	if err := a.setRemote(ctx, remote, repo, user); err != nil {
 		return err
 	}
 	return nil
 }
 
-func (a *RootAction) ensurePush(remote string) error {
+// workingDir returns the absolute path of the directory being setup.
+func (a *RootAction) workingDir() (string, error) {
+	if a.Dir != "" {
+		return a.Dir, nil
+	}
+	return os.Getwd()
+}
+
+// currentUser returns the user that repos are created for.
+// Apps have no user, so the configured owner stands in for one.
+func (a *RootAction) currentUser() (*gh.User, error) {
+	if a.user != nil {
+		return a.user, nil
+	}
+	if a.Config.App.ID != "" {
+		a.user = &gh.User{
+			Login:       a.Config.Owner,
+			Orgs:        []*gh.Account{},
+			GitProtocol: gh.ProtocolHTTPS,
+		}
+		return a.user, nil
+	}
+	user, err := a.GhClient.CurrentUser()
+	if err != nil {
+		return nil, err
+	}
+	a.user = user
+	return user, nil
+}
+
+func (a *RootAction) ensurePush(ctx context.Context, remote string) error {
 	if !a.GitClient.HasCommits() {
 		return nil // no commits - nothing to push
 	}
@@ -257,16 +534,16 @@ func (a *RootAction) ensurePush(remote string) error {
 	}
 
 	a.IO.StartProgressIndicatorWithLabel("Pushing")
-	_, _, err = a.GitClient.Exec("push", "-u", remote, "HEAD")
+	err = a.GitClient.Push(ctx, remote, a.gitProgress("Pushing"))
 	if err != nil {
 		// If the push failed, then it's likely due to being behind the remote,
 		// and the error message suggests running `git pull`.
 		// Ensure that the upstream is correctly set so that the pull works.
 		remoteHead := fmt.Sprintf("%s/HEAD", remote)
-		_, _, _ = a.GitClient.Exec("branch", "-u", remoteHead, "HEAD")
+		_ = a.GitClient.SetUpstream(ctx, remoteHead)
 		return err
 	}
-	if err := a.setRemoteHead(remote); err != nil {
+	if err := a.setRemoteHead(ctx, remote); err != nil {
 		a.IO.StopProgressIndicator()
 		return err
 	}
@@ -274,21 +551,46 @@ func (a *RootAction) ensurePush(remote string) error {
 	return nil
 }
 
-func (a *RootAction) setRemote(remote string, repo *gh.Repository, user *gh.User) error {
-	url := repo.RemoteURL(user.GitProtocol)
+// gitProgress returns a func that shows git progress updates
+// (e.g. "Pushing: Writing objects 45% (9/20)") in the progress indicator.
+// Returns nil (i.e. just the spinner) when stderr is not a terminal.
+func (a *RootAction) gitProgress(label string) git.ProgressFunc {
+	if !a.IO.ProgressIndicatorEnabled() || !a.IO.IsStderrTTY() {
+		return nil
+	}
+	return func(p git.Progress) {
+		a.IO.StartProgressIndicatorWithLabel(gitProgressLabel(label, p))
+	}
+}
+
+// gitProgressLabel returns the progress indicator label for a git progress update.
+func gitProgressLabel(label string, p git.Progress) string {
+	return fmt.Sprintf("%s: %s", label, p)
+}
+
+func (a *RootAction) setRemote(ctx context.Context, remote string, repo *gh.Repository, user *gh.User) error {
+	protocol, err := a.ensureSSHKey(user.GitProtocol)
+	if err != nil {
+		return err
+	}
+	url := repo.RemoteURL(protocol)
 	a.IO.StartProgressIndicatorWithLabel("Adding remote")
-	_, _, err := a.GitClient.Exec("remote", "add", remote, url)
+	if a.GitClient.HasRemote(remote) {
+		err = a.GitClient.SetRemoteURL(ctx, remote, url)
+	} else {
+		err = a.GitClient.AddRemote(ctx, remote, url)
+	}
 	if err != nil {
 		return err
 	}
 	if os.Getenv("APP_ENV") != EnvTest {
 		a.IO.StartProgressIndicatorWithLabel("Fetching")
-		_, _, err := a.GitClient.Exec("fetch", remote)
+		err := a.GitClient.Fetch(ctx, remote, a.gitProgress("Fetching"))
 		if err != nil {
 			a.IO.StopProgressIndicator()
 			return err
 		}
-		if err := a.setRemoteHead(remote); err != nil {
+		if err := a.setRemoteHead(ctx, remote); err != nil {
 			a.IO.StopProgressIndicator()
 			return err
 		}
@@ -298,18 +600,18 @@ func (a *RootAction) setRemote(remote string, repo *gh.Repository, user *gh.User
 	return nil
 }
 
-func (a *RootAction) setRemoteHead(remote string) error {
+func (a *RootAction) setRemoteHead(ctx context.Context, remote string) error {
 	if os.Getenv("APP_ENV") == EnvTest {
 		return nil
 	}
 	a.IO.StartProgressIndicatorWithLabel("Setting remote HEAD")
 	// If there are no commits in the remote, then this may error.
-	_, _, err := a.GitClient.Exec("remote", "set-head", remote, "-a")
+	err := a.GitClient.SetRemoteHead(ctx, remote)
 	if err == nil && a.GitClient.HasCommits() {
 		// Ok, we have both local _and_ remote HEADs - set upstream.
 		remoteHead := fmt.Sprintf("%s/HEAD", remote)
 		a.IO.StartProgressIndicatorWithLabel("Setting upstream")
-		_, _, err = a.GitClient.Exec("branch", "-u", remoteHead, "HEAD")
+		err = a.GitClient.SetUpstream(ctx, remoteHead)
 		if err != nil {
 			a.IO.StopProgressIndicator()
 			return err
@@ -319,6 +621,26 @@ func (a *RootAction) setRemoteHead(remote string) error {
 	return nil
 }
 
+// settingChange is a setting (e.g. a git config key) that differs
+// from the configured value.
+type settingChange struct {
+	Key   string
+	From  string
+	Value string
+}
+
+// printChanges shows changes as a diff (omitting unset values).
+func (a *RootAction) printChanges(changes []settingChange) {
+	fmt.Fprintf(a.IO.Err, "\n")
+	for _, change := range changes {
+		if change.From != "" {
+			fmt.Fprintf(a.IO.Err, "- %s = %s\n", change.Key, change.From)
+		}
+		fmt.Fprintf(a.IO.Err, "+ %s = %s\n", change.Key, change.Value)
+	}
+	fmt.Fprintf(a.IO.Err, "\n")
+}
+
 func (a *RootAction) promptToCommit(lines []string) (bool, error) {
 	a.Messenger.Info("There are uncommitted files in the working directory:\n")
 	fmt.Fprintf(a.IO.Err, "\n")
@@ -329,8 +651,8 @@ func (a *RootAction) promptToCommit(lines []string) (bool, error) {
 	return a.Prompter.Confirm("Add and commit?", true, "")
 }
 
-func (a *RootAction) commit() error {
-	_, _, err := a.GitClient.Exec("add", ".")
+func (a *RootAction) commit(ctx context.Context) error {
+	err := a.GitClient.Add(ctx, ".")
 	if err != nil {
 		return err
 	}
@@ -338,16 +660,38 @@ func (a *RootAction) commit() error {
 	if err != nil {
 		return err
 	}
-	args := []string{"commit", "-m", msg}
-	if os.Getenv("APP_ENV") == EnvTest {
-		// Don't sign when testing because some folks (:raise_hand:)
-		// use a YubiKey and it will block waiting for approval.
-		args = append(args, "--no-gpg-sign", "--no-verify")
-	}
 	// Starting a progress indicator for the YubiKey folks as a reminder
 	// that they need to touch to approve.
 	a.IO.StartProgressIndicatorWithLabel("Committing")
-	_, _, err = a.GitClient.Exec(args...)
+	err = a.GitClient.Commit(ctx, msg, a.commitOptions())
 	a.IO.StopProgressIndicator()
 	return err
 }
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// expandHome replaces a leading "~/" in path with the home dir.
+func expandHome(path string) (string, error) {
+	if !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, path[2:]), nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
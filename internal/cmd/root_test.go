@@ -1,8 +1,10 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -40,13 +42,65 @@ func TestRootAction_Run(t *testing.T) {
 			err: "could not find git",
 		},
 
+		{
+			desc: "returns an error if the token is missing scopes",
+			setup: func(t *testing.T, a *RootAction) {
+				t.Helper()
+
+				a.Config.Host = "github.com"
+				a.GhClient = NewClientMock()
+				ghc := a.GhClient.(*gh.ClientMock)
+				ghc.TokenScopesFunc = func() ([]string, error) {
+					return []string{"public_repo"}, nil
+				}
+				a.GitClient = newTestGitClient()
+				// pushing workflows requires an extra scope
+				_ = os.MkdirAll(".github/workflows", 0750)
+			},
+			assertions: func(t *testing.T, a *RootAction) {
+				t.Helper()
+
+				// nothing should have been done
+				assert.Equal(t, false, a.GitClient.IsInitialized())
+				assert.Contains(t, a.IO.Out.String(),
+					"missing scopes needed for setup: repo, read:org, workflow, user:email\n")
+				assert.Contains(t, a.IO.Out.String(),
+					"gh auth refresh -h github.com -s repo,read:org,workflow,user:email\n")
+			},
+			err: "missing token scopes",
+		},
+		{
+			desc: "skips the scope check for tokens without scopes",
+			setup: func(t *testing.T, a *RootAction) {
+				t.Helper()
+
+				a.GhClient = NewClientMock()
+				ghc := a.GhClient.(*gh.ClientMock)
+				ghc.TokenScopesFunc = func() ([]string, error) {
+					return nil, nil
+				}
+				a.GitClient = newTestGitClient()
+
+				p := a.Prompter.(*uimock.PrompterMock)
+				p.ConfirmFunc = func(msg string, value bool, help string) (bool, error) {
+					switch msg {
+					case "Initialize the repo?":
+						return false, nil
+					default:
+						panic(fmt.Errorf("unexpected confirm call: %s", msg))
+					}
+				}
+			},
+			err: "aborted",
+		},
+
 		{
 			desc: "prompts to run git init if needed",
 			setup: func(t *testing.T, a *RootAction) {
 				t.Helper()
 
 				a.GhClient = NewClientMock()
-				a.GitClient = git.DefaultClient
+				a.GitClient = newTestGitClient()
 
 				p := a.Prompter.(*uimock.PrompterMock)
 				p.ConfirmFunc = func(msg string, value bool, help string) (bool, error) {
@@ -77,7 +131,7 @@ func TestRootAction_Run(t *testing.T) {
 				t.Helper()
 
 				a.GhClient = NewClientMock()
-				a.GitClient = git.DefaultClient
+				a.GitClient = newTestGitClient()
 
 				p := a.Prompter.(*uimock.PrompterMock)
 				p.ConfirmFunc = func(msg string, value bool, help string) (bool, error) {
@@ -100,13 +154,356 @@ func TestRootAction_Run(t *testing.T) {
 			},
 			err: "aborted",
 		},
+		{
+			desc: "sets up the repo at the given path",
+			setup: func(t *testing.T, a *RootAction) {
+				t.Helper()
+
+				a.GhClient = NewClientMock()
+				require.NoError(t, os.Mkdir("some-project", 0750))
+				require.NoError(t, a.InitGit("some-project"))
+
+				p := a.Prompter.(*uimock.PrompterMock)
+				p.ConfirmFunc = func(msg string, value bool, help string) (bool, error) {
+					switch msg {
+					case "Initialize the repo?":
+						return true, nil
+					case "Create a new repo on GitHub?":
+						return false, nil
+					default:
+						panic(fmt.Errorf("unexpected confirm call: %s", msg))
+					}
+				}
+			},
+			assertions: func(t *testing.T, a *RootAction) {
+				t.Helper()
+
+				// the repo was initialized in (and named after) the path
+				assert.DirExists(t, filepath.Join("some-project", ".git"))
+				assert.Equal(t, false, git.IsInitialized())
+				ghc := a.GhClient.(*gh.ClientMock)
+				assert.Equal(t, 1, len(ghc.GetRepoCalls()))
+				assert.Equal(t, "test-user/some-project", ghc.GetRepoCalls()[0].Name)
+			},
+			err: "aborted",
+		},
+		{
+			desc: "looks for existing repos under the configured owner",
+			setup: func(t *testing.T, a *RootAction) {
+				t.Helper()
+
+				a.Config.Owner = "org1"
+				a.GhClient = NewClientMock()
+				a.GitClient = newTestGitClient()
+				_, _, err := a.GitClient.Exec("init")
+				assert.NoError(t, err)
+
+				p := a.Prompter.(*uimock.PrompterMock)
+				p.ConfirmFunc = func(msg string, value bool, help string) (bool, error) {
+					switch msg {
+					case "Create a new repo on GitHub?":
+						return false, nil
+					default:
+						panic(fmt.Errorf("unexpected confirm call: %s", msg))
+					}
+				}
+			},
+			assertions: func(t *testing.T, a *RootAction) {
+				t.Helper()
+
+				ghc := a.GhClient.(*gh.ClientMock)
+				assert.Equal(t, 1, len(ghc.GetRepoCalls()))
+				assert.Contains(t, ghc.GetRepoCalls()[0].Name, "org1/")
+				assert.NotContains(t, a.IO.Out.String(), "not visible")
+			},
+			err: "aborted",
+		},
+		{
+			desc: "warns when the configured owner is not visible to the account",
+			setup: func(t *testing.T, a *RootAction) {
+				t.Helper()
+
+				a.Config.Owner = "some-other-org"
+				a.GhClient = NewClientMock()
+				a.GitClient = newTestGitClient()
+				_, _, err := a.GitClient.Exec("init")
+				assert.NoError(t, err)
+
+				p := a.Prompter.(*uimock.PrompterMock)
+				p.ConfirmFunc = func(msg string, value bool, help string) (bool, error) {
+					switch msg {
+					case "Create a new repo on GitHub?":
+						return false, nil
+					default:
+						panic(fmt.Errorf("unexpected confirm call: %s", msg))
+					}
+				}
+			},
+			assertions: func(t *testing.T, a *RootAction) {
+				t.Helper()
+
+				ghc := a.GhClient.(*gh.ClientMock)
+				assert.Equal(t, 1, len(ghc.GetRepoCalls()))
+				assert.Contains(t, ghc.GetRepoCalls()[0].Name, "test-user/")
+				assert.Contains(t, a.IO.Out.String(),
+					"The 'some-other-org' owner is not visible to the 'test-user' account")
+			},
+			err: "aborted",
+		},
+
+		{
+			desc: "uses the configured owner instead of the current user for apps",
+			setup: func(t *testing.T, a *RootAction) {
+				t.Helper()
+
+				a.Config.App.ID = "12345"
+				a.Config.Owner = "org1"
+				a.GhClient = NewClientMock()
+				ghc := a.GhClient.(*gh.ClientMock)
+				ghc.CurrentUserFunc = func() (*gh.User, error) {
+					panic("unexpected current user call")
+				}
+				a.GitClient = newTestGitClient()
+				_, _, err := a.GitClient.Exec("init")
+				assert.NoError(t, err)
+
+				p := a.Prompter.(*uimock.PrompterMock)
+				p.ConfirmFunc = func(msg string, value bool, help string) (bool, error) {
+					switch msg {
+					case "Create a new repo on GitHub?":
+						return false, nil
+					default:
+						panic(fmt.Errorf("unexpected confirm call: %s", msg))
+					}
+				}
+			},
+			assertions: func(t *testing.T, a *RootAction) {
+				t.Helper()
+
+				ghc := a.GhClient.(*gh.ClientMock)
+				assert.Equal(t, 1, len(ghc.GetRepoCalls()))
+				assert.Contains(t, ghc.GetRepoCalls()[0].Name, "org1/")
+			},
+			err: "aborted",
+		},
+
+		{
+			desc: "prints friendly messages for GitHub API errors",
+			setup: func(t *testing.T, a *RootAction) {
+				t.Helper()
+
+				a.GhClient = NewClientMock()
+				ghc := a.GhClient.(*gh.ClientMock)
+				ghc.CreateRepoFunc = func(owner, name string, vis gh.Visibility) (*gh.Repository, error) {
+					return nil, &gh.APIError{
+						Message: "A repo with that name already exists on this account.",
+						Hint:    "Choose a different name, or add the existing repo as a remote.",
+					}
+				}
+				a.GitClient = newTestGitClient()
+				_, _, err := a.GitClient.Exec("init")
+				assert.NoError(t, err)
+
+				p := a.Prompter.(*uimock.PrompterMock)
+				p.ConfirmFunc = func(msg string, value bool, help string) (bool, error) {
+					return true, nil
+				}
+				p.InputFunc = func(msg, value, help string) (string, error) {
+					return value, nil
+				}
+				p.SelectFunc = func(msg string, options []string, value, help string) (string, error) {
+					return value, nil
+				}
+			},
+			assertions: func(t *testing.T, a *RootAction) {
+				t.Helper()
+
+				assert.Contains(t, a.IO.Out.String(), "A repo with that name already exists on this account.")
+				assert.Contains(t, a.IO.Out.String(), "Choose a different name, or add the existing repo as a remote.")
+			},
+			err: "aborted",
+		},
+		{
+			desc: "prints a hint when git times out",
+			setup: func(t *testing.T, a *RootAction) {
+				t.Helper()
+
+				a.GhClient = NewClientMock()
+				gc := a.GitClient.(*git.ClientMock)
+				gc.IsInstalledFunc = func() bool {
+					return true
+				}
+				gc.IsInitializedFunc = func() bool {
+					return false
+				}
+				gc.HasCommitsFunc = func() bool {
+					return false
+				}
+				gc.ConfigFunc = func(key string) (string, error) {
+					return "", nil
+				}
+				gc.InitFunc = func(ctx context.Context) error {
+					return fmt.Errorf("git init %w after 10m0s", git.ErrTimeout)
+				}
+
+				p := a.Prompter.(*uimock.PrompterMock)
+				p.ConfirmFunc = func(msg string, value bool, help string) (bool, error) {
+					return true, nil
+				}
+			},
+			assertions: func(t *testing.T, a *RootAction) {
+				t.Helper()
+
+				assert.Contains(t, a.IO.Out.String(), "Git init timed out after 10m0s.")
+				assert.Contains(t, a.IO.Out.String(), "Git may be waiting for input")
+			},
+			err: "aborted",
+		},
+		{
+			desc: "aborts when interrupted",
+			setup: func(t *testing.T, a *RootAction) {
+				t.Helper()
+
+				a.GhClient = NewClientMock()
+				gc := a.GitClient.(*git.ClientMock)
+				gc.IsInstalledFunc = func() bool {
+					return true
+				}
+				gc.IsInitializedFunc = func() bool {
+					return false
+				}
+				gc.HasCommitsFunc = func() bool {
+					return false
+				}
+				gc.ConfigFunc = func(key string) (string, error) {
+					return "", nil
+				}
+				gc.InitFunc = func(ctx context.Context) error {
+					return fmt.Errorf("git init: %w", context.Canceled)
+				}
+
+				p := a.Prompter.(*uimock.PrompterMock)
+				p.ConfirmFunc = func(msg string, value bool, help string) (bool, error) {
+					return true, nil
+				}
+			},
+			assertions: func(t *testing.T, a *RootAction) {
+				t.Helper()
+
+				assert.Contains(t, a.IO.Out.String(), "Interrupted.")
+			},
+			err: "aborted",
+		},
+
+		{
+			desc: "uses the configured remote name",
+			setup: func(t *testing.T, a *RootAction) {
+				t.Helper()
+
+				a.Config.Remote = "github"
+				a.GhClient = NewClientMock()
+				ghc := a.GhClient.(*gh.ClientMock)
+				ghc.GetRepoFunc = func(name string) (*gh.Repository, error) {
+					return &gh.Repository{
+						FullName: "test-user/repo",
+						CloneURL: "https://github.com/test-user/repo.git",
+					}, nil
+				}
+				a.GitClient = newTestGitClient()
+				_, _, err := a.GitClient.Exec("init")
+				assert.NoError(t, err)
+				err = a.GitClient.AddRemote(context.Background(), "github", "https://github.com/test-user/repo.git")
+				assert.NoError(t, err)
+			},
+			assertions: func(t *testing.T, a *RootAction) {
+				t.Helper()
 
+				assert.Equal(t, true, git.HasRemote("github"))
+				assert.Equal(t, false, git.HasRemote("origin"))
+				assert.Contains(t, a.IO.Out.String(), "Setup complete.")
+			},
+		},
+		{
+			desc: "builds on the upstream repo instead of the template when forking",
+			setup: func(t *testing.T, a *RootAction) {
+				t.Helper()
+				ctx := context.Background()
+
+				// The template would overwrite the README of upstream.
+				tmplDir := t.TempDir()
+				require.NoError(t, os.WriteFile(filepath.Join(tmplDir, "README.md"), []byte("# template"), 0600))
+				a.Config.Template = tmplDir
+				a.Config.CI.Enabled = true
+
+				srcDir := t.TempDir()
+				src := git.NewClient(srcDir, testGitIdentity)
+				_, _, err := src.Exec("init", "-b", "main")
+				require.NoError(t, err)
+				require.NoError(t, os.WriteFile(filepath.Join(srcDir, "README.md"), []byte("# upstream"), 0600))
+				require.NoError(t, os.WriteFile(filepath.Join(srcDir, "go.mod"), []byte("module example.com/a"), 0600))
+				require.NoError(t, src.Add(ctx, "."))
+				require.NoError(t, src.Commit(ctx, "Initial commit", git.CommitOptions{NoSign: true, NoVerify: true}))
+				source := &gh.Repository{
+					Name:          "some-repo",
+					FullName:      "other-org/some-repo",
+					CloneURL:      "file://" + filepath.ToSlash(srcDir),
+					DefaultBranch: "main",
+				}
+				fork := &gh.Repository{
+					Name:          "some-repo",
+					FullName:      "test-user/some-repo",
+					CloneURL:      "https://github.com/test-user/some-repo.git",
+					Fork:          true,
+					DefaultBranch: "main",
+				}
+
+				a.Fork = source.FullName
+				a.GhClient = NewClientMock()
+				ghc := a.GhClient.(*gh.ClientMock)
+				ghc.GetRepoFunc = func(name string) (*gh.Repository, error) {
+					if name == source.FullName {
+						return source, nil
+					}
+					return fork, nil
+				}
+				a.GitClient = newTestGitClient()
+				_, _, err = a.GitClient.Exec("init")
+				require.NoError(t, err)
+				require.NoError(t, a.GitClient.AddRemote(ctx, "origin", fork.CloneURL))
+				require.NoError(t, a.GitClient.AddRemote(ctx, "upstream", source.CloneURL))
+				// Fetching is skipped in tests.
+				require.NoError(t, a.GitClient.Fetch(ctx, "upstream", nil))
+
+				p := a.Prompter.(*uimock.PrompterMock)
+				p.ConfirmFunc = func(msg string, value bool, help string) (bool, error) {
+					switch msg {
+					case "Sync test-user/some-repo with the latest changes from other-org/some-repo?":
+						return false, nil
+					case "Push local commits to the remote?":
+						return false, nil
+					default:
+						panic(fmt.Errorf("unexpected confirm call: %s", msg))
+					}
+				}
+			},
+			assertions: func(t *testing.T, a *RootAction) {
+				t.Helper()
+
+				content, err := os.ReadFile("README.md")
+				assert.NoError(t, err)
+				assert.Equal(t, "# upstream", string(content))
+				assert.NoDirExists(t, filepath.Join(".github", "workflows"))
+				assert.Equal(t, false, a.GitClient.IsDirty())
+				assert.Contains(t, a.IO.Out.String(), "Checked out main from upstream")
+				assert.Contains(t, a.IO.Out.String(), "Setup complete.")
+			},
+		},
 		{
 			desc: "creates a new repo",
 			setup: func(t *testing.T, a *RootAction) {
 				t.Helper()
 
-				a.GitClient = git.DefaultClient
+				a.GitClient = newTestGitClient()
 
 				a.GhClient = NewClientMock()
 				ghc := a.GhClient.(*gh.ClientMock)
@@ -194,7 +591,7 @@ func TestRootAction_Run(t *testing.T) {
 				}
 
 				// run the action
-				err := action.Run()
+				err := action.Run(context.Background())
 				// assert error
 				if tt.err == "" {
 					require.NoError(t, err)
@@ -211,8 +608,300 @@ func TestRootAction_Run(t *testing.T) {
 	}
 }
 
+func TestRootAction_Setup(t *testing.T) {
+	tests := []struct {
+		desc  string
+		args  []string
+		setup func(t *testing.T, a *RootAction)
+		dir   string
+		err   string
+	}{
+		{
+			desc: "uses the working dir by default",
+			args: []string{},
+			dir:  "",
+		},
+		{
+			desc: "uses the path when given",
+			args: []string{"some-project"},
+			setup: func(t *testing.T, a *RootAction) {
+				t.Helper()
+				require.NoError(t, os.Mkdir("some-project", 0750))
+			},
+			dir: "some-project",
+		},
+		{
+			desc: "returns an error if the path does not exist",
+			args: []string{"some-project"},
+			err:  "no such file or directory",
+		},
+		{
+			desc: "returns an error if the path is not a directory",
+			args: []string{"some-file"},
+			setup: func(t *testing.T, a *RootAction) {
+				t.Helper()
+				require.NoError(t, os.WriteFile("some-file", []byte("aaa"), 0600))
+			},
+			err: "some-file is not a directory",
+		},
+		{
+			desc: "returns an error for unknown git backends",
+			args: []string{},
+			setup: func(t *testing.T, a *RootAction) {
+				t.Helper()
+				a.Config.Git.Backend = "nope"
+			},
+			err: "invalid git backend: nope",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			testutil.InTempDir(t, func(tmpDir string) {
+				app := core.NewTestApp()
+				action := NewRootAction(app)
+				if tt.setup != nil {
+					tt.setup(t, action)
+				}
+
+				err := action.Setup(NewRootCmd(app), tt.args)
+
+				if tt.err != "" {
+					assert.ErrorContains(t, err, tt.err)
+					return
+				}
+				assert.NoError(t, err)
+				_, isMock := action.GitClient.(*git.ClientMock)
+				assert.False(t, isMock)
+				if tt.dir == "" {
+					assert.Equal(t, "", action.Dir)
+				} else {
+					assert.True(t, filepath.IsAbs(action.Dir))
+					assert.Equal(t, tt.dir, filepath.Base(action.Dir))
+					assert.False(t, action.GitClient.IsInitialized())
+				}
+			})
+		})
+	}
+}
+
+func TestRootAction_GitProgress(t *testing.T) {
+	app := core.NewTestApp()
+	action := NewRootAction(app)
+
+	// no progress updates when there is no progress indicator
+	assert.Nil(t, action.gitProgress("Pushing"))
+
+	app.IO.SetStderrTTY(true)
+	app.IO.SetProgressIndicatorEnabled(true)
+	assert.NotNil(t, action.gitProgress("Pushing"))
+
+	assert.Equal(t, "Pushing: Writing objects 50% (1/2)", gitProgressLabel("Pushing", git.Progress{
+		Phase:   "Writing objects",
+		Percent: 50,
+		Current: 1,
+		Total:   2,
+	}))
+}
+
+func TestRootAction_RequiredScopes(t *testing.T) {
+	tests := []struct {
+		desc       string
+		commits    bool
+		config     map[string]string
+		protocol   gh.Protocol
+		codeOwners []core.CodeOwnersRule
+		expected   []string
+	}{
+		{
+			desc:     "needs to create and push repos",
+			commits:  true,
+			expected: []string{"repo", "read:org"},
+		},
+		{
+			desc:     "checks the commit email of new commits",
+			expected: []string{"repo", "read:org", "user:email"},
+		},
+		{
+			desc:     "checks the GPG key of signed commits",
+			config:   map[string]string{"commit.gpgsign": "true"},
+			expected: []string{"repo", "read:org", "user:email", "read:gpg_key"},
+		},
+		{
+			desc:     "checks the SSH signing key of signed commits",
+			config:   map[string]string{"commit.gpgsign": "true", "gpg.format": "ssh"},
+			expected: []string{"repo", "read:org", "user:email", "read:ssh_signing_key"},
+		},
+		{
+			desc:     "checks the SSH keys of SSH users",
+			commits:  true,
+			protocol: gh.ProtocolSSH,
+			expected: []string{"repo", "read:org", "write:public_key"},
+		},
+		{
+			desc:       "grants CODEOWNERS teams access",
+			commits:    true,
+			codeOwners: []core.CodeOwnersRule{{Path: "*", Owners: []string{"@some-org/some-team"}}},
+			expected:   []string{"repo", "read:org", "admin:org"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			t.Setenv("APP_ENV", "") // so that commits are signed
+			app := core.NewTestApp()
+			app.Dir = t.TempDir()
+			app.Config.CodeOwners = tt.codeOwners
+			app.GhClient = &gh.ClientMock{
+				CurrentUserFunc: func() (*gh.User, error) {
+					return &gh.User{Login: "test-user", GitProtocol: tt.protocol}, nil
+				},
+			}
+			app.GitClient = &git.ClientMock{
+				ConfigFunc: func(key string) (string, error) {
+					return tt.config[key], nil
+				},
+				HasCommitsFunc: func() bool {
+					return tt.commits
+				},
+				IsDirtyFunc: func() bool {
+					return false
+				},
+			}
+			action := NewRootAction(app)
+
+			scopes, err := action.requiredScopes()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, scopes)
+		})
+	}
+}
+
+func TestRootAction_Validate(t *testing.T) {
+	tests := []struct {
+		desc   string
+		config core.Config
+		err    string
+	}{
+		{
+			desc:   "passes by default",
+			config: core.Config{},
+		},
+		{
+			desc: "passes when app credentials and an owner are given",
+			config: core.Config{
+				Owner: "org1",
+				App: core.AppConfig{
+					ID:             "12345",
+					PrivateKeyPath: "app.pem",
+				},
+			},
+		},
+		{
+			desc: "requires a private key for apps",
+			config: core.Config{
+				Owner: "org1",
+				App: core.AppConfig{
+					ID: "12345",
+				},
+			},
+			err: "--app-key is required",
+		},
+		{
+			desc: "requires an owner for apps",
+			config: core.Config{
+				App: core.AppConfig{
+					ID:             "12345",
+					PrivateKeyPath: "app.pem",
+				},
+			},
+			err: "--owner is required",
+		},
+		{
+			desc: "requires valid actions default permissions",
+			config: core.Config{
+				Actions: core.ActionsConfig{
+					Enabled:            true,
+					DefaultPermissions: "admin",
+					AllowedActions:     "selected",
+				},
+			},
+			err: "actions: invalid default_permissions: admin",
+		},
+		{
+			desc: "requires valid allowed actions",
+			config: core.Config{
+				Actions: core.ActionsConfig{
+					Enabled:            true,
+					DefaultPermissions: "read",
+					AllowedActions:     "some",
+				},
+			},
+			err: "actions: invalid allowed_actions: some",
+		},
+		{
+			desc: "ignores the actions settings unless enabled",
+			config: core.Config{
+				Actions: core.ActionsConfig{
+					DefaultPermissions: "admin",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			app := core.NewTestApp()
+			app.Config = &tt.config
+			action := NewRootAction(app)
+
+			err := action.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
+
+// newTestGitClient returns a client for the working dir that commits as a
+// test user (so that the tests don't depend on the host's git identity).
+// testGitIdentity is the commit identity of the git clients in tests.
+var testGitIdentity = git.WithEnv(
+	"GIT_AUTHOR_NAME=Test User",
+	"GIT_AUTHOR_EMAIL=test@example.com",
+	"GIT_COMMITTER_NAME=Test User",
+	"GIT_COMMITTER_EMAIL=test@example.com",
+)
+
+// stubConfirm answers the confirm prompts of app with answer.
+func stubConfirm(app *core.App, answer bool) *uimock.PrompterMock {
+	p := app.Prompter.(*uimock.PrompterMock)
+	p.ConfirmFunc = func(msg string, value bool, help string) (bool, error) {
+		return answer, nil
+	}
+	return p
+}
+
+// confirmed returns the messages of the confirm prompts shown (in order).
+func confirmed(p *uimock.PrompterMock) []string {
+	var msgs []string
+	for _, call := range p.ConfirmCalls() {
+		msgs = append(msgs, call.Msg)
+	}
+	return msgs
+}
+
+func newTestGitClient() git.Client {
+	return git.NewClient("", testGitIdentity)
+}
+
 func NewClientMock() *gh.ClientMock {
 	return &gh.ClientMock{
+		AutomatedSecurityFixesEnabledFunc: func(name string) (bool, error) {
+			return true, nil
+		},
+		CodeScanningDefaultSetupEnabledFunc: func(name string) (bool, error) {
+			return true, nil
+		},
 		CurrentRemoteFunc: func() (*gh.Repository, error) {
 			return nil, nil
 		},
@@ -231,8 +920,35 @@ func NewClientMock() *gh.ClientMock {
 		GetAccountFunc: func(name string) (*gh.Account, error) {
 			return nil, nil
 		},
+		GetActionsPermissionsFunc: func(name string) (*gh.ActionsPermissions, error) {
+			return &gh.ActionsPermissions{Enabled: false}, nil
+		},
 		GetRepoFunc: func(name string) (*gh.Repository, error) {
 			return nil, nil
 		},
+		GetWorkflowPermissionsFunc: func(name string) (*gh.WorkflowPermissions, error) {
+			return &gh.WorkflowPermissions{DefaultWorkflowPermissions: gh.WorkflowPermissionsRead}, nil
+		},
+		ListEmailsFunc: func() ([]*gh.Email, error) {
+			return []*gh.Email{}, nil
+		},
+		ListGPGKeysFunc: func() ([]*gh.GPGKey, error) {
+			return []*gh.GPGKey{}, nil
+		},
+		ListSSHSigningKeysFunc: func() ([]*gh.SSHSigningKey, error) {
+			return []*gh.SSHSigningKey{}, nil
+		},
+		ListTemplatesFunc: func(owner string) ([]*gh.Repository, error) {
+			return []*gh.Repository{}, nil
+		},
+		PrivateVulnerabilityReportingEnabledFunc: func(name string) (bool, error) {
+			return true, nil
+		},
+		TokenScopesFunc: func() ([]string, error) {
+			return []string{"gist", "read:org", "repo", "user:email", "workflow"}, nil
+		},
+		VulnerabilityAlertsEnabledFunc: func(name string) (bool, error) {
+			return true, nil
+		},
 	}
 }
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"context"
+	"os/exec"
+	"runtime"
+)
+
+var (
+	// for stubbing
+	shellCommand = func(ctx context.Context, script string) *exec.Cmd {
+		if runtime.GOOS == "windows" {
+			return exec.CommandContext(ctx, "cmd", "/C", script)
+		}
+		return exec.CommandContext(ctx, "sh", "-c", script)
+	}
+)
+
+// runPostSetup runs the configured post-setup scripts in the repo dir,
+// stopping at the first one that fails.
+func (a *RootAction) runPostSetup(ctx context.Context) error {
+	if len(a.Config.PostSetup) == 0 {
+		return nil
+	}
+	dir, err := a.workingDir()
+	if err != nil {
+		return err
+	}
+	for _, script := range a.Config.PostSetup {
+		a.Messenger.Info("Running: %s\n", script)
+		cmd := shellCommand(ctx, script)
+		cmd.Dir = dir
+		cmd.Stdout = a.IO.Out
+		cmd.Stderr = a.IO.Err
+		if err := cmd.Run(); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			a.Messenger.Failure("The post-setup script failed (%s): %s\n", err, script)
+			return ErrAborted
+		}
+	}
+	return nil
+}
@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/twelvelabs/gh-setup/internal/gh"
+)
+
+// securityFeature is a repo security feature that can be enabled.
+type securityFeature struct {
+	Name    string
+	Enabled func(repo *gh.Repository) (bool, error)
+	Enable  func(repo *gh.Repository) error
+}
+
+// Label returns the name of the feature for use mid-sentence.
+func (f securityFeature) Label() string {
+	if strings.HasPrefix(f.Name, "Dependabot") {
+		return f.Name // a proper noun
+	}
+	return strings.ToLower(f.Name)
+}
+
+// securityFeatures returns the configured security features
+// (in the order they need to be enabled), if enabled.
+func (a *RootAction) securityFeatures() []securityFeature {
+	client := a.GhClient
+	config := a.Config.Security
+	features := []securityFeature{}
+	if !config.Enabled {
+		return features
+	}
+	if config.DependabotAlerts {
+		features = append(features, securityFeature{
+			Name: "Dependabot alerts",
+			Enabled: func(repo *gh.Repository) (bool, error) {
+				return client.VulnerabilityAlertsEnabled(repo.FullName)
+			},
+			Enable: func(repo *gh.Repository) error {
+				return client.EnableVulnerabilityAlerts(repo.FullName)
+			},
+		})
+	}
+	if config.DependabotSecurityUpdates {
+		// Needs Dependabot alerts.
+		features = append(features, securityFeature{
+			Name: "Dependabot security updates",
+			Enabled: func(repo *gh.Repository) (bool, error) {
+				return client.AutomatedSecurityFixesEnabled(repo.FullName)
+			},
+			Enable: func(repo *gh.Repository) error {
+				return client.EnableAutomatedSecurityFixes(repo.FullName)
+			},
+		})
+	}
+	if config.SecretScanning {
+		features = append(features, securityFeature{
+			Name: "Secret scanning",
+			Enabled: func(repo *gh.Repository) (bool, error) {
+				return securityAndAnalysisEnabled(repo, func(s *gh.SecurityAndAnalysis) *gh.SecurityFeature {
+					return s.SecretScanning
+				})
+			},
+			Enable: func(repo *gh.Repository) error {
+				return client.UpdateSecurityAndAnalysis(repo.FullName, &gh.SecurityAndAnalysis{
+					SecretScanning: &gh.SecurityFeature{Status: gh.StatusEnabled},
+				})
+			},
+		})
+	}
+	if config.PushProtection {
+		// Needs secret scanning.
+		features = append(features, securityFeature{
+			Name: "Push protection",
+			Enabled: func(repo *gh.Repository) (bool, error) {
+				return securityAndAnalysisEnabled(repo, func(s *gh.SecurityAndAnalysis) *gh.SecurityFeature {
+					return s.SecretScanningPushProtection
+				})
+			},
+			Enable: func(repo *gh.Repository) error {
+				return client.UpdateSecurityAndAnalysis(repo.FullName, &gh.SecurityAndAnalysis{
+					SecretScanningPushProtection: &gh.SecurityFeature{Status: gh.StatusEnabled},
+				})
+			},
+		})
+	}
+	if config.PrivateVulnerabilityReporting {
+		features = append(features, securityFeature{
+			Name: "Private vulnerability reporting",
+			Enabled: func(repo *gh.Repository) (bool, error) {
+				return client.PrivateVulnerabilityReportingEnabled(repo.FullName)
+			},
+			Enable: func(repo *gh.Repository) error {
+				return client.EnablePrivateVulnerabilityReporting(repo.FullName)
+			},
+		})
+	}
+	if config.CodeScanning {
+		features = append(features, securityFeature{
+			Name: "Code scanning",
+			Enabled: func(repo *gh.Repository) (bool, error) {
+				return client.CodeScanningDefaultSetupEnabled(repo.FullName)
+			},
+			Enable: func(repo *gh.Repository) error {
+				return client.EnableCodeScanningDefaultSetup(repo.FullName)
+			},
+		})
+	}
+	return features
+}
+
+// ensureSecurityFeatures enables the configured security features that are
+// off (once confirmed), reporting for each whether it was enabled,
+// was already on, or is unavailable to the repo.
+func (a *RootAction) ensureSecurityFeatures() error {
+	features := a.securityFeatures()
+	if len(features) == 0 {
+		return nil // nothing configured
+	}
+	repo, err := a.remoteRepo()
+	if err != nil || repo == nil {
+		return err
+	}
+
+	pending := []securityFeature{}
+	for _, feature := range features {
+		enabled, err := feature.Enabled(repo)
+		switch {
+		case a.isUnavailable(feature.Name, err):
+			continue
+		case err != nil:
+			return err
+		case enabled:
+			a.Messenger.Info("%s: already on\n", feature.Name)
+		default:
+			pending = append(pending, feature)
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	names := []string{}
+	for _, feature := range pending {
+		names = append(names, feature.Label())
+	}
+	ok, err := a.Prompter.Confirm(fmt.Sprintf("Enable %s?", strings.Join(names, ", ")), true, "")
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return nil
+	}
+	for _, feature := range pending {
+		a.IO.StartProgressIndicatorWithLabel("Enabling " + feature.Label())
+		err := feature.Enable(repo)
+		a.IO.StopProgressIndicator()
+		switch {
+		case a.isUnavailable(feature.Name, err):
+			continue
+		case err != nil:
+			return err
+		}
+		a.Messenger.Success("%s: enabled\n", feature.Name)
+	}
+	return nil
+}
+
+// isUnavailable reports the feature (or setting) name as unavailable
+// if err says it is.
+func (a *RootAction) isUnavailable(name string, err error) bool {
+	unavailable := &gh.UnavailableError{}
+	if !errors.As(err, &unavailable) {
+		return false
+	}
+	reason := strings.TrimSuffix(unavailable.Reason, ".")
+	if reason == "" {
+		reason = "not included in the plan"
+	}
+	a.Messenger.Warning("%s: unavailable (%s)\n", name, reason)
+	return true
+}
+
+// securityAndAnalysisEnabled returns whether the feature in the security and
+// analysis settings of repo is enabled. GitHub omits the settings for users
+// without admin access, and omits features the repo can't use.
+func securityAndAnalysisEnabled(
+	repo *gh.Repository, feature func(s *gh.SecurityAndAnalysis) *gh.SecurityFeature,
+) (bool, error) {
+	if repo.SecurityAndAnalysis == nil {
+		return false, &gh.UnavailableError{Reason: "requires admin access to " + repo.FullName}
+	}
+	setting := feature(repo.SecurityAndAnalysis)
+	if setting == nil {
+		return false, &gh.UnavailableError{}
+	}
+	return setting.Enabled(), nil
+}
@@ -0,0 +1,229 @@
+package cmd
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/twelvelabs/gh-setup/internal/core"
+	"github.com/twelvelabs/gh-setup/internal/gh"
+	"github.com/twelvelabs/gh-setup/internal/git"
+)
+
+func TestRootAction_EnsureSecurityFeatures(t *testing.T) {
+	allOn := core.SecurityConfig{
+		Enabled:                       true,
+		DependabotAlerts:              true,
+		DependabotSecurityUpdates:     true,
+		SecretScanning:                true,
+		PushProtection:                true,
+		PrivateVulnerabilityReporting: true,
+		CodeScanning:                  true,
+	}
+	unavailable := &gh.UnavailableError{Reason: "Advanced Security must be enabled for this repository."}
+
+	tests := []struct {
+		desc       string
+		config     core.SecurityConfig
+		enabled    bool
+		noAdmin    bool
+		getErr     error
+		enableErr  error
+		confirm    bool
+		prompted   []string
+		enabledAll []string
+		output     []string
+		err        string
+	}{
+		{
+			desc:   "does nothing unless enabled",
+			config: core.SecurityConfig{DependabotAlerts: true},
+		},
+		{
+			desc:   "does nothing when no features are configured",
+			config: core.SecurityConfig{Enabled: true},
+		},
+		{
+			desc:    "reports features that are already on",
+			config:  allOn,
+			enabled: true,
+			output: []string{
+				"Dependabot alerts: already on",
+				"Push protection: already on",
+				"Code scanning: already on",
+			},
+		},
+		{
+			desc:    "enables features that are off when confirmed",
+			config:  allOn,
+			confirm: true,
+			prompted: []string{
+				"Enable Dependabot alerts, Dependabot security updates, secret scanning, " +
+					"push protection, private vulnerability reporting, code scanning?",
+			},
+			enabledAll: []string{
+				"vulnerability-alerts",
+				"automated-security-fixes",
+				"secret_scanning",
+				"secret_scanning_push_protection",
+				"private-vulnerability-reporting",
+				"code-scanning",
+			},
+			output: []string{
+				"Dependabot alerts: enabled",
+				"Secret scanning: enabled",
+				"Code scanning: enabled",
+			},
+		},
+		{
+			desc: "leaves the repo alone when declined",
+			config: core.SecurityConfig{
+				Enabled:          true,
+				DependabotAlerts: true,
+			},
+			prompted: []string{"Enable Dependabot alerts?"},
+		},
+		{
+			desc: "reports features unavailable to the repo",
+			config: core.SecurityConfig{
+				Enabled:          true,
+				DependabotAlerts: true,
+				CodeScanning:     true,
+			},
+			getErr:  unavailable,
+			confirm: true,
+			prompted: []string{
+				"Enable Dependabot alerts?",
+			},
+			enabledAll: []string{"vulnerability-alerts"},
+			output: []string{
+				"Code scanning: unavailable (Advanced Security must be enabled for this repository)",
+				"Dependabot alerts: enabled",
+			},
+		},
+		{
+			desc: "reports features that can't be enabled",
+			config: core.SecurityConfig{
+				Enabled:        true,
+				SecretScanning: true,
+			},
+			enableErr:  unavailable,
+			confirm:    true,
+			prompted:   []string{"Enable secret scanning?"},
+			enabledAll: []string{"secret_scanning"},
+			output: []string{
+				"Secret scanning: unavailable (Advanced Security must be enabled for this repository)",
+			},
+		},
+		{
+			desc: "reports settings hidden from non-admins as unavailable",
+			config: core.SecurityConfig{
+				Enabled:        true,
+				SecretScanning: true,
+				PushProtection: true,
+			},
+			noAdmin: true,
+			output: []string{
+				"Secret scanning: unavailable (requires admin access to some-org/some-repo)",
+				"Push protection: unavailable (requires admin access to some-org/some-repo)",
+			},
+		},
+		{
+			desc: "returns other errors",
+			config: core.SecurityConfig{
+				Enabled:      true,
+				CodeScanning: true,
+			},
+			getErr: errors.New("boom"),
+			err:    "boom",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			status := gh.StatusDisabled
+			if tt.enabled {
+				status = gh.StatusEnabled
+			}
+			repo := &gh.Repository{
+				FullName: "some-org/some-repo",
+				SecurityAndAnalysis: &gh.SecurityAndAnalysis{
+					SecretScanning:               &gh.SecurityFeature{Status: status},
+					SecretScanningPushProtection: &gh.SecurityFeature{Status: status},
+				},
+			}
+			if tt.noAdmin {
+				repo.SecurityAndAnalysis = nil
+			}
+
+			app := core.NewTestApp()
+			app.Config.Security = tt.config
+			app.GitClient = &git.ClientMock{
+				HasRemoteFunc: func(name string) bool {
+					return true
+				},
+				RemoteURLFunc: func(name string) (string, error) {
+					return "https://github.com/some-org/some-repo.git", nil
+				},
+			}
+			enabled := []string{}
+			enable := func(feature string) error {
+				enabled = append(enabled, feature)
+				return tt.enableErr
+			}
+			app.GhClient = &gh.ClientMock{
+				GetRepoFunc: func(name string) (*gh.Repository, error) {
+					return repo, nil
+				},
+				VulnerabilityAlertsEnabledFunc: func(name string) (bool, error) {
+					return tt.enabled, nil
+				},
+				AutomatedSecurityFixesEnabledFunc: func(name string) (bool, error) {
+					return tt.enabled, nil
+				},
+				PrivateVulnerabilityReportingEnabledFunc: func(name string) (bool, error) {
+					return tt.enabled, nil
+				},
+				CodeScanningDefaultSetupEnabledFunc: func(name string) (bool, error) {
+					return tt.enabled, tt.getErr
+				},
+				EnableVulnerabilityAlertsFunc: func(name string) error {
+					return enable("vulnerability-alerts")
+				},
+				EnableAutomatedSecurityFixesFunc: func(name string) error {
+					return enable("automated-security-fixes")
+				},
+				EnablePrivateVulnerabilityReportingFunc: func(name string) error {
+					return enable("private-vulnerability-reporting")
+				},
+				EnableCodeScanningDefaultSetupFunc: func(name string) error {
+					return enable("code-scanning")
+				},
+				UpdateSecurityAndAnalysisFunc: func(name string, settings *gh.SecurityAndAnalysis) error {
+					assert.Equal(t, "some-org/some-repo", name)
+					if settings.SecretScanning.Enabled() {
+						return enable("secret_scanning")
+					}
+					return enable("secret_scanning_push_protection")
+				},
+			}
+			p := stubConfirm(app, tt.confirm)
+			action := NewRootAction(app)
+
+			err := action.ensureSecurityFeatures()
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+			assert.Equal(t, tt.prompted, confirmed(p))
+			if tt.enabledAll == nil {
+				tt.enabledAll = []string{}
+			}
+			assert.Equal(t, tt.enabledAll, enabled)
+			for _, s := range tt.output {
+				assert.Contains(t, app.IO.Out.String(), s)
+			}
+		})
+	}
+}
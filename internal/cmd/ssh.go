@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/twelvelabs/gh-setup/internal/gh"
+	"github.com/twelvelabs/gh-setup/internal/sshkey"
+)
+
+const (
+	sshUseHTTPS = "Use HTTPS for this remote"
+	sshUseSSH   = "Use SSH anyway"
+)
+
+var (
+	// for stubbing
+	localSSHKeys = sshkey.Local
+)
+
+// ensureSSHKey checks, before an SSH remote is added, that one of the local
+// SSH keys (in ~/.ssh or ssh-agent) is registered on GitHub, and offers to
+// upload one if not. Returns the protocol to use for the remote, which is
+// HTTPS if the user chooses to fall back to it.
+func (a *RootAction) ensureSSHKey(protocol gh.Protocol) (gh.Protocol, error) {
+	if protocol != gh.ProtocolSSH {
+		return protocol, nil // not using ssh
+	}
+	local, err := localSSHKeys()
+	if err != nil {
+		return protocol, err
+	}
+	registered, err := a.GhClient.ListSSHKeys()
+	if err != nil {
+		a.skipCheck("your SSH keys", err)
+		return protocol, nil
+	}
+	for _, key := range registered {
+		fingerprint, err := sshkey.Fingerprint(key.Key)
+		if err != nil {
+			continue
+		}
+		if sshkey.Find(local, fingerprint) != nil {
+			return protocol, nil // good to go
+		}
+	}
+
+	if len(local) == 0 {
+		a.Messenger.Warning("No SSH keys were found in ~/.ssh or ssh-agent.\n")
+	} else {
+		a.Messenger.Warning("None of your SSH keys are registered on GitHub.\n")
+	}
+	uploads := map[string]*sshkey.Key{}
+	options := []string{}
+	for _, key := range local {
+		option := "Upload " + displayKey(key)
+		uploads[option] = key
+		options = append(options, option)
+	}
+	options = append(options, sshUseHTTPS, sshUseSSH)
+	choice, err := a.Prompter.Select("How should the remote connect to GitHub?", options, options[0], "")
+	if err != nil {
+		return protocol, err
+	}
+	switch choice {
+	case sshUseHTTPS:
+		return gh.ProtocolHTTPS, nil
+	case sshUseSSH:
+		return protocol, nil
+	}
+
+	key := uploads[choice]
+	title := key.Comment
+	if title == "" {
+		title = "gh-setup"
+	}
+	a.IO.StartProgressIndicatorWithLabel("Uploading SSH key")
+	_, err = a.GhClient.AddSSHKey(title, key.PublicKey)
+	a.IO.StopProgressIndicator()
+	if err != nil {
+		return protocol, err
+	}
+	a.Messenger.Success("SSH key uploaded: %s\n", key.Fingerprint)
+	return protocol, nil
+}
+
+// displayKey returns a short description of key for prompts.
+func displayKey(key *sshkey.Key) string {
+	if key.Path == "" {
+		if key.Comment == "" {
+			return key.Fingerprint + " (from ssh-agent)"
+		}
+		return key.Comment + " (from ssh-agent)"
+	}
+	path := key.Path
+	if home, err := os.UserHomeDir(); err == nil && strings.HasPrefix(path, home+string(filepath.Separator)) {
+		path = "~" + strings.TrimPrefix(path, home)
+	}
+	return path
+}
@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	uimock "github.com/twelvelabs/termite/ui/mock"
+
+	"github.com/twelvelabs/gh-setup/internal/core"
+	"github.com/twelvelabs/gh-setup/internal/gh"
+	"github.com/twelvelabs/gh-setup/internal/sshkey"
+)
+
+func TestRootAction_EnsureSSHKey(t *testing.T) {
+	fixtures, err := sshkey.FromDir(filepath.Join("..", "sshkey", "testdata", "ssh"))
+	if err != nil || len(fixtures) != 2 {
+		t.Fatalf("unable to read fixture keys: %v", err)
+	}
+	ed25519Key, rsaKey := fixtures[0], fixtures[1]
+	agentKey := &sshkey.Key{
+		PublicKey:   rsaKey.PublicKey,
+		Comment:     "agent@example.com",
+		Fingerprint: rsaKey.Fingerprint,
+	}
+
+	tests := []struct {
+		desc       string
+		protocol   gh.Protocol
+		local      []*sshkey.Key
+		registered []*gh.SSHKey
+		choice     string
+		expected   gh.Protocol
+		uploaded   string
+		output     []string
+		err        string
+	}{
+		{
+			desc:     "does nothing for https",
+			protocol: gh.ProtocolHTTPS,
+			expected: gh.ProtocolHTTPS,
+		},
+		{
+			desc:     "passes when a local key is registered",
+			protocol: gh.ProtocolSSH,
+			local:    []*sshkey.Key{ed25519Key, rsaKey},
+			registered: []*gh.SSHKey{
+				{Key: "not a key"},
+				{Key: rsaKey.PublicKey + " some-other-comment"},
+			},
+			expected: gh.ProtocolSSH,
+		},
+		{
+			desc:     "passes when an ssh-agent key is registered",
+			protocol: gh.ProtocolSSH,
+			local:    []*sshkey.Key{agentKey},
+			registered: []*gh.SSHKey{
+				{Key: rsaKey.PublicKey},
+			},
+			expected: gh.ProtocolSSH,
+		},
+		{
+			desc:       "uploads the chosen key",
+			protocol:   gh.ProtocolSSH,
+			local:      []*sshkey.Key{ed25519Key, agentKey},
+			registered: []*gh.SSHKey{},
+			choice:     "Upload agent@example.com (from ssh-agent)",
+			expected:   gh.ProtocolSSH,
+			uploaded:   rsaKey.PublicKey,
+			output: []string{
+				"None of your SSH keys are registered on GitHub.",
+				"SSH key uploaded: " + rsaKey.Fingerprint,
+			},
+		},
+		{
+			desc:       "falls back to https",
+			protocol:   gh.ProtocolSSH,
+			local:      []*sshkey.Key{},
+			registered: []*gh.SSHKey{{Key: ed25519Key.PublicKey}},
+			choice:     sshUseHTTPS,
+			expected:   gh.ProtocolHTTPS,
+			output:     []string{"No SSH keys were found in ~/.ssh or ssh-agent."},
+		},
+		{
+			desc:       "uses ssh anyway",
+			protocol:   gh.ProtocolSSH,
+			local:      []*sshkey.Key{ed25519Key},
+			registered: []*gh.SSHKey{},
+			choice:     sshUseSSH,
+			expected:   gh.ProtocolSSH,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			localSSHKeys = func() ([]*sshkey.Key, error) {
+				return tt.local, nil
+			}
+			defer func() { localSSHKeys = sshkey.Local }()
+
+			app := core.NewTestApp()
+			uploaded := ""
+			app.GhClient = &gh.ClientMock{
+				ListSSHKeysFunc: func() ([]*gh.SSHKey, error) {
+					return tt.registered, nil
+				},
+				AddSSHKeyFunc: func(title string, key string) (*gh.SSHKey, error) {
+					uploaded = key
+					return &gh.SSHKey{Title: title, Key: key}, nil
+				},
+			}
+			p := app.Prompter.(*uimock.PrompterMock)
+			p.SelectFunc = func(msg string, options []string, value, help string) (string, error) {
+				if !contains(options, tt.choice) {
+					panic(fmt.Errorf("unexpected select call: %s %v", msg, options))
+				}
+				return tt.choice, nil
+			}
+			action := NewRootAction(app)
+
+			protocol, err := action.ensureSSHKey(tt.protocol)
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+			assert.Equal(t, tt.expected, protocol)
+			assert.Equal(t, tt.uploaded, uploaded)
+			for _, s := range tt.output {
+				assert.Contains(t, app.IO.Out.String(), s)
+			}
+		})
+	}
+}
+
+func TestRootAction_EnsureSSHKey_Errors(t *testing.T) {
+	defer func() { localSSHKeys = sshkey.Local }()
+
+	app := core.NewTestApp()
+	action := NewRootAction(app)
+
+	localSSHKeys = func() ([]*sshkey.Key, error) {
+		return nil, errors.New("boom")
+	}
+	_, err := action.ensureSSHKey(gh.ProtocolSSH)
+	assert.EqualError(t, err, "boom")
+
+	// registered keys are best effort
+	localSSHKeys = func() ([]*sshkey.Key, error) {
+		return []*sshkey.Key{}, nil
+	}
+	app.GhClient = &gh.ClientMock{
+		ListSSHKeysFunc: func() ([]*gh.SSHKey, error) {
+			return nil, errors.New("network down")
+		},
+	}
+	protocol, err := action.ensureSSHKey(gh.ProtocolSSH)
+	assert.NoError(t, err)
+	assert.Equal(t, gh.ProtocolSSH, protocol)
+	assert.Contains(t, app.IO.Out.String(), "Unable to check your SSH keys: network down")
+}
@@ -0,0 +1,237 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/twelvelabs/gh-setup/internal/gh"
+	"github.com/twelvelabs/gh-setup/internal/scaffold"
+)
+
+// ensureTemplate renders the configured template into the working dir
+// of repos that have no commits yet. Files that already exist are only
+// overwritten when confirmed.
+func (a *RootAction) ensureTemplate(ctx context.Context) error {
+	if a.Config.Template == "" || a.GitClient.HasCommits() {
+		return nil // nothing to render, or not a new repo
+	}
+	source, err := a.templateSource()
+	if err != nil {
+		return err
+	}
+	dir, cleanup, err := a.sourceDir(ctx, source, "template")
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	tmpl, err := scaffold.Load(dir)
+	if err != nil {
+		return err
+	}
+	data, err := a.templateData(tmpl.Manifest)
+	if err != nil {
+		return err
+	}
+	files, err := tmpl.Render(data)
+	if err != nil {
+		return err
+	}
+
+	root, err := a.workingDir()
+	if err != nil {
+		return err
+	}
+	written := 0
+	workflows := false
+	for _, file := range files {
+		path := filepath.Join(root, filepath.FromSlash(file.Path))
+		current, err := os.ReadFile(path)
+		switch {
+		case err == nil:
+			if bytes.Equal(current, file.Content) {
+				continue // already rendered
+			}
+			ok, err := a.Prompter.Confirm(fmt.Sprintf("Overwrite %s with the template version?", file.Path), false, "")
+			if err != nil {
+				return err
+			}
+			if !ok {
+				continue // keep the existing file
+			}
+		case !os.IsNotExist(err):
+			return err
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, file.Content, file.Mode); err != nil {
+			return err
+		}
+		written++
+		workflows = workflows || strings.HasPrefix(file.Path, ".github/workflows/")
+	}
+	if written > 0 {
+		a.Messenger.Success("Template rendered: %d files written.\n", written)
+	}
+	if workflows {
+		return a.checkScopes([]string{"workflow"})
+	}
+	return nil
+}
+
+// templateSource returns the template to render. When the configured template
+// is an owner (rather than a dir or a repo), one of their template repos is picked.
+func (a *RootAction) templateSource() (string, error) {
+	source := a.Config.Template
+	if strings.Contains(source, "/") {
+		return source, nil // a path or an "owner/name" repo
+	}
+	path, err := expandHome(source)
+	if err != nil {
+		return "", err
+	}
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		return source, nil
+	}
+
+	templates, err := a.GhClient.ListTemplates(source)
+	if err != nil {
+		return "", err
+	}
+	if len(templates) == 0 {
+		a.Messenger.Failure("Unable to find the template: '%s' has no template repos.\n", source)
+		return "", ErrAborted
+	}
+	names := []string{}
+	for _, repo := range templates {
+		names = append(names, repo.FullName)
+	}
+	return a.Prompter.Select("Template", names, names[0], "")
+}
+
+// sourceDir returns the dir of source (either a local dir, or a GitHub repo
+// cloned over HTTPS into a temp dir that the returned func removes).
+// The name of what's being fetched (e.g. "template") is used in messages.
+func (a *RootAction) sourceDir(ctx context.Context, source string, name string) (string, func(), error) {
+	noop := func() {}
+	path, err := expandHome(source)
+	if err != nil {
+		return "", noop, err
+	}
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		return path, noop, nil
+	}
+
+	parts := strings.Split(source, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		a.Messenger.Failure("Unable to find the %s: %s is not a directory or a GitHub repo.\n", name, source)
+		return "", noop, ErrAborted
+	}
+	repo, err := a.GhClient.GetRepo(source)
+	if err != nil {
+		return "", noop, err
+	}
+	if repo == nil {
+		a.Messenger.Failure("Unable to find the %s: the '%s' repo does not exist.\n", name, source)
+		return "", noop, ErrAborted
+	}
+
+	dir, err := os.MkdirTemp("", "gh-setup-")
+	if err != nil {
+		return "", noop, err
+	}
+	cleanup := func() {
+		_ = os.RemoveAll(dir)
+	}
+	label := "Fetching the " + name
+	a.IO.StartProgressIndicatorWithLabel(label)
+	err = a.NewTokenGitClient(dir).Clone(ctx, repo.RemoteURL(gh.ProtocolHTTPS), a.gitProgress(label))
+	a.IO.StopProgressIndicator()
+	if err != nil {
+		cleanup()
+		return "", noop, err
+	}
+	return dir, cleanup, nil
+}
+
+// templateData returns the data the template is rendered with,
+// prompting for the description and any custom prompts in manifest.
+// The repo name and owner come from the remote when there is one,
+// otherwise they are the defaults offered when creating the repo.
+func (a *RootAction) templateData(manifest *scaffold.Manifest) (scaffold.Data, error) {
+	data := scaffold.Data{
+		Year:    time.Now().Year(),
+		Answers: map[string]string{},
+	}
+	user, err := a.currentUser()
+	if err != nil {
+		return data, err
+	}
+	data.User = user.Login
+
+	name, _, err := a.gitIdentity("AUTHOR")
+	if err != nil {
+		return data, err
+	}
+	data.UserName = name
+	if data.UserName == "" {
+		data.UserName = user.Login
+	}
+
+	if a.GitClient.HasRemote(a.Config.Remote) {
+		url, err := a.GitClient.RemoteURL(a.Config.Remote)
+		if err != nil {
+			return data, err
+		}
+		_, fullName, err := gh.ParseRemoteURL(url)
+		if err != nil {
+			return data, err
+		}
+		data.Owner, data.Name, _ = strings.Cut(fullName, "/")
+		repo, err := a.GhClient.GetRepo(fullName)
+		if err != nil {
+			return data, err
+		}
+		if repo != nil {
+			data.Description = repo.Description
+		}
+	} else {
+		dir, err := a.workingDir()
+		if err != nil {
+			return data, err
+		}
+		data.Name = filepath.Base(dir)
+		data.Owner = user.Login
+		if a.Config.Owner != "" {
+			data.Owner = a.Config.Owner
+		}
+	}
+
+	data.Description, err = a.Prompter.Input("Repo description", data.Description, "")
+	if err != nil {
+		return data, err
+	}
+	for _, prompt := range manifest.Prompts {
+		message := prompt.Message
+		if message == "" {
+			message = prompt.Name
+		}
+		var answer string
+		if len(prompt.Options) > 0 {
+			answer, err = a.Prompter.Select(message, prompt.Options, prompt.Default, "")
+		} else {
+			answer, err = a.Prompter.Input(message, prompt.Default, "")
+		}
+		if err != nil {
+			return data, err
+		}
+		data.Answers[prompt.Name] = answer
+	}
+	return data, nil
+}
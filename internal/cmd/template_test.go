@@ -0,0 +1,269 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	uimock "github.com/twelvelabs/termite/ui/mock"
+
+	"github.com/twelvelabs/gh-setup/internal/core"
+	"github.com/twelvelabs/gh-setup/internal/gh"
+	"github.com/twelvelabs/gh-setup/internal/git"
+)
+
+func TestRootAction_EnsureTemplate(t *testing.T) {
+	template := map[string]string{
+		"gh-setup.yaml": "prompts:\n" +
+			"  - name: license\n    options: [MIT, Apache-2.0]\n    default: MIT\n" +
+			"  - name: team\n    message: Team\n    default: maintainers\n",
+		"README.md.tmpl":           "# {{ .Repo }}\n\n{{ .Description }}\n",
+		"LICENSE.tmpl":             "{{ .Answers.license }} - {{ .UserName }}\n",
+		".github/CODEOWNERS.tmpl":  "* @{{ .Owner }}/{{ .Answers.team }} @{{ .User }}\n",
+		".github/workflows/ci.yml": "runs-on: ${{ matrix.os }}\n",
+		".github/dependabot.yml":   "version: 2\n",
+	}
+
+	tests := []struct {
+		desc       string
+		source     string
+		hasCommits bool
+		remoteURL  string
+		repo       *gh.Repository
+		existing   map[string]string
+		confirm    bool
+		prompted   []string
+		files      map[string]string
+		output     []string
+		err        string
+	}{
+		{
+			desc: "does nothing without a template",
+		},
+		{
+			desc:       "does nothing when the repo has commits",
+			source:     "template",
+			hasCommits: true,
+			files:      map[string]string{"README.md": ""},
+		},
+		{
+			desc:   "renders the template into new repos",
+			source: "template",
+			files: map[string]string{
+				"README.md":                "# some-user/work\n\nDoes things.\n",
+				"LICENSE":                  "MIT - Some User\n",
+				".github/CODEOWNERS":       "* @some-user/maintainers @some-user\n",
+				".github/workflows/ci.yml": "runs-on: ${{ matrix.os }}\n",
+				".github/dependabot.yml":   "version: 2\n",
+			},
+			output: []string{"Template rendered: 5 files written."},
+		},
+		{
+			desc:      "uses the repo the remote points to",
+			source:    "template",
+			remoteURL: "git@github.com:some-org/some-repo.git",
+			repo:      &gh.Repository{FullName: "some-org/some-repo", Description: "Does things."},
+			files: map[string]string{
+				"README.md":          "# some-org/some-repo\n\nDoes things.\n",
+				".github/CODEOWNERS": "* @some-org/maintainers @some-user\n",
+			},
+		},
+		{
+			desc:   "leaves existing files alone unless confirmed",
+			source: "template",
+			existing: map[string]string{
+				"README.md":              "# Mine\n",
+				".github/dependabot.yml": "version: 2\n",
+			},
+			prompted: []string{"Overwrite README.md with the template version?"},
+			files: map[string]string{
+				"README.md":              "# Mine\n",
+				".github/dependabot.yml": "version: 2\n",
+			},
+			output: []string{"Template rendered: 3 files written."},
+		},
+		{
+			desc:   "overwrites existing files when confirmed",
+			source: "template",
+			existing: map[string]string{
+				"README.md": "# Mine\n",
+			},
+			confirm:  true,
+			prompted: []string{"Overwrite README.md with the template version?"},
+			files: map[string]string{
+				"README.md": "# some-user/work\n\nDoes things.\n",
+			},
+		},
+		{
+			desc:   "aborts when the template repo does not exist",
+			source: "some-org/missing",
+			output: []string{"Unable to find the template: the 'some-org/missing' repo does not exist."},
+			err:    "aborted",
+		},
+		{
+			desc:   "aborts when the template can not be found",
+			source: "some/missing/dir",
+			output: []string{"Unable to find the template: some/missing/dir is not a directory or a GitHub repo."},
+			err:    "aborted",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			t.Setenv("GIT_AUTHOR_NAME", "")
+			tmp := t.TempDir()
+			for name, content := range template {
+				path := filepath.Join(tmp, "template", name)
+				require.NoError(t, os.MkdirAll(filepath.Dir(path), 0750))
+				require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+			}
+			root := filepath.Join(tmp, "work")
+			for name, content := range tt.existing {
+				path := filepath.Join(root, name)
+				require.NoError(t, os.MkdirAll(filepath.Dir(path), 0750))
+				require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+			}
+			require.NoError(t, os.MkdirAll(root, 0750))
+
+			app := core.NewTestApp()
+			app.Dir = root
+			if tt.source == "template" {
+				app.Config.Template = filepath.Join(tmp, "template")
+			} else {
+				app.Config.Template = tt.source
+			}
+			app.GitClient = &git.ClientMock{
+				HasCommitsFunc: func() bool {
+					return tt.hasCommits
+				},
+				HasRemoteFunc: func(name string) bool {
+					return tt.remoteURL != ""
+				},
+				RemoteURLFunc: func(name string) (string, error) {
+					return tt.remoteURL, nil
+				},
+				GetenvFunc: func(key string) string {
+					return ""
+				},
+				ConfigFunc: func(key string) (string, error) {
+					if key == "user.name" {
+						return "Some User", nil
+					}
+					return "", nil
+				},
+			}
+			app.GhClient = &gh.ClientMock{
+				CurrentUserFunc: func() (*gh.User, error) {
+					return &gh.User{Login: "some-user"}, nil
+				},
+				GetRepoFunc: func(name string) (*gh.Repository, error) {
+					return tt.repo, nil
+				},
+				TokenScopesFunc: func() ([]string, error) {
+					return []string{"repo", "read:org", "workflow"}, nil
+				},
+			}
+			p := stubConfirm(app, tt.confirm)
+			p.InputFunc = func(msg string, value string, help string) (string, error) {
+				if msg == "Repo description" && value == "" {
+					return "Does things.", nil
+				}
+				return value, nil
+			}
+			p.SelectFunc = func(msg string, options []string, value string, help string) (string, error) {
+				assert.Equal(t, "license", msg)
+				return value, nil
+			}
+			action := NewRootAction(app)
+
+			err := action.ensureTemplate(context.Background())
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+			assert.Equal(t, tt.prompted, confirmed(p))
+			for name, content := range tt.files {
+				actual, err := os.ReadFile(filepath.Join(root, name))
+				if content == "" {
+					assert.True(t, os.IsNotExist(err), name)
+					continue
+				}
+				assert.NoError(t, err)
+				assert.Equal(t, content, string(actual), name)
+			}
+			for _, s := range tt.output {
+				assert.Contains(t, app.IO.Out.String(), s)
+			}
+		})
+	}
+}
+
+func TestRootAction_TemplateSource(t *testing.T) {
+	tests := []struct {
+		desc      string
+		source    string
+		templates []*gh.Repository
+		selected  string
+		expected  string
+		output    string
+		err       string
+	}{
+		{
+			desc:     "returns repos as is",
+			source:   "some-org/project-template",
+			expected: "some-org/project-template",
+		},
+		{
+			desc:     "returns dirs as is",
+			source:   ".",
+			expected: ".",
+		},
+		{
+			desc:   "picks one of the template repos of an owner",
+			source: "some-org",
+			templates: []*gh.Repository{
+				{FullName: "some-org/go-template"},
+				{FullName: "some-org/node-template"},
+			},
+			selected: "some-org/node-template",
+			expected: "some-org/node-template",
+		},
+		{
+			desc:   "aborts when the owner has no template repos",
+			source: "some-org",
+			output: "Unable to find the template: 'some-org' has no template repos.",
+			err:    "aborted",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			app := core.NewTestApp()
+			app.Config.Template = tt.source
+			app.GhClient = &gh.ClientMock{
+				ListTemplatesFunc: func(owner string) ([]*gh.Repository, error) {
+					assert.Equal(t, tt.source, owner)
+					return tt.templates, nil
+				},
+			}
+			p := app.Prompter.(*uimock.PrompterMock)
+			p.SelectFunc = func(msg string, options []string, value string, help string) (string, error) {
+				assert.Equal(t, "Template", msg)
+				assert.Equal(t, "some-org/go-template", value)
+				return tt.selected, nil
+			}
+			action := NewRootAction(app)
+
+			actual, err := action.templateSource()
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+			assert.Equal(t, tt.expected, actual)
+			assert.Contains(t, app.IO.Out.String(), tt.output)
+		})
+	}
+}
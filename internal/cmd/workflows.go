@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/twelvelabs/gh-setup/internal/workflow"
+)
+
+// ensureWorkflows generates a CI workflow for each project type detected
+// in new repos that have no workflows, previewing each before writing it.
+// Workflow templates in the configured dir (or repo) override the built-ins.
+func (a *RootAction) ensureWorkflows(ctx context.Context) error {
+	if !a.Config.CI.Enabled || a.GitClient.HasCommits() {
+		return nil // disabled, or not a new repo
+	}
+	root, err := a.workingDir()
+	if err != nil {
+		return err
+	}
+	entries, err := os.ReadDir(filepath.Join(root, ".github", "workflows"))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if len(entries) > 0 {
+		return nil // already has workflows
+	}
+	projects, err := workflow.Detect(root)
+	if err != nil {
+		return err
+	}
+	if len(projects) == 0 {
+		return nil // nothing to build
+	}
+
+	overrides := ""
+	if a.Config.CI.Templates != "" {
+		dir, cleanup, err := a.sourceDir(ctx, a.Config.CI.Templates, "workflow templates")
+		if err != nil {
+			return err
+		}
+		defer cleanup()
+		overrides = dir
+	}
+
+	written := false
+	for _, project := range projects {
+		content, err := workflow.Render(project, overrides)
+		if err != nil {
+			return err
+		}
+		a.Messenger.Info("Generated a CI workflow for the %s project (%s):\n", project.Type, project.Path())
+		fmt.Fprintf(a.IO.Err, "\n%s\n", content)
+		ok, err := a.Prompter.Confirm(fmt.Sprintf("Add the %s workflow?", project.Type), true, "")
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		path := filepath.Join(root, filepath.FromSlash(project.Path()))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, content, 0o644); err != nil { //nolint: gosec // not a secret
+			return err
+		}
+		a.Messenger.Success("Workflow added: %s\n", project.Path())
+		written = true
+	}
+	if written {
+		return a.checkScopes([]string{"workflow"})
+	}
+	return nil
+}
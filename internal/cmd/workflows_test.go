@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/twelvelabs/gh-setup/internal/core"
+	"github.com/twelvelabs/gh-setup/internal/gh"
+	"github.com/twelvelabs/gh-setup/internal/git"
+)
+
+func TestRootAction_EnsureWorkflows(t *testing.T) {
+	tests := []struct {
+		desc       string
+		disabled   bool
+		hasCommits bool
+		files      map[string]string
+		overrides  map[string]string
+		confirm    bool
+		prompted   []string
+		written    map[string]string
+		output     []string
+	}{
+		{
+			desc:     "does nothing when disabled",
+			disabled: true,
+			files:    map[string]string{"go.mod": "go 1.20\n"},
+		},
+		{
+			desc:       "does nothing when the repo has commits",
+			hasCommits: true,
+			files:      map[string]string{"go.mod": "go 1.20\n"},
+			written:    map[string]string{".github/workflows/go.yml": ""},
+		},
+		{
+			desc:  "does nothing for unknown projects",
+			files: map[string]string{"README.md": ""},
+		},
+		{
+			desc: "does nothing when the repo has workflows",
+			files: map[string]string{
+				"go.mod":                      "go 1.20\n",
+				".github/workflows/build.yml": "name: build\n",
+			},
+		},
+		{
+			desc: "previews and writes the workflows when confirmed",
+			files: map[string]string{
+				"go.mod":     "go 1.20\n",
+				"Dockerfile": "FROM scratch\n",
+			},
+			confirm:  true,
+			prompted: []string{"Add the go workflow?", "Add the docker workflow?"},
+			written: map[string]string{
+				".github/workflows/go.yml":     `go-version: "1.20"`,
+				".github/workflows/docker.yml": "docker/build-push-action",
+			},
+			output: []string{
+				"Generated a CI workflow for the go project (.github/workflows/go.yml):",
+				`go-version: "1.20"`,
+			},
+		},
+		{
+			desc:     "leaves the repo alone when declined",
+			files:    map[string]string{"go.mod": "go 1.20\n"},
+			prompted: []string{"Add the go workflow?"},
+			written:  map[string]string{".github/workflows/go.yml": ""},
+		},
+		{
+			desc:      "uses the configured templates",
+			files:     map[string]string{"go.mod": "go 1.20\n"},
+			overrides: map[string]string{"go.yml": "org-go: [[ .Version ]]\n"},
+			confirm:   true,
+			prompted:  []string{"Add the go workflow?"},
+			written:   map[string]string{".github/workflows/go.yml": "org-go: 1.20\n"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			root := t.TempDir()
+			for name, content := range tt.files {
+				path := filepath.Join(root, name)
+				require.NoError(t, os.MkdirAll(filepath.Dir(path), 0750))
+				require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+			}
+
+			app := core.NewTestApp()
+			app.Dir = root
+			app.Config.CI.Enabled = !tt.disabled
+			if tt.overrides != nil {
+				overrides := t.TempDir()
+				for name, content := range tt.overrides {
+					require.NoError(t, os.WriteFile(filepath.Join(overrides, name), []byte(content), 0600))
+				}
+				app.Config.CI.Templates = overrides
+			}
+			app.GitClient = &git.ClientMock{
+				HasCommitsFunc: func() bool {
+					return tt.hasCommits
+				},
+			}
+			app.GhClient = &gh.ClientMock{
+				TokenScopesFunc: func() ([]string, error) {
+					return []string{"repo", "read:org", "workflow"}, nil
+				},
+			}
+			p := stubConfirm(app, tt.confirm)
+			action := NewRootAction(app)
+
+			err := action.ensureWorkflows(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, tt.prompted, confirmed(p))
+			for name, content := range tt.written {
+				actual, err := os.ReadFile(filepath.Join(root, name))
+				if content == "" {
+					assert.True(t, os.IsNotExist(err), name)
+					continue
+				}
+				assert.NoError(t, err)
+				assert.Contains(t, string(actual), content)
+			}
+			for _, s := range tt.output {
+				assert.Contains(t, app.IO.Out.String()+app.IO.Err.String(), s)
+			}
+		})
+	}
+}
@@ -1,8 +1,12 @@
 package core
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 
+	"github.com/cli/go-gh/pkg/auth"
 	"github.com/twelvelabs/termite/ioutil"
 	"github.com/twelvelabs/termite/ui"
 	uimock "github.com/twelvelabs/termite/ui/mock"
@@ -12,51 +16,209 @@ import (
 )
 
 type App struct {
+	// The directory being setup (empty for the current working directory).
+	Dir          string
+	Config       *Config
 	IO           *ioutil.IOStreams
 	Messenger    *ui.Messenger
 	Prompter     ui.Prompter
 	GhClient     gh.Client
 	GhRestClient gh.RESTClient
+	GhGQLClient  gh.GraphQLClient
 	GitClient    git.Client
+
+	// The GitHub token (once InitGitHub has been called).
+	token string
 }
 
 func NewApp() (*App, error) {
+	config, err := NewConfig(ConfigPath())
+	if err != nil {
+		return nil, fmt.Errorf("config: %w", err)
+	}
+	if config.Host == "" {
+		config.Host, _ = auth.DefaultHost()
+	}
+
 	ios := ioutil.System()
 	messenger := ui.NewMessenger(ios)
 	prompter := ui.NewSurveyPrompter(ios.In, ios.Out, ios.Err, ios)
-	gitClient := git.DefaultClient
+	gitClient := git.NewClient("",
+		git.WithBackend(git.Backend(config.Git.Backend)),
+		git.WithTimeout(config.Git.Timeout),
+	)
+
+	app := &App{
+		Config:    config,
+		IO:        ios,
+		Messenger: messenger,
+		Prompter:  prompter,
+		GitClient: gitClient,
+	}
+	return app, nil
+}
+
+// InitGit creates the git client for dir.
+// If dir is empty, then the current working directory is used.
+// It is called once flags have been parsed so that the backend is known.
+func (a *App) InitGit(dir string) error {
+	if dir != "" {
+		abs, err := filepath.Abs(dir)
+		if err != nil {
+			return fmt.Errorf("git: %w", err)
+		}
+		info, err := os.Stat(abs)
+		if err != nil {
+			return fmt.Errorf("git: %w", err)
+		}
+		if !info.IsDir() {
+			return fmt.Errorf("git: %s is not a directory", dir)
+		}
+		dir = abs
+	}
+	return a.initGit(dir)
+}
 
-	ghRestClient, err := gh.NewRESTClient()
+// InitCloneGit creates the git client for dir, the dir to clone into.
+// Unlike InitGit, dir need not exist yet (the client creates it when cloning),
+// so that nothing is left behind if setup fails before then.
+func (a *App) InitCloneGit(dir string) error {
+	abs, err := filepath.Abs(dir)
 	if err != nil {
-		return nil, fmt.Errorf("gh: %w", err)
+		return fmt.Errorf("git: %w", err)
 	}
-	ghClient := gh.NewClient(ghRestClient, nil)
+	return a.initGit(abs)
+}
 
-	app := &App{
-		IO:           ios,
-		Messenger:    messenger,
-		Prompter:     prompter,
-		GhClient:     ghClient,
-		GhRestClient: ghRestClient,
-		GitClient:    gitClient,
+func (a *App) initGit(dir string) error {
+	backend := git.Backend(a.Config.Git.Backend)
+	if err := backend.Validate(); err != nil {
+		return err
 	}
-	return app, nil
+	a.Dir = dir
+	a.GitClient = a.NewGitClient(dir)
+	return nil
+}
+
+// NewGitClient returns a git client for dir (e.g. a temp dir to clone into),
+// using the configured backend and timeout.
+func (a *App) NewGitClient(dir string) git.Client {
+	opts := a.gitOptions()
+	if a.Config.App.ID != "" {
+		// Otherwise, git uses the credential helpers of the user.
+		opts = append(opts, git.WithCredentials("https://"+a.Host(), a.gitCredentials))
+	}
+	return git.NewClient(dir, opts...)
+}
+
+// NewTokenGitClient returns a git client for dir that always authenticates
+// HTTPS remotes on the GitHub host with the GitHub token, so that sources
+// (e.g. templates) can be cloned without the SSH key or credential helpers of the user.
+func (a *App) NewTokenGitClient(dir string) git.Client {
+	opts := append(a.gitOptions(), git.WithCredentials("https://"+a.Host(), a.gitCredentials))
+	return git.NewClient(dir, opts...)
+}
+
+// gitOptions returns the options for the configured backend and timeout.
+func (a *App) gitOptions() []git.Option {
+	return []git.Option{
+		git.WithBackend(git.Backend(a.Config.Git.Backend)),
+		git.WithTimeout(a.Config.Git.Timeout),
+	}
+}
+
+// gitCredentials returns the credentials git uses for HTTPS remotes
+// on the GitHub host (the GitHub token).
+func (a *App) gitCredentials() (string, string) {
+	return "x-access-token", a.token
+}
+
+// Host returns the GitHub host (github.com unless configured).
+func (a *App) Host() string {
+	if a.Config.Host != "" {
+		return a.Config.Host
+	}
+	return "github.com"
+}
+
+// InitGitHub creates the GitHub clients for the configured host and account.
+// It is called once flags have been parsed so that both are known.
+// API requests are abandoned once ctx is done.
+func (a *App) InitGitHub(ctx context.Context) error {
+	host := a.Config.Host
+
+	token := ""
+	switch {
+	case a.Config.App.ID != "":
+		var err error
+		token, err = a.appInstallationToken()
+		if err != nil {
+			return fmt.Errorf("gh: app %s: %w", a.Config.App.ID, err)
+		}
+	case a.Config.Account != "":
+		var err error
+		token, err = gh.TokenForLogin(nil, host, a.Config.Account)
+		if err != nil {
+			return fmt.Errorf("gh: account %s: %w", a.Config.Account, err)
+		}
+	}
+
+	if token == "" {
+		token, _ = auth.TokenForHost(host)
+	}
+	a.token = token
+
+	restClient, err := gh.NewRESTClient(host, token)
+	if err != nil {
+		return fmt.Errorf("gh: %w", err)
+	}
+	ghRestClient := gh.NewRetryClient(ctx, restClient, gh.RetryOptions{
+		MaxRetries: a.Config.Retry.MaxRetries,
+		BaseDelay:  a.Config.Retry.BaseDelay,
+		MaxWait:    a.Config.Retry.MaxWait,
+	})
+	ghGQLClient, err := gh.NewGraphQLClient(host, token)
+	if err != nil {
+		return fmt.Errorf("gh: %w", err)
+	}
+	a.GhRestClient = ghRestClient
+	a.GhGQLClient = ghGQLClient
+	a.GhClient = gh.NewClient(host, ghRestClient, ghGQLClient, nil)
+	return nil
+}
+
+// appInstallationToken mints an installation token for the configured app.
+func (a *App) appInstallationToken() (string, error) {
+	key, err := gh.ReadPrivateKey(a.Config.App.PrivateKeyPath)
+	if err != nil {
+		return "", err
+	}
+	return gh.NewInstallationToken(a.Config.Host, gh.AppCredentials{
+		AppID:          a.Config.App.ID,
+		PrivateKey:     key,
+		InstallationID: a.Config.App.InstallationID,
+		Owner:          a.Config.Owner,
+	})
 }
 
 func NewTestApp() *App {
+	config, _ := NewConfig("")
 	ios := ioutil.Test()
 	messenger := ui.NewMessenger(ios)
 	prompter := uimock.NewPrompterMock()
 	ghClient := &gh.ClientMock{}
 	ghRestClient := &gh.RESTClientMock{}
+	ghGQLClient := &gh.GraphQLClientMock{}
 	gitClient := &git.ClientMock{}
 
 	return &App{
+		Config:       config,
 		IO:           ios,
 		Messenger:    messenger,
 		Prompter:     prompter,
 		GhClient:     ghClient,
 		GhRestClient: ghRestClient,
+		GhGQLClient:  ghGQLClient,
 		GitClient:    gitClient,
 	}
 }
@@ -0,0 +1,178 @@
+package core
+
+import (
+	"time"
+
+	"github.com/twelvelabs/termite/conf"
+)
+
+// ConfigPath returns the default config file path.
+func ConfigPath() string {
+	return conf.XDGPath("gh-setup")
+}
+
+// NewConfig returns a new Config populated from the file at path.
+// Defaults are used for any values not present in the file
+// (or for all values if the file does not exist).
+func NewConfig(path string) (*Config, error) {
+	return conf.NewLoader(&Config{}, path).Load()
+}
+
+// Config contains the user configuration for gh-setup.
+type Config struct {
+	// The GitHub host to use.
+	// Defaults to $GH_HOST or the host gh is authenticated with.
+	Host string `yaml:"host"`
+	// The gh account to use when logged in to multiple accounts on Host.
+	// Defaults to prompting for one of the accounts.
+	Account string `yaml:"account"`
+	// The default owner for new repos.
+	// Defaults to the login of the current user.
+	Owner string `yaml:"owner"`
+	// GitHub App credentials.
+	// When set, gh-setup authenticates as an installation of the app
+	// instead of as a gh account (and Owner is required).
+	App AppConfig `yaml:"app"`
+	// Retry settings for rate limited (or failed) GitHub API requests.
+	Retry RetryConfig `yaml:"retry"`
+	// Settings for running git.
+	Git GitConfig `yaml:"git"`
+	// The name of the git remote for the GitHub repo.
+	Remote string `yaml:"remote" default:"origin"`
+	// Additional git remotes (e.g. the upstream of a fork, or a backup mirror).
+	Remotes []RemoteConfig `yaml:"remotes"`
+	// Settings for installing git hooks.
+	Hooks HooksConfig `yaml:"hooks"`
+	// A template of project files rendered into new repos (before the
+	// initial commit): either a local dir, a GitHub repo ("owner/name"),
+	// or an owner to pick one of their template repos from.
+	Template string `yaml:"template"`
+	// The rules of the .github/CODEOWNERS file generated for org repos
+	// (in order, as the last matching rule wins).
+	CodeOwners []CodeOwnersRule `yaml:"codeowners"`
+	// Settings for generating CI workflows.
+	CI CIConfig `yaml:"ci"`
+	// The security features enabled for the GitHub repo.
+	Security SecurityConfig `yaml:"security"`
+	// The GitHub Actions settings enforced on the GitHub repo.
+	Actions ActionsConfig `yaml:"actions"`
+	// Shell commands run in the repo dir once setup (or a clone) is complete.
+	// They are run every time, so should be safe to re-run.
+	PostSetup []string `yaml:"post_setup"`
+}
+
+// AppConfig contains the credentials for authenticating as a GitHub App.
+type AppConfig struct {
+	// The app ID.
+	ID string `yaml:"id"`
+	// Path to the PEM encoded app private key.
+	PrivateKeyPath string `yaml:"private_key_path"`
+	// The installation ID.
+	// Defaults to the installation for Owner.
+	InstallationID int64 `yaml:"installation_id"`
+}
+
+// RetryConfig contains the retry settings for GitHub API requests.
+type RetryConfig struct {
+	// The maximum number of times to retry a request.
+	MaxRetries int `yaml:"max_retries" default:"5"`
+	// The delay before the first backoff retry.
+	BaseDelay time.Duration `yaml:"base_delay" default:"1s"`
+	// The maximum total time to wait between retries of a request.
+	MaxWait time.Duration `yaml:"max_wait" default:"2m"`
+}
+
+// GitConfig contains the settings for running git.
+type GitConfig struct {
+	// The git implementation to use: "system" (the git binary),
+	// "go" (a pure Go implementation), or "auto" (system if installed).
+	Backend string `yaml:"backend" default:"auto"`
+	// The maximum time a single git command may run.
+	// Zero means commands may run indefinitely.
+	Timeout time.Duration `yaml:"timeout" default:"10m"`
+	// Repo-local git config (e.g. "pull.rebase": "true")
+	// applied to the repo during setup (and to cloned repos).
+	Config map[string]string `yaml:"config"`
+}
+
+// HooksConfig contains the settings for installing git hooks.
+type HooksConfig struct {
+	// A directory of hooks to install (absolute, or relative to the repo).
+	Dir string `yaml:"dir"`
+	// How the hooks in Dir are installed: "hooks-path" (set core.hooksPath
+	// to Dir), "copy" or "symlink" (into .git/hooks).
+	Mode string `yaml:"mode" default:"hooks-path"`
+	// Whether to run the installers of any hook frameworks used by the repo
+	// (pre-commit, lefthook or husky).
+	Frameworks bool `yaml:"frameworks" default:"true"`
+}
+
+// CodeOwnersRule is a CODEOWNERS rule.
+type CodeOwnersRule struct {
+	// The path pattern (e.g. "*" or "/docs/").
+	Path string `yaml:"path"`
+	// The owning teams ("@org/team") and users ("@login").
+	Owners []string `yaml:"owners"`
+}
+
+// CIConfig contains the settings for generating CI workflows.
+type CIConfig struct {
+	// Whether to generate CI workflows (for the detected project types)
+	// in new repos that have none.
+	Enabled bool `yaml:"enabled" default:"true"`
+	// Workflow templates ("go.yml", "node.yml", etc) that override the
+	// built-in ones: either a local dir or a GitHub repo ("owner/name").
+	Templates string `yaml:"templates"`
+}
+
+// SecurityConfig contains the security features enabled for the GitHub repo
+// (when the owner's plan allows).
+type SecurityConfig struct {
+	// Whether to enable the features.
+	// Off by default, as setup also runs against existing repos.
+	Enabled bool `yaml:"enabled"`
+	// Dependabot alerts for vulnerable dependencies.
+	DependabotAlerts bool `yaml:"dependabot_alerts" default:"true"`
+	// Dependabot pull requests that update vulnerable dependencies.
+	DependabotSecurityUpdates bool `yaml:"dependabot_security_updates" default:"true"`
+	// Secret scanning.
+	SecretScanning bool `yaml:"secret_scanning" default:"true"`
+	// Secret scanning push protection (blocking pushes that contain secrets).
+	PushProtection bool `yaml:"push_protection" default:"true"`
+	// Private vulnerability reporting.
+	PrivateVulnerabilityReporting bool `yaml:"private_vulnerability_reporting" default:"true"`
+	// The code scanning default setup (CodeQL).
+	CodeScanning bool `yaml:"code_scanning" default:"true"`
+}
+
+// ActionsConfig contains the GitHub Actions settings enforced on the GitHub repo.
+type ActionsConfig struct {
+	// Whether to enforce the settings.
+	// Off by default, as they replace those of existing repos.
+	Enabled bool `yaml:"enabled"`
+	// The default permissions of the GITHUB_TOKEN: "read" or "write".
+	DefaultPermissions string `yaml:"default_permissions" default:"read"`
+	// Whether workflows can approve pull requests.
+	CanApprovePullRequests bool `yaml:"can_approve_pull_requests"`
+	// The actions workflows may use: "all", "local_only" or "selected"
+	// (those allowed by the settings below).
+	AllowedActions string `yaml:"allowed_actions" default:"selected"`
+	// Whether actions created by GitHub are allowed.
+	GithubOwnedAllowed bool `yaml:"github_owned_allowed" default:"true"`
+	// Whether actions by Marketplace verified creators are allowed.
+	VerifiedAllowed bool `yaml:"verified_allowed"`
+	// The other allowed actions ("owner/name@ref", with * wildcards).
+	// Defaults to those used by the generated CI workflows.
+	PatternsAllowed []string `yaml:"patterns_allowed" default:"[\"docker/*\", \"dtolnay/rust-toolchain@*\", \"golangci/golangci-lint-action@*\", \"hadolint/hadolint-action@*\"]"` //nolint: lll
+}
+
+// RemoteConfig declares an additional git remote.
+type RemoteConfig struct {
+	// The remote name.
+	Name string `yaml:"name"`
+	// The remote URL.
+	URL string `yaml:"url"`
+	// A GitHub repo ("owner/name") to use instead of URL.
+	// The URL is for the protocol preferred by the current user.
+	Repo string `yaml:"repo"`
+}
@@ -0,0 +1,131 @@
+package core
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewConfig(t *testing.T) {
+	tests := []struct {
+		desc     string
+		path     string
+		expected *Config
+	}{
+		{
+			desc: "returns defaults when the file does not exist",
+			path: filepath.Join("testdata", "missing.yaml"),
+			expected: &Config{
+				Retry: RetryConfig{
+					MaxRetries: 5,
+					BaseDelay:  1 * time.Second,
+					MaxWait:    2 * time.Minute,
+				},
+				Git: GitConfig{
+					Backend: "auto",
+					Timeout: 10 * time.Minute,
+				},
+				Remote: "origin",
+				Hooks: HooksConfig{
+					Mode:       "hooks-path",
+					Frameworks: true,
+				},
+				CI: CIConfig{
+					Enabled: true,
+				},
+				Security: SecurityConfig{
+					DependabotAlerts:              true,
+					DependabotSecurityUpdates:     true,
+					SecretScanning:                true,
+					PushProtection:                true,
+					PrivateVulnerabilityReporting: true,
+					CodeScanning:                  true,
+				},
+				Actions: ActionsConfig{
+					DefaultPermissions: "read",
+					AllowedActions:     "selected",
+					GithubOwnedAllowed: true,
+					PatternsAllowed: []string{
+						"docker/*",
+						"dtolnay/rust-toolchain@*",
+						"golangci/golangci-lint-action@*",
+						"hadolint/hadolint-action@*",
+					},
+				},
+			},
+		},
+		{
+			desc: "merges values from the file with the defaults",
+			path: filepath.Join("testdata", "config.yaml"),
+			expected: &Config{
+				Host:    "ghe.example.com",
+				Account: "some-user",
+				Owner:   "some-org",
+				App: AppConfig{
+					ID:             "12345",
+					PrivateKeyPath: "/path/to/app.pem",
+					InstallationID: 67890,
+				},
+				Retry: RetryConfig{
+					MaxRetries: 3,
+					BaseDelay:  1 * time.Second,
+					MaxWait:    30 * time.Second,
+				},
+				Git: GitConfig{
+					Backend: "go",
+					Timeout: 1 * time.Minute,
+					Config: map[string]string{
+						"pull.rebase":    "true",
+						"core.hooksPath": ".githooks",
+					},
+				},
+				Remote: "github",
+				Remotes: []RemoteConfig{
+					{Name: "upstream", Repo: "other-org/some-repo"},
+					{Name: "backup", URL: "git@git.example.com:some-org/some-repo.git"},
+				},
+				Hooks: HooksConfig{
+					Dir:        "~/.config/gh-setup/hooks",
+					Mode:       "symlink",
+					Frameworks: false,
+				},
+				Template: "some-org/some-template",
+				CodeOwners: []CodeOwnersRule{
+					{Path: "*", Owners: []string{"@some-org/maintainers"}},
+					{Path: "/docs/", Owners: []string{"@some-org/docs", "@some-user"}},
+				},
+				CI: CIConfig{
+					Enabled:   false,
+					Templates: "some-org/workflow-templates",
+				},
+				Security: SecurityConfig{
+					Enabled:                       true,
+					DependabotAlerts:              true,
+					DependabotSecurityUpdates:     true,
+					SecretScanning:                false,
+					PushProtection:                true,
+					PrivateVulnerabilityReporting: true,
+					CodeScanning:                  false,
+				},
+				Actions: ActionsConfig{
+					Enabled:            true,
+					DefaultPermissions: "read",
+					AllowedActions:     "selected",
+					GithubOwnedAllowed: true,
+					VerifiedAllowed:    true,
+					PatternsAllowed:    []string{"some-org/*"},
+				},
+				PostSetup: []string{"make setup"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			actual, err := NewConfig(tt.path)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
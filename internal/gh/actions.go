@@ -0,0 +1,42 @@
+package gh
+
+// The actions a repo's workflows may use (in [ActionsPermissions]).
+const (
+	AllowedActionsAll       = "all"
+	AllowedActionsLocalOnly = "local_only"
+	AllowedActionsSelected  = "selected"
+)
+
+// The default permissions of the GITHUB_TOKEN (in [WorkflowPermissions]).
+const (
+	WorkflowPermissionsRead  = "read"
+	WorkflowPermissionsWrite = "write"
+)
+
+// ActionsPermissions is the GitHub Actions permissions of a repo.
+type ActionsPermissions struct {
+	// Whether Actions are enabled for the repo.
+	Enabled bool `json:"enabled"`
+	// AllowedActionsAll, AllowedActionsLocalOnly or AllowedActionsSelected.
+	AllowedActions string `json:"allowed_actions,omitempty"`
+}
+
+// SelectedActions is the actions allowed in a repo
+// when its allowed actions are AllowedActionsSelected.
+type SelectedActions struct {
+	// Whether actions created by GitHub are allowed.
+	GithubOwnedAllowed bool `json:"github_owned_allowed"`
+	// Whether actions by Marketplace verified creators are allowed.
+	VerifiedAllowed bool `json:"verified_allowed"`
+	// The other allowed actions ("owner/name@ref", with * wildcards).
+	PatternsAllowed []string `json:"patterns_allowed"`
+}
+
+// WorkflowPermissions is the permissions of the GITHUB_TOKEN
+// in a repo's workflows.
+type WorkflowPermissions struct {
+	// WorkflowPermissionsRead or WorkflowPermissionsWrite.
+	DefaultWorkflowPermissions string `json:"default_workflow_permissions"`
+	// Whether workflows can approve pull requests.
+	CanApprovePullRequestReviews bool `json:"can_approve_pull_request_reviews"`
+}
@@ -0,0 +1,151 @@
+package gh
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/cli/go-gh/pkg/api"
+)
+
+var (
+	ErrInvalidPrivateKey = errors.New("invalid private key")
+)
+
+// AppCredentials are used to authenticate as a GitHub App installation.
+type AppCredentials struct {
+	// The app ID (or client ID).
+	AppID string
+	// The app private key.
+	PrivateKey *rsa.PrivateKey
+	// The installation ID.
+	// If zero, then the installation for Owner is used.
+	InstallationID int64
+	// The account the app is installed on.
+	Owner string
+}
+
+// Installation is a GitHub App installation.
+type Installation struct {
+	ID int64 `json:"id"`
+}
+
+// ReadPrivateKey reads a PEM encoded RSA private key from path.
+func ReadPrivateKey(path string) (*rsa.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%w: %s: no PEM data found", ErrInvalidPrivateKey, path)
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %s", ErrInvalidPrivateKey, path, err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s: not an RSA key", ErrInvalidPrivateKey, path)
+	}
+	return key, nil
+}
+
+// NewAppJWT returns a JWT for appID signed with key.
+// The token is backdated a minute to allow for clock drift
+// and expires after 9 minutes (GitHub allows at most 10).
+func NewAppJWT(appID string, key *rsa.PrivateKey, now time.Time) (string, error) {
+	header := map[string]any{
+		"alg": "RS256",
+		"typ": "JWT",
+	}
+	claims := map[string]any{
+		"iat": now.Add(-1 * time.Minute).Unix(),
+		"exp": now.Add(9 * time.Minute).Unix(),
+		"iss": appID,
+	}
+	headerJSON, err := json.Marshal(header)
+	if err != nil {
+		return "", err
+	}
+	claimsJSON, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	enc := base64.RawURLEncoding
+	unsigned := enc.EncodeToString(headerJSON) + "." + enc.EncodeToString(claimsJSON)
+	digest := sha256.Sum256([]byte(unsigned))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", err
+	}
+	return unsigned + "." + enc.EncodeToString(sig), nil
+}
+
+// NewInstallationToken mints an installation access token for creds on host.
+func NewInstallationToken(host string, creds AppCredentials) (string, error) {
+	jwt, err := NewAppJWT(creds.AppID, creds.PrivateKey, time.Now())
+	if err != nil {
+		return "", err
+	}
+	restClient, err := NewAppRESTClient(host, jwt)
+	if err != nil {
+		return "", err
+	}
+	return InstallationToken(restClient, creds.InstallationID, creds.Owner)
+}
+
+// InstallationToken exchanges the app JWT used by restClient for an
+// installation access token. If installationID is zero, then the
+// installation for owner is looked up first.
+func InstallationToken(restClient RESTClient, installationID int64, owner string) (string, error) {
+	if installationID == 0 {
+		installation, err := GetInstallation(restClient, owner)
+		if err != nil {
+			return "", err
+		}
+		installationID = installation.ID
+	}
+
+	path := fmt.Sprintf("app/installations/%d/access_tokens", installationID)
+	resp := &struct {
+		Token string `json:"token"`
+	}{}
+	if err := restClient.Post(path, nil, resp); err != nil {
+		return "", err
+	}
+	return resp.Token, nil
+}
+
+// GetInstallation returns the app installation for the owner account.
+func GetInstallation(restClient RESTClient, owner string) (*Installation, error) {
+	installation := &Installation{}
+	err := restClient.Get(fmt.Sprintf("orgs/%s/installation", owner), installation)
+	if err == nil {
+		return installation, nil
+	}
+	httpErr := &api.HTTPError{}
+	if !errors.As(err, httpErr) || httpErr.StatusCode != http.StatusNotFound {
+		return nil, err
+	}
+	// Not an org (or not installed there) - try the user endpoint.
+	err = restClient.Get(fmt.Sprintf("users/%s/installation", owner), installation)
+	if err != nil {
+		return nil, err
+	}
+	return installation, nil
+}
@@ -0,0 +1,224 @@
+package gh
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadPrivateKey(t *testing.T) {
+	key := newTestKey(t)
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	tests := []struct {
+		desc string
+		data []byte
+		err  string
+	}{
+		{
+			desc: "reads PKCS1 keys",
+			data: pem.EncodeToMemory(&pem.Block{
+				Type:  "RSA PRIVATE KEY",
+				Bytes: x509.MarshalPKCS1PrivateKey(key),
+			}),
+		},
+		{
+			desc: "reads PKCS8 keys",
+			data: pem.EncodeToMemory(&pem.Block{
+				Type:  "PRIVATE KEY",
+				Bytes: pkcs8,
+			}),
+		},
+		{
+			desc: "returns an error for non-PEM data",
+			data: []byte("not a key"),
+			err:  "no PEM data found",
+		},
+		{
+			desc: "returns an error for invalid keys",
+			data: pem.EncodeToMemory(&pem.Block{
+				Type:  "PRIVATE KEY",
+				Bytes: []byte("not a key"),
+			}),
+			err: "invalid private key",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "app.pem")
+			require.NoError(t, os.WriteFile(path, tt.data, 0600))
+
+			actual, err := ReadPrivateKey(path)
+
+			if tt.err == "" {
+				assert.NoError(t, err)
+				assert.True(t, key.Equal(actual))
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+				assert.Nil(t, actual)
+			}
+		})
+	}
+}
+
+func TestNewAppJWT(t *testing.T) {
+	key := newTestKey(t)
+	now := time.Date(2023, 4, 1, 12, 0, 0, 0, time.UTC)
+
+	jwt, err := NewAppJWT("12345", key, now)
+	require.NoError(t, err)
+
+	header, claims := verifyTestJWT(t, jwt, &key.PublicKey)
+	assert.Equal(t, "RS256", header["alg"])
+	assert.Equal(t, "JWT", header["typ"])
+	assert.Equal(t, "12345", claims["iss"])
+	assert.Equal(t, float64(now.Add(-1*time.Minute).Unix()), claims["iat"])
+	assert.Equal(t, float64(now.Add(9*time.Minute).Unix()), claims["exp"])
+}
+
+func TestNewInstallationToken(t *testing.T) {
+	key := newTestKey(t)
+
+	tests := []struct {
+		desc  string
+		creds AppCredentials
+		paths []string
+		err   string
+	}{
+		{
+			desc: "exchanges the JWT for an installation token",
+			creds: AppCredentials{
+				AppID:          "12345",
+				PrivateKey:     key,
+				InstallationID: 42,
+			},
+			paths: []string{
+				"POST /api/v3/app/installations/42/access_tokens",
+			},
+		},
+		{
+			desc: "looks up org installations by owner",
+			creds: AppCredentials{
+				AppID:      "12345",
+				PrivateKey: key,
+				Owner:      "some-org",
+			},
+			paths: []string{
+				"GET /api/v3/orgs/some-org/installation",
+				"POST /api/v3/app/installations/42/access_tokens",
+			},
+		},
+		{
+			desc: "looks up user installations by owner",
+			creds: AppCredentials{
+				AppID:      "12345",
+				PrivateKey: key,
+				Owner:      "some-user",
+			},
+			paths: []string{
+				"GET /api/v3/orgs/some-user/installation",
+				"GET /api/v3/users/some-user/installation",
+				"POST /api/v3/app/installations/42/access_tokens",
+			},
+		},
+		{
+			desc: "returns an error when the app is not installed",
+			creds: AppCredentials{
+				AppID:      "12345",
+				PrivateKey: key,
+				Owner:      "unknown",
+			},
+			paths: []string{
+				"GET /api/v3/orgs/unknown/installation",
+				"GET /api/v3/users/unknown/installation",
+			},
+			err: "HTTP 404",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			mux := http.NewServeMux()
+			mux.HandleFunc("/api/v3/orgs/some-org/installation", func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, `{"id": 42}`)
+			})
+			mux.HandleFunc("/api/v3/users/some-user/installation", func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, `{"id": 42}`)
+			})
+			mux.HandleFunc("/api/v3/app/installations/42/access_tokens", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusCreated)
+				fmt.Fprint(w, `{"token": "ghs_abc123", "expires_at": "2023-04-01T13:00:00Z"}`)
+			})
+			requests := stubFakeHost(t, mux)
+
+			token, err := NewInstallationToken("ghe.example.com", tt.creds)
+
+			if tt.err == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, "ghs_abc123", token)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+				assert.Equal(t, "", token)
+			}
+
+			paths := []string{}
+			for _, req := range *requests {
+				paths = append(paths, req.Method+" "+req.URL.Path)
+
+				auth := req.Header.Get("Authorization")
+				require.True(t, strings.HasPrefix(auth, "Bearer "), "expected bearer auth: %s", auth)
+				_, claims := verifyTestJWT(t, strings.TrimPrefix(auth, "Bearer "), &key.PublicKey)
+				assert.Equal(t, "12345", claims["iss"])
+			}
+			assert.Equal(t, tt.paths, paths)
+		})
+	}
+}
+
+func newTestKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return key
+}
+
+// verifyTestJWT verifies the jwt signature and returns the decoded header and claims.
+func verifyTestJWT(t *testing.T, jwt string, key *rsa.PublicKey) (map[string]any, map[string]any) {
+	t.Helper()
+
+	parts := strings.Split(jwt, ".")
+	require.Equal(t, 3, len(parts))
+
+	enc := base64.RawURLEncoding
+	sig, err := enc.DecodeString(parts[2])
+	require.NoError(t, err)
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	require.NoError(t, rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], sig))
+
+	header := map[string]any{}
+	data, err := enc.DecodeString(parts[0])
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, &header))
+
+	claims := map[string]any{}
+	data, err = enc.DecodeString(parts[1])
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, &claims))
+
+	return header, claims
+}
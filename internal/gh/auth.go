@@ -0,0 +1,46 @@
+package gh
+
+import (
+	"strings"
+
+	"github.com/cli/go-gh"
+	"github.com/cli/go-gh/pkg/config"
+)
+
+// Logins returns the accounts gh is authenticated with for host.
+// The active account is listed first.
+func Logins(host string) []string {
+	cfg, err := config.Read()
+	if err != nil {
+		return []string{}
+	}
+	return logins(cfg, host)
+}
+
+func logins(cfg *config.Config, host string) []string {
+	results := []string{}
+	active, _ := cfg.Get([]string{"hosts", host, "user"})
+	if active != "" {
+		results = append(results, active)
+	}
+	users, _ := cfg.Keys([]string{"hosts", host, "users"})
+	for _, user := range users {
+		if user != active {
+			results = append(results, user)
+		}
+	}
+	return results
+}
+
+// TokenForLogin returns the auth token gh has stored for login on host.
+// If exec is nil, then gh.Exec is used.
+func TokenForLogin(exec ExecFunc, host string, login string) (string, error) {
+	if exec == nil {
+		exec = gh.Exec
+	}
+	stdout, _, err := exec("auth", "token", "--hostname", host, "--user", login)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
@@ -0,0 +1,113 @@
+package gh
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/cli/go-gh/pkg/config"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogins(t *testing.T) {
+	tests := []struct {
+		desc     string
+		config   string
+		host     string
+		expected []string
+	}{
+		{
+			desc: "returns the active account first",
+			config: `
+hosts:
+  github.com:
+    users:
+      alice:
+      bob:
+    user: bob
+`,
+			host:     "github.com",
+			expected: []string{"bob", "alice"},
+		},
+		{
+			desc: "only returns accounts for the given host",
+			config: `
+hosts:
+  github.com:
+    users:
+      alice:
+    user: alice
+  ghe.example.com:
+    users:
+      alice-work:
+    user: alice-work
+`,
+			host:     "ghe.example.com",
+			expected: []string{"alice-work"},
+		},
+		{
+			desc: "supports configs that predate multiple accounts",
+			config: `
+hosts:
+  github.com:
+    user: alice
+`,
+			host:     "github.com",
+			expected: []string{"alice"},
+		},
+		{
+			desc:     "returns an empty slice for unknown hosts",
+			config:   ``,
+			host:     "github.com",
+			expected: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			cfg := config.ReadFromString(tt.config)
+			assert.Equal(t, tt.expected, logins(cfg, tt.host))
+		})
+	}
+}
+
+func TestTokenForLogin(t *testing.T) {
+	tests := []struct {
+		desc     string
+		execFunc ExecFunc
+		expected string
+		err      string
+	}{
+		{
+			desc: "returns the token for the login",
+			execFunc: func(args ...string) (bytes.Buffer, bytes.Buffer, error) {
+				expected := []string{"auth", "token", "--hostname", "github.com", "--user", "alice"}
+				if !cmp.Equal(args, expected) {
+					return bytes.Buffer{}, bytes.Buffer{}, errors.New("unexpected args")
+				}
+				return *bytes.NewBufferString("gho_abc123\n"), bytes.Buffer{}, nil
+			},
+			expected: "gho_abc123",
+		},
+		{
+			desc: "returns exec errors",
+			execFunc: func(args ...string) (bytes.Buffer, bytes.Buffer, error) {
+				return bytes.Buffer{}, bytes.Buffer{}, errors.New("no oauth token found")
+			},
+			err: "no oauth token found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			actual, err := TokenForLogin(tt.execFunc, "github.com", "alice")
+
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
 
 	"github.com/cli/go-gh"
@@ -15,42 +16,125 @@ import (
 //go:generate moq -rm -out client_mock.go . Client
 
 type Client interface {
+	AddSSHKey(title string, key string) (*SSHKey, error)
+	AutomatedSecurityFixesEnabled(name string) (bool, error)
+	CodeScanningDefaultSetupEnabled(name string) (bool, error)
 	CurrentUser() (*User, error)
 	CurrentRemote() (*Repository, error)
+	CreateFork(name string, org string, forkName string) (*Repository, error)
 	CreateRepo(owner string, name string, access Visibility) (*Repository, error)
+	EnableAutomatedSecurityFixes(name string) error
+	EnableCodeScanningDefaultSetup(name string) error
+	EnablePrivateVulnerabilityReporting(name string) error
+	EnableVulnerabilityAlerts(name string) error
 	GetAccount(name string) (*Account, error)
+	GetActionsPermissions(name string) (*ActionsPermissions, error)
 	GetRepo(name string) (*Repository, error)
+	GetSelectedActions(name string) (*SelectedActions, error)
+	GetTeam(org string, slug string) (*Team, error)
+	GetWorkflowPermissions(name string) (*WorkflowPermissions, error)
+	HasBranch(name string, branch string) (bool, error)
+	IsCollaborator(name string, login string) (bool, error)
+	ListEmails() ([]*Email, error)
+	ListGPGKeys() ([]*GPGKey, error)
+	ListRepoTeams(name string) ([]*Team, error)
+	ListSSHKeys() ([]*SSHKey, error)
+	ListSSHSigningKeys() ([]*SSHSigningKey, error)
+	ListTemplates(owner string) ([]*Repository, error)
+	PrivateVulnerabilityReportingEnabled(name string) (bool, error)
+	SetActionsPermissions(name string, permissions *ActionsPermissions) error
+	SetSelectedActions(name string, actions *SelectedActions) error
+	SetTeamPermission(org string, slug string, name string, permission string) error
+	SetWorkflowPermissions(name string, permissions *WorkflowPermissions) error
+	SyncFork(name string, branch string) error
+	TokenScopes() ([]string, error)
+	UnarchiveRepo(name string) (*Repository, error)
+	UpdateSecurityAndAnalysis(name string, settings *SecurityAndAnalysis) error
+	VulnerabilityAlertsEnabled(name string) (bool, error)
 }
 
-func NewClient(restClient RESTClient, exec ExecFunc) *SystemClient {
+// NewClient returns a new client for host.
+// If exec is nil, then gh.Exec is used.
+func NewClient(host string, restClient RESTClient, graphQLClient GraphQLClient, exec ExecFunc) *SystemClient {
 	if exec == nil {
 		exec = gh.Exec
 	}
 	return &SystemClient{
-		restClient: restClient,
-		exec:       exec,
+		host:          host,
+		restClient:    restClient,
+		graphQLClient: graphQLClient,
+		exec:          exec,
 	}
 }
 
 type ExecFunc func(args ...string) (bytes.Buffer, bytes.Buffer, error)
 
 type SystemClient struct {
-	exec       ExecFunc
-	restClient RESTClient
+	host          string
+	exec          ExecFunc
+	restClient    RESTClient
+	graphQLClient GraphQLClient
 }
 
 var _ Client = &SystemClient{}
 
+// AddSSHKey registers the public key (in authorized_keys format)
+// as an SSH authentication key for the current user.
+func (c *SystemClient) AddSSHKey(title string, key string) (*SSHKey, error) {
+	requestJSON, err := json.Marshal(&SSHKeyRequest{
+		Title: title,
+		Key:   key,
+	})
+	if err != nil {
+		return nil, err
+	}
+	sshKey := &SSHKey{}
+	err = c.restClient.Post("user/keys", bytes.NewReader(requestJSON), sshKey)
+	if err != nil {
+		return nil, TranslateError(err)
+	}
+	return sshKey, nil
+}
+
+// AutomatedSecurityFixesEnabled returns whether Dependabot security updates
+// are enabled for the repo name ("owner/name").
+func (c *SystemClient) AutomatedSecurityFixesEnabled(name string) (bool, error) {
+	status := struct {
+		Enabled bool `json:"enabled"`
+	}{}
+	if err := c.restClient.Get(fmt.Sprintf("repos/%s/automated-security-fixes", name), &status); err != nil {
+		return false, translateSecurityError(err)
+	}
+	return status.Enabled, nil
+}
+
+// CodeScanningDefaultSetupEnabled returns whether the code scanning
+// default setup is configured for the repo name ("owner/name").
+func (c *SystemClient) CodeScanningDefaultSetupEnabled(name string) (bool, error) {
+	setup := struct {
+		State string `json:"state"`
+	}{}
+	if err := c.restClient.Get(fmt.Sprintf("repos/%s/code-scanning/default-setup", name), &setup); err != nil {
+		return false, translateSecurityError(err)
+	}
+	return setup.State == "configured", nil
+}
+
 func (c *SystemClient) CurrentUser() (*User, error) {
 	user := &User{}
 	if err := c.restClient.Get("user", user); err != nil {
-		return nil, err
+		return nil, TranslateError(err)
 	}
-	user.Orgs = []*Account{}
-	if err := c.restClient.Get("user/orgs", &user.Orgs); err != nil {
-		return nil, err
+	orgs, err := Paginate[*Account](c.restClient, "user/orgs?per_page=100")
+	if err != nil {
+		return nil, TranslateError(err)
 	}
-	stdout, _, err := c.exec("config", "get", "git_protocol")
+	user.Orgs = orgs
+	args := []string{"config", "get", "git_protocol"}
+	if c.host != "" {
+		args = append(args, "-h", c.host)
+	}
+	stdout, _, err := c.exec(args...)
 	if err != nil {
 		return nil, err
 	}
@@ -63,13 +147,25 @@ func (c *SystemClient) CurrentRemote() (*Repository, error) {
 	if err != nil {
 		return nil, err
 	}
-	repo := &Repository{
-		Name:     r.Name(),
-		FullName: fmt.Sprintf("%s/%s", r.Owner(), r.Name()),
-		Owner: &Account{
-			Login: r.Owner(),
-		},
-		CloneURL: fmt.Sprintf("%s/%s/%s.git", r.Host(), r.Owner(), r.Name()),
+	return newRepository(r.Host(), r.Owner(), r.Name()), nil
+}
+
+// CreateFork forks the repo name ("owner/name") into org
+// (or the current user when empty), optionally renaming it to forkName.
+// Forks are created asynchronously, so the git objects
+// may not be available as soon as this returns (see HasBranch).
+func (c *SystemClient) CreateFork(name string, org string, forkName string) (*Repository, error) {
+	requestJSON, err := json.Marshal(&ForkRequest{
+		Organization: org,
+		Name:         forkName,
+	})
+	if err != nil {
+		return nil, err
+	}
+	repo := &Repository{}
+	err = c.restClient.Post(fmt.Sprintf("repos/%s/forks", name), bytes.NewReader(requestJSON), repo)
+	if err != nil {
+		return nil, TranslateError(err)
 	}
 	return repo, nil
 }
@@ -106,11 +202,52 @@ func (c *SystemClient) CreateRepo(owner string, name string, vis Visibility) (*R
 	repo := &Repository{}
 	err = c.restClient.Post(path, body, repo)
 	if err != nil {
-		return nil, err
+		return nil, TranslateError(err)
 	}
 	return repo, nil
 }
 
+// EnableAutomatedSecurityFixes enables Dependabot security updates
+// for the repo name ("owner/name").
+func (c *SystemClient) EnableAutomatedSecurityFixes(name string) error {
+	if err := c.restClient.Put(fmt.Sprintf("repos/%s/automated-security-fixes", name), nil, nil); err != nil {
+		return translateSecurityError(err)
+	}
+	return nil
+}
+
+// EnableCodeScanningDefaultSetup configures the code scanning default setup
+// for the repo name ("owner/name"). GitHub finishes configuring it
+// in the background.
+func (c *SystemClient) EnableCodeScanningDefaultSetup(name string) error {
+	requestJSON, err := json.Marshal(map[string]string{"state": "configured"})
+	if err != nil {
+		return err
+	}
+	path := fmt.Sprintf("repos/%s/code-scanning/default-setup", name)
+	if err := c.restClient.Patch(path, bytes.NewReader(requestJSON), nil); err != nil {
+		return translateSecurityError(err)
+	}
+	return nil
+}
+
+// EnablePrivateVulnerabilityReporting enables private vulnerability reporting
+// for the repo name ("owner/name").
+func (c *SystemClient) EnablePrivateVulnerabilityReporting(name string) error {
+	if err := c.restClient.Put(fmt.Sprintf("repos/%s/private-vulnerability-reporting", name), nil, nil); err != nil {
+		return translateSecurityError(err)
+	}
+	return nil
+}
+
+// EnableVulnerabilityAlerts enables Dependabot alerts for the repo name ("owner/name").
+func (c *SystemClient) EnableVulnerabilityAlerts(name string) error {
+	if err := c.restClient.Put(fmt.Sprintf("repos/%s/vulnerability-alerts", name), nil, nil); err != nil {
+		return translateSecurityError(err)
+	}
+	return nil
+}
+
 func (c *SystemClient) GetAccount(name string) (*Account, error) {
 	path := fmt.Sprintf("users/%s", name)
 	account := &Account{}
@@ -121,11 +258,21 @@ func (c *SystemClient) GetAccount(name string) (*Account, error) {
 				return nil, nil //nolint: nilnil
 			}
 		}
-		return nil, err
+		return nil, TranslateError(err)
 	}
 	return account, nil
 }
 
+// GetActionsPermissions returns the GitHub Actions permissions
+// of the repo name ("owner/name").
+func (c *SystemClient) GetActionsPermissions(name string) (*ActionsPermissions, error) {
+	permissions := &ActionsPermissions{}
+	if err := c.restClient.Get(fmt.Sprintf("repos/%s/actions/permissions", name), permissions); err != nil {
+		return nil, translateSecurityError(err)
+	}
+	return permissions, nil
+}
+
 func (c *SystemClient) GetRepo(name string) (*Repository, error) {
 	path := fmt.Sprintf("repos/%s", name)
 	repo := &Repository{}
@@ -136,7 +283,339 @@ func (c *SystemClient) GetRepo(name string) (*Repository, error) {
 				return nil, nil //nolint: nilnil
 			}
 		}
+		return nil, TranslateError(err)
+	}
+	return repo, nil
+}
+
+// GetSelectedActions returns the actions allowed in the repo name ("owner/name").
+// Only available when its allowed actions are AllowedActionsSelected.
+func (c *SystemClient) GetSelectedActions(name string) (*SelectedActions, error) {
+	actions := &SelectedActions{}
+	path := fmt.Sprintf("repos/%s/actions/permissions/selected-actions", name)
+	if err := c.restClient.Get(path, actions); err != nil {
+		return nil, translateSecurityError(err)
+	}
+	return actions, nil
+}
+
+// GetTeam returns the team slug in org, or nil if it doesn't exist
+// (or isn't visible to the current user).
+func (c *SystemClient) GetTeam(org string, slug string) (*Team, error) {
+	path := fmt.Sprintf("orgs/%s/teams/%s", org, url.PathEscape(slug))
+	team := &Team{}
+	if err := c.restClient.Get(path, team); err != nil {
+		httpErr := &api.HTTPError{}
+		if errors.As(err, httpErr) {
+			if httpErr.StatusCode == http.StatusNotFound {
+				return nil, nil //nolint: nilnil
+			}
+		}
+		return nil, TranslateError(err)
+	}
+	return team, nil
+}
+
+// GetWorkflowPermissions returns the permissions of the GITHUB_TOKEN
+// in the workflows of the repo name ("owner/name").
+func (c *SystemClient) GetWorkflowPermissions(name string) (*WorkflowPermissions, error) {
+	permissions := &WorkflowPermissions{}
+	if err := c.restClient.Get(fmt.Sprintf("repos/%s/actions/permissions/workflow", name), permissions); err != nil {
+		return nil, translateSecurityError(err)
+	}
+	return permissions, nil
+}
+
+// HasBranch returns whether the repo name ("owner/name") has the given branch.
+func (c *SystemClient) HasBranch(name string, branch string) (bool, error) {
+	path := fmt.Sprintf("repos/%s/branches/%s", name, url.PathEscape(branch))
+	if err := c.restClient.Get(path, &struct{}{}); err != nil {
+		httpErr := &api.HTTPError{}
+		if errors.As(err, httpErr) {
+			if httpErr.StatusCode == http.StatusNotFound {
+				return false, nil
+			}
+		}
+		return false, TranslateError(err)
+	}
+	return true, nil
+}
+
+// IsCollaborator returns whether login has access to the repo name ("owner/name"),
+// either directly or as an org member.
+func (c *SystemClient) IsCollaborator(name string, login string) (bool, error) {
+	path := fmt.Sprintf("repos/%s/collaborators/%s", name, url.PathEscape(login))
+	resp, err := c.restClient.Request(http.MethodGet, path, nil)
+	if err != nil {
+		httpErr := &api.HTTPError{}
+		if errors.As(err, httpErr) {
+			if httpErr.StatusCode == http.StatusNotFound {
+				return false, nil
+			}
+		}
+		return false, TranslateError(err)
+	}
+	resp.Body.Close()
+	return true, nil
+}
+
+// ListEmails returns the email addresses of the current user.
+func (c *SystemClient) ListEmails() ([]*Email, error) {
+	emails, err := Paginate[*Email](c.restClient, "user/emails?per_page=100")
+	if err != nil {
+		return nil, TranslateError(err)
+	}
+	return emails, nil
+}
+
+// ListGPGKeys returns the GPG keys registered by the current user.
+func (c *SystemClient) ListGPGKeys() ([]*GPGKey, error) {
+	keys, err := Paginate[*GPGKey](c.restClient, "user/gpg_keys?per_page=100")
+	if err != nil {
+		return nil, TranslateError(err)
+	}
+	return keys, nil
+}
+
+// ListRepoTeams returns the teams with access to the repo name ("owner/name"),
+// along with their permission on it.
+func (c *SystemClient) ListRepoTeams(name string) ([]*Team, error) {
+	teams, err := Paginate[*Team](c.restClient, fmt.Sprintf("repos/%s/teams?per_page=100", name))
+	if err != nil {
+		return nil, TranslateError(err)
+	}
+	return teams, nil
+}
+
+// ListSSHKeys returns the SSH authentication keys registered by the current user.
+func (c *SystemClient) ListSSHKeys() ([]*SSHKey, error) {
+	keys, err := Paginate[*SSHKey](c.restClient, "user/keys?per_page=100")
+	if err != nil {
+		return nil, TranslateError(err)
+	}
+	return keys, nil
+}
+
+// ListSSHSigningKeys returns the SSH signing keys registered by the current user.
+func (c *SystemClient) ListSSHSigningKeys() ([]*SSHSigningKey, error) {
+	keys, err := Paginate[*SSHSigningKey](c.restClient, "user/ssh_signing_keys?per_page=100")
+	if err != nil {
+		return nil, TranslateError(err)
+	}
+	return keys, nil
+}
+
+const listTemplatesQuery = `
+query ListTemplates($owner: String!, $cursor: String) {
+	repositoryOwner(login: $owner) {
+		repositories(first: 100, after: $cursor, orderBy: {field: NAME, direction: ASC}) {
+			nodes {
+				name
+				nameWithOwner
+				description
+				url
+				isTemplate
+				visibility
+			}
+			pageInfo {
+				hasNextPage
+				endCursor
+			}
+		}
+	}
+}`
+
+// ListTemplates returns the template repos owned by owner.
+func (c *SystemClient) ListTemplates(owner string) ([]*Repository, error) {
+	type response struct {
+		RepositoryOwner *struct {
+			Repositories struct {
+				Nodes []struct {
+					Name          string
+					NameWithOwner string
+					Description   string
+					URL           string
+					IsTemplate    bool
+					Visibility    Visibility
+				}
+				PageInfo struct {
+					HasNextPage bool
+					EndCursor   string
+				}
+			}
+		}
+	}
+
+	templates := []*Repository{}
+	variables := map[string]interface{}{
+		"owner":  owner,
+		"cursor": nil,
+	}
+	for {
+		resp := &response{}
+		if err := c.graphQLClient.Do(listTemplatesQuery, variables, resp); err != nil {
+			return nil, TranslateError(err)
+		}
+		if resp.RepositoryOwner == nil {
+			return templates, nil // unknown owner
+		}
+		repos := resp.RepositoryOwner.Repositories
+		for _, node := range repos.Nodes {
+			if !node.IsTemplate {
+				continue
+			}
+			templates = append(templates, &Repository{
+				Name:        node.Name,
+				FullName:    node.NameWithOwner,
+				Owner:       &Account{Login: owner},
+				Description: node.Description,
+				Visibility:  node.Visibility,
+				URL:         node.URL,
+				IsTemplate:  node.IsTemplate,
+			})
+		}
+		if !repos.PageInfo.HasNextPage {
+			return templates, nil
+		}
+		variables["cursor"] = repos.PageInfo.EndCursor
+	}
+}
+
+// PrivateVulnerabilityReportingEnabled returns whether private vulnerability
+// reporting is enabled for the repo name ("owner/name").
+func (c *SystemClient) PrivateVulnerabilityReportingEnabled(name string) (bool, error) {
+	status := struct {
+		Enabled bool `json:"enabled"`
+	}{}
+	if err := c.restClient.Get(fmt.Sprintf("repos/%s/private-vulnerability-reporting", name), &status); err != nil {
+		return false, translateSecurityError(err)
+	}
+	return status.Enabled, nil
+}
+
+// SetActionsPermissions sets the GitHub Actions permissions
+// of the repo name ("owner/name").
+func (c *SystemClient) SetActionsPermissions(name string, permissions *ActionsPermissions) error {
+	requestJSON, err := json.Marshal(permissions)
+	if err != nil {
+		return err
+	}
+	path := fmt.Sprintf("repos/%s/actions/permissions", name)
+	if err := c.restClient.Put(path, bytes.NewReader(requestJSON), nil); err != nil {
+		return translateSecurityError(err)
+	}
+	return nil
+}
+
+// SetSelectedActions sets the actions allowed in the repo name ("owner/name").
+// Its allowed actions must be AllowedActionsSelected.
+func (c *SystemClient) SetSelectedActions(name string, actions *SelectedActions) error {
+	requestJSON, err := json.Marshal(actions)
+	if err != nil {
+		return err
+	}
+	path := fmt.Sprintf("repos/%s/actions/permissions/selected-actions", name)
+	if err := c.restClient.Put(path, bytes.NewReader(requestJSON), nil); err != nil {
+		return translateSecurityError(err)
+	}
+	return nil
+}
+
+// SetTeamPermission grants the team slug in org permission
+// (pull, triage, push, maintain or admin) on the repo name ("owner/name").
+func (c *SystemClient) SetTeamPermission(org string, slug string, name string, permission string) error {
+	requestJSON, err := json.Marshal(map[string]string{"permission": permission})
+	if err != nil {
+		return err
+	}
+	path := fmt.Sprintf("orgs/%s/teams/%s/repos/%s", org, url.PathEscape(slug), name)
+	if err := c.restClient.Put(path, bytes.NewReader(requestJSON), nil); err != nil {
+		return TranslateError(err)
+	}
+	return nil
+}
+
+// SetWorkflowPermissions sets the permissions of the GITHUB_TOKEN
+// in the workflows of the repo name ("owner/name").
+func (c *SystemClient) SetWorkflowPermissions(name string, permissions *WorkflowPermissions) error {
+	requestJSON, err := json.Marshal(permissions)
+	if err != nil {
+		return err
+	}
+	path := fmt.Sprintf("repos/%s/actions/permissions/workflow", name)
+	if err := c.restClient.Put(path, bytes.NewReader(requestJSON), nil); err != nil {
+		return translateSecurityError(err)
+	}
+	return nil
+}
+
+// SyncFork merges any new commits on the upstream branch
+// into the same branch of the fork name ("owner/name").
+func (c *SystemClient) SyncFork(name string, branch string) error {
+	requestJSON, err := json.Marshal(map[string]string{"branch": branch})
+	if err != nil {
+		return err
+	}
+	err = c.restClient.Post(fmt.Sprintf("repos/%s/merge-upstream", name), bytes.NewReader(requestJSON), nil)
+	if err != nil {
+		return TranslateError(err)
+	}
+	return nil
+}
+
+// TokenScopes returns the OAuth scopes granted to the current token.
+// Returns nil if the token does not use OAuth scopes.
+func (c *SystemClient) TokenScopes() ([]string, error) {
+	resp, err := c.restClient.Request(http.MethodGet, "user", nil)
+	if err != nil {
+		return nil, TranslateError(err)
+	}
+	resp.Body.Close()
+	return ParseScopes(resp.Header), nil
+}
+
+// UnarchiveRepo makes the archived repo name ("owner/name") writable again.
+func (c *SystemClient) UnarchiveRepo(name string) (*Repository, error) {
+	requestJSON, err := json.Marshal(map[string]bool{"archived": false})
+	if err != nil {
 		return nil, err
 	}
+	repo := &Repository{}
+	err = c.restClient.Patch(fmt.Sprintf("repos/%s", name), bytes.NewReader(requestJSON), repo)
+	if err != nil {
+		return nil, TranslateError(err)
+	}
 	return repo, nil
 }
+
+// UpdateSecurityAndAnalysis updates the security and analysis settings
+// (secret scanning, push protection, etc) of the repo name ("owner/name").
+// Only the non-nil settings are changed.
+func (c *SystemClient) UpdateSecurityAndAnalysis(name string, settings *SecurityAndAnalysis) error {
+	requestJSON, err := json.Marshal(map[string]*SecurityAndAnalysis{"security_and_analysis": settings})
+	if err != nil {
+		return err
+	}
+	if err := c.restClient.Patch(fmt.Sprintf("repos/%s", name), bytes.NewReader(requestJSON), nil); err != nil {
+		return translateSecurityError(err)
+	}
+	return nil
+}
+
+// VulnerabilityAlertsEnabled returns whether Dependabot alerts are enabled
+// for the repo name ("owner/name").
+func (c *SystemClient) VulnerabilityAlertsEnabled(name string) (bool, error) {
+	path := fmt.Sprintf("repos/%s/vulnerability-alerts", name)
+	resp, err := c.restClient.Request(http.MethodGet, path, nil)
+	if err != nil {
+		httpErr := &api.HTTPError{}
+		if errors.As(err, httpErr) {
+			if httpErr.StatusCode == http.StatusNotFound {
+				return false, nil // GitHub's way of saying "disabled"
+			}
+		}
+		return false, translateSecurityError(err)
+	}
+	resp.Body.Close()
+	return true, nil
+}
@@ -17,6 +17,18 @@ var _ Client = &ClientMock{}
 //
 //		// make and configure a mocked Client
 //		mockedClient := &ClientMock{
+//			AddSSHKeyFunc: func(title string, key string) (*SSHKey, error) {
+//				panic("mock out the AddSSHKey method")
+//			},
+//			AutomatedSecurityFixesEnabledFunc: func(name string) (bool, error) {
+//				panic("mock out the AutomatedSecurityFixesEnabled method")
+//			},
+//			CodeScanningDefaultSetupEnabledFunc: func(name string) (bool, error) {
+//				panic("mock out the CodeScanningDefaultSetupEnabled method")
+//			},
+//			CreateForkFunc: func(name string, org string, forkName string) (*Repository, error) {
+//				panic("mock out the CreateFork method")
+//			},
 //			CreateRepoFunc: func(owner string, name string, access Visibility) (*Repository, error) {
 //				panic("mock out the CreateRepo method")
 //			},
@@ -26,12 +38,90 @@ var _ Client = &ClientMock{}
 //			CurrentUserFunc: func() (*User, error) {
 //				panic("mock out the CurrentUser method")
 //			},
+//			EnableAutomatedSecurityFixesFunc: func(name string) error {
+//				panic("mock out the EnableAutomatedSecurityFixes method")
+//			},
+//			EnableCodeScanningDefaultSetupFunc: func(name string) error {
+//				panic("mock out the EnableCodeScanningDefaultSetup method")
+//			},
+//			EnablePrivateVulnerabilityReportingFunc: func(name string) error {
+//				panic("mock out the EnablePrivateVulnerabilityReporting method")
+//			},
+//			EnableVulnerabilityAlertsFunc: func(name string) error {
+//				panic("mock out the EnableVulnerabilityAlerts method")
+//			},
 //			GetAccountFunc: func(name string) (*Account, error) {
 //				panic("mock out the GetAccount method")
 //			},
+//			GetActionsPermissionsFunc: func(name string) (*ActionsPermissions, error) {
+//				panic("mock out the GetActionsPermissions method")
+//			},
 //			GetRepoFunc: func(name string) (*Repository, error) {
 //				panic("mock out the GetRepo method")
 //			},
+//			GetSelectedActionsFunc: func(name string) (*SelectedActions, error) {
+//				panic("mock out the GetSelectedActions method")
+//			},
+//			GetTeamFunc: func(org string, slug string) (*Team, error) {
+//				panic("mock out the GetTeam method")
+//			},
+//			GetWorkflowPermissionsFunc: func(name string) (*WorkflowPermissions, error) {
+//				panic("mock out the GetWorkflowPermissions method")
+//			},
+//			HasBranchFunc: func(name string, branch string) (bool, error) {
+//				panic("mock out the HasBranch method")
+//			},
+//			IsCollaboratorFunc: func(name string, login string) (bool, error) {
+//				panic("mock out the IsCollaborator method")
+//			},
+//			ListEmailsFunc: func() ([]*Email, error) {
+//				panic("mock out the ListEmails method")
+//			},
+//			ListGPGKeysFunc: func() ([]*GPGKey, error) {
+//				panic("mock out the ListGPGKeys method")
+//			},
+//			ListRepoTeamsFunc: func(name string) ([]*Team, error) {
+//				panic("mock out the ListRepoTeams method")
+//			},
+//			ListSSHKeysFunc: func() ([]*SSHKey, error) {
+//				panic("mock out the ListSSHKeys method")
+//			},
+//			ListSSHSigningKeysFunc: func() ([]*SSHSigningKey, error) {
+//				panic("mock out the ListSSHSigningKeys method")
+//			},
+//			ListTemplatesFunc: func(owner string) ([]*Repository, error) {
+//				panic("mock out the ListTemplates method")
+//			},
+//			PrivateVulnerabilityReportingEnabledFunc: func(name string) (bool, error) {
+//				panic("mock out the PrivateVulnerabilityReportingEnabled method")
+//			},
+//			SetActionsPermissionsFunc: func(name string, permissions *ActionsPermissions) error {
+//				panic("mock out the SetActionsPermissions method")
+//			},
+//			SetSelectedActionsFunc: func(name string, actions *SelectedActions) error {
+//				panic("mock out the SetSelectedActions method")
+//			},
+//			SetTeamPermissionFunc: func(org string, slug string, name string, permission string) error {
+//				panic("mock out the SetTeamPermission method")
+//			},
+//			SetWorkflowPermissionsFunc: func(name string, permissions *WorkflowPermissions) error {
+//				panic("mock out the SetWorkflowPermissions method")
+//			},
+//			SyncForkFunc: func(name string, branch string) error {
+//				panic("mock out the SyncFork method")
+//			},
+//			TokenScopesFunc: func() ([]string, error) {
+//				panic("mock out the TokenScopes method")
+//			},
+//			UnarchiveRepoFunc: func(name string) (*Repository, error) {
+//				panic("mock out the UnarchiveRepo method")
+//			},
+//			UpdateSecurityAndAnalysisFunc: func(name string, settings *SecurityAndAnalysis) error {
+//				panic("mock out the UpdateSecurityAndAnalysis method")
+//			},
+//			VulnerabilityAlertsEnabledFunc: func(name string) (bool, error) {
+//				panic("mock out the VulnerabilityAlertsEnabled method")
+//			},
 //		}
 //
 //		// use mockedClient in code that requires Client
@@ -39,6 +129,18 @@ var _ Client = &ClientMock{}
 //
 //	}
 type ClientMock struct {
+	// AddSSHKeyFunc mocks the AddSSHKey method.
+	AddSSHKeyFunc func(title string, key string) (*SSHKey, error)
+
+	// AutomatedSecurityFixesEnabledFunc mocks the AutomatedSecurityFixesEnabled method.
+	AutomatedSecurityFixesEnabledFunc func(name string) (bool, error)
+
+	// CodeScanningDefaultSetupEnabledFunc mocks the CodeScanningDefaultSetupEnabled method.
+	CodeScanningDefaultSetupEnabledFunc func(name string) (bool, error)
+
+	// CreateForkFunc mocks the CreateFork method.
+	CreateForkFunc func(name string, org string, forkName string) (*Repository, error)
+
 	// CreateRepoFunc mocks the CreateRepo method.
 	CreateRepoFunc func(owner string, name string, access Visibility) (*Repository, error)
 
@@ -48,14 +150,118 @@ type ClientMock struct {
 	// CurrentUserFunc mocks the CurrentUser method.
 	CurrentUserFunc func() (*User, error)
 
+	// EnableAutomatedSecurityFixesFunc mocks the EnableAutomatedSecurityFixes method.
+	EnableAutomatedSecurityFixesFunc func(name string) error
+
+	// EnableCodeScanningDefaultSetupFunc mocks the EnableCodeScanningDefaultSetup method.
+	EnableCodeScanningDefaultSetupFunc func(name string) error
+
+	// EnablePrivateVulnerabilityReportingFunc mocks the EnablePrivateVulnerabilityReporting method.
+	EnablePrivateVulnerabilityReportingFunc func(name string) error
+
+	// EnableVulnerabilityAlertsFunc mocks the EnableVulnerabilityAlerts method.
+	EnableVulnerabilityAlertsFunc func(name string) error
+
 	// GetAccountFunc mocks the GetAccount method.
 	GetAccountFunc func(name string) (*Account, error)
 
+	// GetActionsPermissionsFunc mocks the GetActionsPermissions method.
+	GetActionsPermissionsFunc func(name string) (*ActionsPermissions, error)
+
 	// GetRepoFunc mocks the GetRepo method.
 	GetRepoFunc func(name string) (*Repository, error)
 
+	// GetSelectedActionsFunc mocks the GetSelectedActions method.
+	GetSelectedActionsFunc func(name string) (*SelectedActions, error)
+
+	// GetTeamFunc mocks the GetTeam method.
+	GetTeamFunc func(org string, slug string) (*Team, error)
+
+	// GetWorkflowPermissionsFunc mocks the GetWorkflowPermissions method.
+	GetWorkflowPermissionsFunc func(name string) (*WorkflowPermissions, error)
+
+	// HasBranchFunc mocks the HasBranch method.
+	HasBranchFunc func(name string, branch string) (bool, error)
+
+	// IsCollaboratorFunc mocks the IsCollaborator method.
+	IsCollaboratorFunc func(name string, login string) (bool, error)
+
+	// ListEmailsFunc mocks the ListEmails method.
+	ListEmailsFunc func() ([]*Email, error)
+
+	// ListGPGKeysFunc mocks the ListGPGKeys method.
+	ListGPGKeysFunc func() ([]*GPGKey, error)
+
+	// ListRepoTeamsFunc mocks the ListRepoTeams method.
+	ListRepoTeamsFunc func(name string) ([]*Team, error)
+
+	// ListSSHKeysFunc mocks the ListSSHKeys method.
+	ListSSHKeysFunc func() ([]*SSHKey, error)
+
+	// ListSSHSigningKeysFunc mocks the ListSSHSigningKeys method.
+	ListSSHSigningKeysFunc func() ([]*SSHSigningKey, error)
+
+	// ListTemplatesFunc mocks the ListTemplates method.
+	ListTemplatesFunc func(owner string) ([]*Repository, error)
+
+	// PrivateVulnerabilityReportingEnabledFunc mocks the PrivateVulnerabilityReportingEnabled method.
+	PrivateVulnerabilityReportingEnabledFunc func(name string) (bool, error)
+
+	// SetActionsPermissionsFunc mocks the SetActionsPermissions method.
+	SetActionsPermissionsFunc func(name string, permissions *ActionsPermissions) error
+
+	// SetSelectedActionsFunc mocks the SetSelectedActions method.
+	SetSelectedActionsFunc func(name string, actions *SelectedActions) error
+
+	// SetTeamPermissionFunc mocks the SetTeamPermission method.
+	SetTeamPermissionFunc func(org string, slug string, name string, permission string) error
+
+	// SetWorkflowPermissionsFunc mocks the SetWorkflowPermissions method.
+	SetWorkflowPermissionsFunc func(name string, permissions *WorkflowPermissions) error
+
+	// SyncForkFunc mocks the SyncFork method.
+	SyncForkFunc func(name string, branch string) error
+
+	// TokenScopesFunc mocks the TokenScopes method.
+	TokenScopesFunc func() ([]string, error)
+
+	// UnarchiveRepoFunc mocks the UnarchiveRepo method.
+	UnarchiveRepoFunc func(name string) (*Repository, error)
+
+	// UpdateSecurityAndAnalysisFunc mocks the UpdateSecurityAndAnalysis method.
+	UpdateSecurityAndAnalysisFunc func(name string, settings *SecurityAndAnalysis) error
+
+	// VulnerabilityAlertsEnabledFunc mocks the VulnerabilityAlertsEnabled method.
+	VulnerabilityAlertsEnabledFunc func(name string) (bool, error)
+
 	// calls tracks calls to the methods.
 	calls struct {
+		// AddSSHKey holds details about calls to the AddSSHKey method.
+		AddSSHKey []struct {
+			// Title is the title argument value.
+			Title string
+			// Key is the key argument value.
+			Key string
+		}
+		// AutomatedSecurityFixesEnabled holds details about calls to the AutomatedSecurityFixesEnabled method.
+		AutomatedSecurityFixesEnabled []struct {
+			// Name is the name argument value.
+			Name string
+		}
+		// CodeScanningDefaultSetupEnabled holds details about calls to the CodeScanningDefaultSetupEnabled method.
+		CodeScanningDefaultSetupEnabled []struct {
+			// Name is the name argument value.
+			Name string
+		}
+		// CreateFork holds details about calls to the CreateFork method.
+		CreateFork []struct {
+			// Name is the name argument value.
+			Name string
+			// Org is the org argument value.
+			Org string
+			// ForkName is the forkName argument value.
+			ForkName string
+		}
 		// CreateRepo holds details about calls to the CreateRepo method.
 		CreateRepo []struct {
 			// Owner is the owner argument value.
@@ -71,22 +277,334 @@ type ClientMock struct {
 		// CurrentUser holds details about calls to the CurrentUser method.
 		CurrentUser []struct {
 		}
+		// EnableAutomatedSecurityFixes holds details about calls to the EnableAutomatedSecurityFixes method.
+		EnableAutomatedSecurityFixes []struct {
+			// Name is the name argument value.
+			Name string
+		}
+		// EnableCodeScanningDefaultSetup holds details about calls to the EnableCodeScanningDefaultSetup method.
+		EnableCodeScanningDefaultSetup []struct {
+			// Name is the name argument value.
+			Name string
+		}
+		// EnablePrivateVulnerabilityReporting holds details about calls to the EnablePrivateVulnerabilityReporting method.
+		EnablePrivateVulnerabilityReporting []struct {
+			// Name is the name argument value.
+			Name string
+		}
+		// EnableVulnerabilityAlerts holds details about calls to the EnableVulnerabilityAlerts method.
+		EnableVulnerabilityAlerts []struct {
+			// Name is the name argument value.
+			Name string
+		}
 		// GetAccount holds details about calls to the GetAccount method.
 		GetAccount []struct {
 			// Name is the name argument value.
 			Name string
 		}
+		// GetActionsPermissions holds details about calls to the GetActionsPermissions method.
+		GetActionsPermissions []struct {
+			// Name is the name argument value.
+			Name string
+		}
 		// GetRepo holds details about calls to the GetRepo method.
 		GetRepo []struct {
 			// Name is the name argument value.
 			Name string
 		}
+		// GetSelectedActions holds details about calls to the GetSelectedActions method.
+		GetSelectedActions []struct {
+			// Name is the name argument value.
+			Name string
+		}
+		// GetTeam holds details about calls to the GetTeam method.
+		GetTeam []struct {
+			// Org is the org argument value.
+			Org string
+			// Slug is the slug argument value.
+			Slug string
+		}
+		// GetWorkflowPermissions holds details about calls to the GetWorkflowPermissions method.
+		GetWorkflowPermissions []struct {
+			// Name is the name argument value.
+			Name string
+		}
+		// HasBranch holds details about calls to the HasBranch method.
+		HasBranch []struct {
+			// Name is the name argument value.
+			Name string
+			// Branch is the branch argument value.
+			Branch string
+		}
+		// IsCollaborator holds details about calls to the IsCollaborator method.
+		IsCollaborator []struct {
+			// Name is the name argument value.
+			Name string
+			// Login is the login argument value.
+			Login string
+		}
+		// ListEmails holds details about calls to the ListEmails method.
+		ListEmails []struct {
+		}
+		// ListGPGKeys holds details about calls to the ListGPGKeys method.
+		ListGPGKeys []struct {
+		}
+		// ListRepoTeams holds details about calls to the ListRepoTeams method.
+		ListRepoTeams []struct {
+			// Name is the name argument value.
+			Name string
+		}
+		// ListSSHKeys holds details about calls to the ListSSHKeys method.
+		ListSSHKeys []struct {
+		}
+		// ListSSHSigningKeys holds details about calls to the ListSSHSigningKeys method.
+		ListSSHSigningKeys []struct {
+		}
+		// ListTemplates holds details about calls to the ListTemplates method.
+		ListTemplates []struct {
+			// Owner is the owner argument value.
+			Owner string
+		}
+		// PrivateVulnerabilityReportingEnabled holds details about calls to the PrivateVulnerabilityReportingEnabled method.
+		PrivateVulnerabilityReportingEnabled []struct {
+			// Name is the name argument value.
+			Name string
+		}
+		// SetActionsPermissions holds details about calls to the SetActionsPermissions method.
+		SetActionsPermissions []struct {
+			// Name is the name argument value.
+			Name string
+			// Permissions is the permissions argument value.
+			Permissions *ActionsPermissions
+		}
+		// SetSelectedActions holds details about calls to the SetSelectedActions method.
+		SetSelectedActions []struct {
+			// Name is the name argument value.
+			Name string
+			// Actions is the actions argument value.
+			Actions *SelectedActions
+		}
+		// SetTeamPermission holds details about calls to the SetTeamPermission method.
+		SetTeamPermission []struct {
+			// Org is the org argument value.
+			Org string
+			// Slug is the slug argument value.
+			Slug string
+			// Name is the name argument value.
+			Name string
+			// Permission is the permission argument value.
+			Permission string
+		}
+		// SetWorkflowPermissions holds details about calls to the SetWorkflowPermissions method.
+		SetWorkflowPermissions []struct {
+			// Name is the name argument value.
+			Name string
+			// Permissions is the permissions argument value.
+			Permissions *WorkflowPermissions
+		}
+		// SyncFork holds details about calls to the SyncFork method.
+		SyncFork []struct {
+			// Name is the name argument value.
+			Name string
+			// Branch is the branch argument value.
+			Branch string
+		}
+		// TokenScopes holds details about calls to the TokenScopes method.
+		TokenScopes []struct {
+		}
+		// UnarchiveRepo holds details about calls to the UnarchiveRepo method.
+		UnarchiveRepo []struct {
+			// Name is the name argument value.
+			Name string
+		}
+		// UpdateSecurityAndAnalysis holds details about calls to the UpdateSecurityAndAnalysis method.
+		UpdateSecurityAndAnalysis []struct {
+			// Name is the name argument value.
+			Name string
+			// Settings is the settings argument value.
+			Settings *SecurityAndAnalysis
+		}
+		// VulnerabilityAlertsEnabled holds details about calls to the VulnerabilityAlertsEnabled method.
+		VulnerabilityAlertsEnabled []struct {
+			// Name is the name argument value.
+			Name string
+		}
+	}
+	lockAddSSHKey                            sync.RWMutex
+	lockAutomatedSecurityFixesEnabled        sync.RWMutex
+	lockCodeScanningDefaultSetupEnabled      sync.RWMutex
+	lockCreateFork                           sync.RWMutex
+	lockCreateRepo                           sync.RWMutex
+	lockCurrentRemote                        sync.RWMutex
+	lockCurrentUser                          sync.RWMutex
+	lockEnableAutomatedSecurityFixes         sync.RWMutex
+	lockEnableCodeScanningDefaultSetup       sync.RWMutex
+	lockEnablePrivateVulnerabilityReporting  sync.RWMutex
+	lockEnableVulnerabilityAlerts            sync.RWMutex
+	lockGetAccount                           sync.RWMutex
+	lockGetActionsPermissions                sync.RWMutex
+	lockGetRepo                              sync.RWMutex
+	lockGetSelectedActions                   sync.RWMutex
+	lockGetTeam                              sync.RWMutex
+	lockGetWorkflowPermissions               sync.RWMutex
+	lockHasBranch                            sync.RWMutex
+	lockIsCollaborator                       sync.RWMutex
+	lockListEmails                           sync.RWMutex
+	lockListGPGKeys                          sync.RWMutex
+	lockListRepoTeams                        sync.RWMutex
+	lockListSSHKeys                          sync.RWMutex
+	lockListSSHSigningKeys                   sync.RWMutex
+	lockListTemplates                        sync.RWMutex
+	lockPrivateVulnerabilityReportingEnabled sync.RWMutex
+	lockSetActionsPermissions                sync.RWMutex
+	lockSetSelectedActions                   sync.RWMutex
+	lockSetTeamPermission                    sync.RWMutex
+	lockSetWorkflowPermissions               sync.RWMutex
+	lockSyncFork                             sync.RWMutex
+	lockTokenScopes                          sync.RWMutex
+	lockUnarchiveRepo                        sync.RWMutex
+	lockUpdateSecurityAndAnalysis            sync.RWMutex
+	lockVulnerabilityAlertsEnabled           sync.RWMutex
+}
+
+// AddSSHKey calls AddSSHKeyFunc.
+func (mock *ClientMock) AddSSHKey(title string, key string) (*SSHKey, error) {
+	if mock.AddSSHKeyFunc == nil {
+		panic("ClientMock.AddSSHKeyFunc: method is nil but Client.AddSSHKey was just called")
+	}
+	callInfo := struct {
+		Title string
+		Key   string
+	}{
+		Title: title,
+		Key:   key,
+	}
+	mock.lockAddSSHKey.Lock()
+	mock.calls.AddSSHKey = append(mock.calls.AddSSHKey, callInfo)
+	mock.lockAddSSHKey.Unlock()
+	return mock.AddSSHKeyFunc(title, key)
+}
+
+// AddSSHKeyCalls gets all the calls that were made to AddSSHKey.
+// Check the length with:
+//
+//	len(mockedClient.AddSSHKeyCalls())
+func (mock *ClientMock) AddSSHKeyCalls() []struct {
+	Title string
+	Key   string
+} {
+	var calls []struct {
+		Title string
+		Key   string
+	}
+	mock.lockAddSSHKey.RLock()
+	calls = mock.calls.AddSSHKey
+	mock.lockAddSSHKey.RUnlock()
+	return calls
+}
+
+// AutomatedSecurityFixesEnabled calls AutomatedSecurityFixesEnabledFunc.
+func (mock *ClientMock) AutomatedSecurityFixesEnabled(name string) (bool, error) {
+	if mock.AutomatedSecurityFixesEnabledFunc == nil {
+		panic("ClientMock.AutomatedSecurityFixesEnabledFunc: method is nil but Client.AutomatedSecurityFixesEnabled was just called")
+	}
+	callInfo := struct {
+		Name string
+	}{
+		Name: name,
+	}
+	mock.lockAutomatedSecurityFixesEnabled.Lock()
+	mock.calls.AutomatedSecurityFixesEnabled = append(mock.calls.AutomatedSecurityFixesEnabled, callInfo)
+	mock.lockAutomatedSecurityFixesEnabled.Unlock()
+	return mock.AutomatedSecurityFixesEnabledFunc(name)
+}
+
+// AutomatedSecurityFixesEnabledCalls gets all the calls that were made to AutomatedSecurityFixesEnabled.
+// Check the length with:
+//
+//	len(mockedClient.AutomatedSecurityFixesEnabledCalls())
+func (mock *ClientMock) AutomatedSecurityFixesEnabledCalls() []struct {
+	Name string
+} {
+	var calls []struct {
+		Name string
+	}
+	mock.lockAutomatedSecurityFixesEnabled.RLock()
+	calls = mock.calls.AutomatedSecurityFixesEnabled
+	mock.lockAutomatedSecurityFixesEnabled.RUnlock()
+	return calls
+}
+
+// CodeScanningDefaultSetupEnabled calls CodeScanningDefaultSetupEnabledFunc.
+func (mock *ClientMock) CodeScanningDefaultSetupEnabled(name string) (bool, error) {
+	if mock.CodeScanningDefaultSetupEnabledFunc == nil {
+		panic("ClientMock.CodeScanningDefaultSetupEnabledFunc: method is nil but Client.CodeScanningDefaultSetupEnabled was just called")
+	}
+	callInfo := struct {
+		Name string
+	}{
+		Name: name,
+	}
+	mock.lockCodeScanningDefaultSetupEnabled.Lock()
+	mock.calls.CodeScanningDefaultSetupEnabled = append(mock.calls.CodeScanningDefaultSetupEnabled, callInfo)
+	mock.lockCodeScanningDefaultSetupEnabled.Unlock()
+	return mock.CodeScanningDefaultSetupEnabledFunc(name)
+}
+
+// CodeScanningDefaultSetupEnabledCalls gets all the calls that were made to CodeScanningDefaultSetupEnabled.
+// Check the length with:
+//
+//	len(mockedClient.CodeScanningDefaultSetupEnabledCalls())
+func (mock *ClientMock) CodeScanningDefaultSetupEnabledCalls() []struct {
+	Name string
+} {
+	var calls []struct {
+		Name string
+	}
+	mock.lockCodeScanningDefaultSetupEnabled.RLock()
+	calls = mock.calls.CodeScanningDefaultSetupEnabled
+	mock.lockCodeScanningDefaultSetupEnabled.RUnlock()
+	return calls
+}
+
+// CreateFork calls CreateForkFunc.
+func (mock *ClientMock) CreateFork(name string, org string, forkName string) (*Repository, error) {
+	if mock.CreateForkFunc == nil {
+		panic("ClientMock.CreateForkFunc: method is nil but Client.CreateFork was just called")
+	}
+	callInfo := struct {
+		Name     string
+		Org      string
+		ForkName string
+	}{
+		Name:     name,
+		Org:      org,
+		ForkName: forkName,
 	}
-	lockCreateRepo    sync.RWMutex
-	lockCurrentRemote sync.RWMutex
-	lockCurrentUser   sync.RWMutex
-	lockGetAccount    sync.RWMutex
-	lockGetRepo       sync.RWMutex
+	mock.lockCreateFork.Lock()
+	mock.calls.CreateFork = append(mock.calls.CreateFork, callInfo)
+	mock.lockCreateFork.Unlock()
+	return mock.CreateForkFunc(name, org, forkName)
+}
+
+// CreateForkCalls gets all the calls that were made to CreateFork.
+// Check the length with:
+//
+//	len(mockedClient.CreateForkCalls())
+func (mock *ClientMock) CreateForkCalls() []struct {
+	Name     string
+	Org      string
+	ForkName string
+} {
+	var calls []struct {
+		Name     string
+		Org      string
+		ForkName string
+	}
+	mock.lockCreateFork.RLock()
+	calls = mock.calls.CreateFork
+	mock.lockCreateFork.RUnlock()
+	return calls
 }
 
 // CreateRepo calls CreateRepoFunc.
@@ -183,66 +701,917 @@ func (mock *ClientMock) CurrentUserCalls() []struct {
 	return calls
 }
 
-// GetAccount calls GetAccountFunc.
-func (mock *ClientMock) GetAccount(name string) (*Account, error) {
-	if mock.GetAccountFunc == nil {
-		panic("ClientMock.GetAccountFunc: method is nil but Client.GetAccount was just called")
+// EnableAutomatedSecurityFixes calls EnableAutomatedSecurityFixesFunc.
+func (mock *ClientMock) EnableAutomatedSecurityFixes(name string) error {
+	if mock.EnableAutomatedSecurityFixesFunc == nil {
+		panic("ClientMock.EnableAutomatedSecurityFixesFunc: method is nil but Client.EnableAutomatedSecurityFixes was just called")
 	}
 	callInfo := struct {
 		Name string
 	}{
 		Name: name,
 	}
-	mock.lockGetAccount.Lock()
-	mock.calls.GetAccount = append(mock.calls.GetAccount, callInfo)
-	mock.lockGetAccount.Unlock()
-	return mock.GetAccountFunc(name)
+	mock.lockEnableAutomatedSecurityFixes.Lock()
+	mock.calls.EnableAutomatedSecurityFixes = append(mock.calls.EnableAutomatedSecurityFixes, callInfo)
+	mock.lockEnableAutomatedSecurityFixes.Unlock()
+	return mock.EnableAutomatedSecurityFixesFunc(name)
 }
 
-// GetAccountCalls gets all the calls that were made to GetAccount.
+// EnableAutomatedSecurityFixesCalls gets all the calls that were made to EnableAutomatedSecurityFixes.
 // Check the length with:
 //
-//	len(mockedClient.GetAccountCalls())
-func (mock *ClientMock) GetAccountCalls() []struct {
+//	len(mockedClient.EnableAutomatedSecurityFixesCalls())
+func (mock *ClientMock) EnableAutomatedSecurityFixesCalls() []struct {
 	Name string
 } {
 	var calls []struct {
 		Name string
 	}
-	mock.lockGetAccount.RLock()
-	calls = mock.calls.GetAccount
-	mock.lockGetAccount.RUnlock()
+	mock.lockEnableAutomatedSecurityFixes.RLock()
+	calls = mock.calls.EnableAutomatedSecurityFixes
+	mock.lockEnableAutomatedSecurityFixes.RUnlock()
 	return calls
 }
 
-// GetRepo calls GetRepoFunc.
-func (mock *ClientMock) GetRepo(name string) (*Repository, error) {
-	if mock.GetRepoFunc == nil {
-		panic("ClientMock.GetRepoFunc: method is nil but Client.GetRepo was just called")
+// EnableCodeScanningDefaultSetup calls EnableCodeScanningDefaultSetupFunc.
+func (mock *ClientMock) EnableCodeScanningDefaultSetup(name string) error {
+	if mock.EnableCodeScanningDefaultSetupFunc == nil {
+		panic("ClientMock.EnableCodeScanningDefaultSetupFunc: method is nil but Client.EnableCodeScanningDefaultSetup was just called")
 	}
 	callInfo := struct {
 		Name string
 	}{
 		Name: name,
 	}
-	mock.lockGetRepo.Lock()
-	mock.calls.GetRepo = append(mock.calls.GetRepo, callInfo)
-	mock.lockGetRepo.Unlock()
-	return mock.GetRepoFunc(name)
+	mock.lockEnableCodeScanningDefaultSetup.Lock()
+	mock.calls.EnableCodeScanningDefaultSetup = append(mock.calls.EnableCodeScanningDefaultSetup, callInfo)
+	mock.lockEnableCodeScanningDefaultSetup.Unlock()
+	return mock.EnableCodeScanningDefaultSetupFunc(name)
 }
 
-// GetRepoCalls gets all the calls that were made to GetRepo.
+// EnableCodeScanningDefaultSetupCalls gets all the calls that were made to EnableCodeScanningDefaultSetup.
 // Check the length with:
 //
-//	len(mockedClient.GetRepoCalls())
-func (mock *ClientMock) GetRepoCalls() []struct {
+//	len(mockedClient.EnableCodeScanningDefaultSetupCalls())
+func (mock *ClientMock) EnableCodeScanningDefaultSetupCalls() []struct {
 	Name string
 } {
 	var calls []struct {
 		Name string
 	}
-	mock.lockGetRepo.RLock()
-	calls = mock.calls.GetRepo
-	mock.lockGetRepo.RUnlock()
+	mock.lockEnableCodeScanningDefaultSetup.RLock()
+	calls = mock.calls.EnableCodeScanningDefaultSetup
+	mock.lockEnableCodeScanningDefaultSetup.RUnlock()
+	return calls
+}
+
+// EnablePrivateVulnerabilityReporting calls EnablePrivateVulnerabilityReportingFunc.
+func (mock *ClientMock) EnablePrivateVulnerabilityReporting(name string) error {
+	if mock.EnablePrivateVulnerabilityReportingFunc == nil {
+		panic("ClientMock.EnablePrivateVulnerabilityReportingFunc: method is nil but Client.EnablePrivateVulnerabilityReporting was just called")
+	}
+	callInfo := struct {
+		Name string
+	}{
+		Name: name,
+	}
+	mock.lockEnablePrivateVulnerabilityReporting.Lock()
+	mock.calls.EnablePrivateVulnerabilityReporting = append(mock.calls.EnablePrivateVulnerabilityReporting, callInfo)
+	mock.lockEnablePrivateVulnerabilityReporting.Unlock()
+	return mock.EnablePrivateVulnerabilityReportingFunc(name)
+}
+
+// EnablePrivateVulnerabilityReportingCalls gets all the calls that were made to EnablePrivateVulnerabilityReporting.
+// Check the length with:
+//
+//	len(mockedClient.EnablePrivateVulnerabilityReportingCalls())
+func (mock *ClientMock) EnablePrivateVulnerabilityReportingCalls() []struct {
+	Name string
+} {
+	var calls []struct {
+		Name string
+	}
+	mock.lockEnablePrivateVulnerabilityReporting.RLock()
+	calls = mock.calls.EnablePrivateVulnerabilityReporting
+	mock.lockEnablePrivateVulnerabilityReporting.RUnlock()
+	return calls
+}
+
+// EnableVulnerabilityAlerts calls EnableVulnerabilityAlertsFunc.
+func (mock *ClientMock) EnableVulnerabilityAlerts(name string) error {
+	if mock.EnableVulnerabilityAlertsFunc == nil {
+		panic("ClientMock.EnableVulnerabilityAlertsFunc: method is nil but Client.EnableVulnerabilityAlerts was just called")
+	}
+	callInfo := struct {
+		Name string
+	}{
+		Name: name,
+	}
+	mock.lockEnableVulnerabilityAlerts.Lock()
+	mock.calls.EnableVulnerabilityAlerts = append(mock.calls.EnableVulnerabilityAlerts, callInfo)
+	mock.lockEnableVulnerabilityAlerts.Unlock()
+	return mock.EnableVulnerabilityAlertsFunc(name)
+}
+
+// EnableVulnerabilityAlertsCalls gets all the calls that were made to EnableVulnerabilityAlerts.
+// Check the length with:
+//
+//	len(mockedClient.EnableVulnerabilityAlertsCalls())
+func (mock *ClientMock) EnableVulnerabilityAlertsCalls() []struct {
+	Name string
+} {
+	var calls []struct {
+		Name string
+	}
+	mock.lockEnableVulnerabilityAlerts.RLock()
+	calls = mock.calls.EnableVulnerabilityAlerts
+	mock.lockEnableVulnerabilityAlerts.RUnlock()
+	return calls
+}
+
+// GetAccount calls GetAccountFunc.
+func (mock *ClientMock) GetAccount(name string) (*Account, error) {
+	if mock.GetAccountFunc == nil {
+		panic("ClientMock.GetAccountFunc: method is nil but Client.GetAccount was just called")
+	}
+	callInfo := struct {
+		Name string
+	}{
+		Name: name,
+	}
+	mock.lockGetAccount.Lock()
+	mock.calls.GetAccount = append(mock.calls.GetAccount, callInfo)
+	mock.lockGetAccount.Unlock()
+	return mock.GetAccountFunc(name)
+}
+
+// GetAccountCalls gets all the calls that were made to GetAccount.
+// Check the length with:
+//
+//	len(mockedClient.GetAccountCalls())
+func (mock *ClientMock) GetAccountCalls() []struct {
+	Name string
+} {
+	var calls []struct {
+		Name string
+	}
+	mock.lockGetAccount.RLock()
+	calls = mock.calls.GetAccount
+	mock.lockGetAccount.RUnlock()
+	return calls
+}
+
+// GetActionsPermissions calls GetActionsPermissionsFunc.
+func (mock *ClientMock) GetActionsPermissions(name string) (*ActionsPermissions, error) {
+	if mock.GetActionsPermissionsFunc == nil {
+		panic("ClientMock.GetActionsPermissionsFunc: method is nil but Client.GetActionsPermissions was just called")
+	}
+	callInfo := struct {
+		Name string
+	}{
+		Name: name,
+	}
+	mock.lockGetActionsPermissions.Lock()
+	mock.calls.GetActionsPermissions = append(mock.calls.GetActionsPermissions, callInfo)
+	mock.lockGetActionsPermissions.Unlock()
+	return mock.GetActionsPermissionsFunc(name)
+}
+
+// GetActionsPermissionsCalls gets all the calls that were made to GetActionsPermissions.
+// Check the length with:
+//
+//	len(mockedClient.GetActionsPermissionsCalls())
+func (mock *ClientMock) GetActionsPermissionsCalls() []struct {
+	Name string
+} {
+	var calls []struct {
+		Name string
+	}
+	mock.lockGetActionsPermissions.RLock()
+	calls = mock.calls.GetActionsPermissions
+	mock.lockGetActionsPermissions.RUnlock()
+	return calls
+}
+
+// GetRepo calls GetRepoFunc.
+func (mock *ClientMock) GetRepo(name string) (*Repository, error) {
+	if mock.GetRepoFunc == nil {
+		panic("ClientMock.GetRepoFunc: method is nil but Client.GetRepo was just called")
+	}
+	callInfo := struct {
+		Name string
+	}{
+		Name: name,
+	}
+	mock.lockGetRepo.Lock()
+	mock.calls.GetRepo = append(mock.calls.GetRepo, callInfo)
+	mock.lockGetRepo.Unlock()
+	return mock.GetRepoFunc(name)
+}
+
+// GetRepoCalls gets all the calls that were made to GetRepo.
+// Check the length with:
+//
+//	len(mockedClient.GetRepoCalls())
+func (mock *ClientMock) GetRepoCalls() []struct {
+	Name string
+} {
+	var calls []struct {
+		Name string
+	}
+	mock.lockGetRepo.RLock()
+	calls = mock.calls.GetRepo
+	mock.lockGetRepo.RUnlock()
+	return calls
+}
+
+// GetSelectedActions calls GetSelectedActionsFunc.
+func (mock *ClientMock) GetSelectedActions(name string) (*SelectedActions, error) {
+	if mock.GetSelectedActionsFunc == nil {
+		panic("ClientMock.GetSelectedActionsFunc: method is nil but Client.GetSelectedActions was just called")
+	}
+	callInfo := struct {
+		Name string
+	}{
+		Name: name,
+	}
+	mock.lockGetSelectedActions.Lock()
+	mock.calls.GetSelectedActions = append(mock.calls.GetSelectedActions, callInfo)
+	mock.lockGetSelectedActions.Unlock()
+	return mock.GetSelectedActionsFunc(name)
+}
+
+// GetSelectedActionsCalls gets all the calls that were made to GetSelectedActions.
+// Check the length with:
+//
+//	len(mockedClient.GetSelectedActionsCalls())
+func (mock *ClientMock) GetSelectedActionsCalls() []struct {
+	Name string
+} {
+	var calls []struct {
+		Name string
+	}
+	mock.lockGetSelectedActions.RLock()
+	calls = mock.calls.GetSelectedActions
+	mock.lockGetSelectedActions.RUnlock()
+	return calls
+}
+
+// GetTeam calls GetTeamFunc.
+func (mock *ClientMock) GetTeam(org string, slug string) (*Team, error) {
+	if mock.GetTeamFunc == nil {
+		panic("ClientMock.GetTeamFunc: method is nil but Client.GetTeam was just called")
+	}
+	callInfo := struct {
+		Org  string
+		Slug string
+	}{
+		Org:  org,
+		Slug: slug,
+	}
+	mock.lockGetTeam.Lock()
+	mock.calls.GetTeam = append(mock.calls.GetTeam, callInfo)
+	mock.lockGetTeam.Unlock()
+	return mock.GetTeamFunc(org, slug)
+}
+
+// GetTeamCalls gets all the calls that were made to GetTeam.
+// Check the length with:
+//
+//	len(mockedClient.GetTeamCalls())
+func (mock *ClientMock) GetTeamCalls() []struct {
+	Org  string
+	Slug string
+} {
+	var calls []struct {
+		Org  string
+		Slug string
+	}
+	mock.lockGetTeam.RLock()
+	calls = mock.calls.GetTeam
+	mock.lockGetTeam.RUnlock()
+	return calls
+}
+
+// GetWorkflowPermissions calls GetWorkflowPermissionsFunc.
+func (mock *ClientMock) GetWorkflowPermissions(name string) (*WorkflowPermissions, error) {
+	if mock.GetWorkflowPermissionsFunc == nil {
+		panic("ClientMock.GetWorkflowPermissionsFunc: method is nil but Client.GetWorkflowPermissions was just called")
+	}
+	callInfo := struct {
+		Name string
+	}{
+		Name: name,
+	}
+	mock.lockGetWorkflowPermissions.Lock()
+	mock.calls.GetWorkflowPermissions = append(mock.calls.GetWorkflowPermissions, callInfo)
+	mock.lockGetWorkflowPermissions.Unlock()
+	return mock.GetWorkflowPermissionsFunc(name)
+}
+
+// GetWorkflowPermissionsCalls gets all the calls that were made to GetWorkflowPermissions.
+// Check the length with:
+//
+//	len(mockedClient.GetWorkflowPermissionsCalls())
+func (mock *ClientMock) GetWorkflowPermissionsCalls() []struct {
+	Name string
+} {
+	var calls []struct {
+		Name string
+	}
+	mock.lockGetWorkflowPermissions.RLock()
+	calls = mock.calls.GetWorkflowPermissions
+	mock.lockGetWorkflowPermissions.RUnlock()
+	return calls
+}
+
+// HasBranch calls HasBranchFunc.
+func (mock *ClientMock) HasBranch(name string, branch string) (bool, error) {
+	if mock.HasBranchFunc == nil {
+		panic("ClientMock.HasBranchFunc: method is nil but Client.HasBranch was just called")
+	}
+	callInfo := struct {
+		Name   string
+		Branch string
+	}{
+		Name:   name,
+		Branch: branch,
+	}
+	mock.lockHasBranch.Lock()
+	mock.calls.HasBranch = append(mock.calls.HasBranch, callInfo)
+	mock.lockHasBranch.Unlock()
+	return mock.HasBranchFunc(name, branch)
+}
+
+// HasBranchCalls gets all the calls that were made to HasBranch.
+// Check the length with:
+//
+//	len(mockedClient.HasBranchCalls())
+func (mock *ClientMock) HasBranchCalls() []struct {
+	Name   string
+	Branch string
+} {
+	var calls []struct {
+		Name   string
+		Branch string
+	}
+	mock.lockHasBranch.RLock()
+	calls = mock.calls.HasBranch
+	mock.lockHasBranch.RUnlock()
+	return calls
+}
+
+// IsCollaborator calls IsCollaboratorFunc.
+func (mock *ClientMock) IsCollaborator(name string, login string) (bool, error) {
+	if mock.IsCollaboratorFunc == nil {
+		panic("ClientMock.IsCollaboratorFunc: method is nil but Client.IsCollaborator was just called")
+	}
+	callInfo := struct {
+		Name  string
+		Login string
+	}{
+		Name:  name,
+		Login: login,
+	}
+	mock.lockIsCollaborator.Lock()
+	mock.calls.IsCollaborator = append(mock.calls.IsCollaborator, callInfo)
+	mock.lockIsCollaborator.Unlock()
+	return mock.IsCollaboratorFunc(name, login)
+}
+
+// IsCollaboratorCalls gets all the calls that were made to IsCollaborator.
+// Check the length with:
+//
+//	len(mockedClient.IsCollaboratorCalls())
+func (mock *ClientMock) IsCollaboratorCalls() []struct {
+	Name  string
+	Login string
+} {
+	var calls []struct {
+		Name  string
+		Login string
+	}
+	mock.lockIsCollaborator.RLock()
+	calls = mock.calls.IsCollaborator
+	mock.lockIsCollaborator.RUnlock()
+	return calls
+}
+
+// ListEmails calls ListEmailsFunc.
+func (mock *ClientMock) ListEmails() ([]*Email, error) {
+	if mock.ListEmailsFunc == nil {
+		panic("ClientMock.ListEmailsFunc: method is nil but Client.ListEmails was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockListEmails.Lock()
+	mock.calls.ListEmails = append(mock.calls.ListEmails, callInfo)
+	mock.lockListEmails.Unlock()
+	return mock.ListEmailsFunc()
+}
+
+// ListEmailsCalls gets all the calls that were made to ListEmails.
+// Check the length with:
+//
+//	len(mockedClient.ListEmailsCalls())
+func (mock *ClientMock) ListEmailsCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockListEmails.RLock()
+	calls = mock.calls.ListEmails
+	mock.lockListEmails.RUnlock()
+	return calls
+}
+
+// ListGPGKeys calls ListGPGKeysFunc.
+func (mock *ClientMock) ListGPGKeys() ([]*GPGKey, error) {
+	if mock.ListGPGKeysFunc == nil {
+		panic("ClientMock.ListGPGKeysFunc: method is nil but Client.ListGPGKeys was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockListGPGKeys.Lock()
+	mock.calls.ListGPGKeys = append(mock.calls.ListGPGKeys, callInfo)
+	mock.lockListGPGKeys.Unlock()
+	return mock.ListGPGKeysFunc()
+}
+
+// ListGPGKeysCalls gets all the calls that were made to ListGPGKeys.
+// Check the length with:
+//
+//	len(mockedClient.ListGPGKeysCalls())
+func (mock *ClientMock) ListGPGKeysCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockListGPGKeys.RLock()
+	calls = mock.calls.ListGPGKeys
+	mock.lockListGPGKeys.RUnlock()
+	return calls
+}
+
+// ListRepoTeams calls ListRepoTeamsFunc.
+func (mock *ClientMock) ListRepoTeams(name string) ([]*Team, error) {
+	if mock.ListRepoTeamsFunc == nil {
+		panic("ClientMock.ListRepoTeamsFunc: method is nil but Client.ListRepoTeams was just called")
+	}
+	callInfo := struct {
+		Name string
+	}{
+		Name: name,
+	}
+	mock.lockListRepoTeams.Lock()
+	mock.calls.ListRepoTeams = append(mock.calls.ListRepoTeams, callInfo)
+	mock.lockListRepoTeams.Unlock()
+	return mock.ListRepoTeamsFunc(name)
+}
+
+// ListRepoTeamsCalls gets all the calls that were made to ListRepoTeams.
+// Check the length with:
+//
+//	len(mockedClient.ListRepoTeamsCalls())
+func (mock *ClientMock) ListRepoTeamsCalls() []struct {
+	Name string
+} {
+	var calls []struct {
+		Name string
+	}
+	mock.lockListRepoTeams.RLock()
+	calls = mock.calls.ListRepoTeams
+	mock.lockListRepoTeams.RUnlock()
+	return calls
+}
+
+// ListSSHKeys calls ListSSHKeysFunc.
+func (mock *ClientMock) ListSSHKeys() ([]*SSHKey, error) {
+	if mock.ListSSHKeysFunc == nil {
+		panic("ClientMock.ListSSHKeysFunc: method is nil but Client.ListSSHKeys was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockListSSHKeys.Lock()
+	mock.calls.ListSSHKeys = append(mock.calls.ListSSHKeys, callInfo)
+	mock.lockListSSHKeys.Unlock()
+	return mock.ListSSHKeysFunc()
+}
+
+// ListSSHKeysCalls gets all the calls that were made to ListSSHKeys.
+// Check the length with:
+//
+//	len(mockedClient.ListSSHKeysCalls())
+func (mock *ClientMock) ListSSHKeysCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockListSSHKeys.RLock()
+	calls = mock.calls.ListSSHKeys
+	mock.lockListSSHKeys.RUnlock()
+	return calls
+}
+
+// ListSSHSigningKeys calls ListSSHSigningKeysFunc.
+func (mock *ClientMock) ListSSHSigningKeys() ([]*SSHSigningKey, error) {
+	if mock.ListSSHSigningKeysFunc == nil {
+		panic("ClientMock.ListSSHSigningKeysFunc: method is nil but Client.ListSSHSigningKeys was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockListSSHSigningKeys.Lock()
+	mock.calls.ListSSHSigningKeys = append(mock.calls.ListSSHSigningKeys, callInfo)
+	mock.lockListSSHSigningKeys.Unlock()
+	return mock.ListSSHSigningKeysFunc()
+}
+
+// ListSSHSigningKeysCalls gets all the calls that were made to ListSSHSigningKeys.
+// Check the length with:
+//
+//	len(mockedClient.ListSSHSigningKeysCalls())
+func (mock *ClientMock) ListSSHSigningKeysCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockListSSHSigningKeys.RLock()
+	calls = mock.calls.ListSSHSigningKeys
+	mock.lockListSSHSigningKeys.RUnlock()
+	return calls
+}
+
+// ListTemplates calls ListTemplatesFunc.
+func (mock *ClientMock) ListTemplates(owner string) ([]*Repository, error) {
+	if mock.ListTemplatesFunc == nil {
+		panic("ClientMock.ListTemplatesFunc: method is nil but Client.ListTemplates was just called")
+	}
+	callInfo := struct {
+		Owner string
+	}{
+		Owner: owner,
+	}
+	mock.lockListTemplates.Lock()
+	mock.calls.ListTemplates = append(mock.calls.ListTemplates, callInfo)
+	mock.lockListTemplates.Unlock()
+	return mock.ListTemplatesFunc(owner)
+}
+
+// ListTemplatesCalls gets all the calls that were made to ListTemplates.
+// Check the length with:
+//
+//	len(mockedClient.ListTemplatesCalls())
+func (mock *ClientMock) ListTemplatesCalls() []struct {
+	Owner string
+} {
+	var calls []struct {
+		Owner string
+	}
+	mock.lockListTemplates.RLock()
+	calls = mock.calls.ListTemplates
+	mock.lockListTemplates.RUnlock()
+	return calls
+}
+
+// PrivateVulnerabilityReportingEnabled calls PrivateVulnerabilityReportingEnabledFunc.
+func (mock *ClientMock) PrivateVulnerabilityReportingEnabled(name string) (bool, error) {
+	if mock.PrivateVulnerabilityReportingEnabledFunc == nil {
+		panic("ClientMock.PrivateVulnerabilityReportingEnabledFunc: method is nil but Client.PrivateVulnerabilityReportingEnabled was just called")
+	}
+	callInfo := struct {
+		Name string
+	}{
+		Name: name,
+	}
+	mock.lockPrivateVulnerabilityReportingEnabled.Lock()
+	mock.calls.PrivateVulnerabilityReportingEnabled = append(mock.calls.PrivateVulnerabilityReportingEnabled, callInfo)
+	mock.lockPrivateVulnerabilityReportingEnabled.Unlock()
+	return mock.PrivateVulnerabilityReportingEnabledFunc(name)
+}
+
+// PrivateVulnerabilityReportingEnabledCalls gets all the calls that were made to PrivateVulnerabilityReportingEnabled.
+// Check the length with:
+//
+//	len(mockedClient.PrivateVulnerabilityReportingEnabledCalls())
+func (mock *ClientMock) PrivateVulnerabilityReportingEnabledCalls() []struct {
+	Name string
+} {
+	var calls []struct {
+		Name string
+	}
+	mock.lockPrivateVulnerabilityReportingEnabled.RLock()
+	calls = mock.calls.PrivateVulnerabilityReportingEnabled
+	mock.lockPrivateVulnerabilityReportingEnabled.RUnlock()
+	return calls
+}
+
+// SetActionsPermissions calls SetActionsPermissionsFunc.
+func (mock *ClientMock) SetActionsPermissions(name string, permissions *ActionsPermissions) error {
+	if mock.SetActionsPermissionsFunc == nil {
+		panic("ClientMock.SetActionsPermissionsFunc: method is nil but Client.SetActionsPermissions was just called")
+	}
+	callInfo := struct {
+		Name        string
+		Permissions *ActionsPermissions
+	}{
+		Name:        name,
+		Permissions: permissions,
+	}
+	mock.lockSetActionsPermissions.Lock()
+	mock.calls.SetActionsPermissions = append(mock.calls.SetActionsPermissions, callInfo)
+	mock.lockSetActionsPermissions.Unlock()
+	return mock.SetActionsPermissionsFunc(name, permissions)
+}
+
+// SetActionsPermissionsCalls gets all the calls that were made to SetActionsPermissions.
+// Check the length with:
+//
+//	len(mockedClient.SetActionsPermissionsCalls())
+func (mock *ClientMock) SetActionsPermissionsCalls() []struct {
+	Name        string
+	Permissions *ActionsPermissions
+} {
+	var calls []struct {
+		Name        string
+		Permissions *ActionsPermissions
+	}
+	mock.lockSetActionsPermissions.RLock()
+	calls = mock.calls.SetActionsPermissions
+	mock.lockSetActionsPermissions.RUnlock()
+	return calls
+}
+
+// SetSelectedActions calls SetSelectedActionsFunc.
+func (mock *ClientMock) SetSelectedActions(name string, actions *SelectedActions) error {
+	if mock.SetSelectedActionsFunc == nil {
+		panic("ClientMock.SetSelectedActionsFunc: method is nil but Client.SetSelectedActions was just called")
+	}
+	callInfo := struct {
+		Name    string
+		Actions *SelectedActions
+	}{
+		Name:    name,
+		Actions: actions,
+	}
+	mock.lockSetSelectedActions.Lock()
+	mock.calls.SetSelectedActions = append(mock.calls.SetSelectedActions, callInfo)
+	mock.lockSetSelectedActions.Unlock()
+	return mock.SetSelectedActionsFunc(name, actions)
+}
+
+// SetSelectedActionsCalls gets all the calls that were made to SetSelectedActions.
+// Check the length with:
+//
+//	len(mockedClient.SetSelectedActionsCalls())
+func (mock *ClientMock) SetSelectedActionsCalls() []struct {
+	Name    string
+	Actions *SelectedActions
+} {
+	var calls []struct {
+		Name    string
+		Actions *SelectedActions
+	}
+	mock.lockSetSelectedActions.RLock()
+	calls = mock.calls.SetSelectedActions
+	mock.lockSetSelectedActions.RUnlock()
+	return calls
+}
+
+// SetTeamPermission calls SetTeamPermissionFunc.
+func (mock *ClientMock) SetTeamPermission(org string, slug string, name string, permission string) error {
+	if mock.SetTeamPermissionFunc == nil {
+		panic("ClientMock.SetTeamPermissionFunc: method is nil but Client.SetTeamPermission was just called")
+	}
+	callInfo := struct {
+		Org        string
+		Slug       string
+		Name       string
+		Permission string
+	}{
+		Org:        org,
+		Slug:       slug,
+		Name:       name,
+		Permission: permission,
+	}
+	mock.lockSetTeamPermission.Lock()
+	mock.calls.SetTeamPermission = append(mock.calls.SetTeamPermission, callInfo)
+	mock.lockSetTeamPermission.Unlock()
+	return mock.SetTeamPermissionFunc(org, slug, name, permission)
+}
+
+// SetTeamPermissionCalls gets all the calls that were made to SetTeamPermission.
+// Check the length with:
+//
+//	len(mockedClient.SetTeamPermissionCalls())
+func (mock *ClientMock) SetTeamPermissionCalls() []struct {
+	Org        string
+	Slug       string
+	Name       string
+	Permission string
+} {
+	var calls []struct {
+		Org        string
+		Slug       string
+		Name       string
+		Permission string
+	}
+	mock.lockSetTeamPermission.RLock()
+	calls = mock.calls.SetTeamPermission
+	mock.lockSetTeamPermission.RUnlock()
+	return calls
+}
+
+// SetWorkflowPermissions calls SetWorkflowPermissionsFunc.
+func (mock *ClientMock) SetWorkflowPermissions(name string, permissions *WorkflowPermissions) error {
+	if mock.SetWorkflowPermissionsFunc == nil {
+		panic("ClientMock.SetWorkflowPermissionsFunc: method is nil but Client.SetWorkflowPermissions was just called")
+	}
+	callInfo := struct {
+		Name        string
+		Permissions *WorkflowPermissions
+	}{
+		Name:        name,
+		Permissions: permissions,
+	}
+	mock.lockSetWorkflowPermissions.Lock()
+	mock.calls.SetWorkflowPermissions = append(mock.calls.SetWorkflowPermissions, callInfo)
+	mock.lockSetWorkflowPermissions.Unlock()
+	return mock.SetWorkflowPermissionsFunc(name, permissions)
+}
+
+// SetWorkflowPermissionsCalls gets all the calls that were made to SetWorkflowPermissions.
+// Check the length with:
+//
+//	len(mockedClient.SetWorkflowPermissionsCalls())
+func (mock *ClientMock) SetWorkflowPermissionsCalls() []struct {
+	Name        string
+	Permissions *WorkflowPermissions
+} {
+	var calls []struct {
+		Name        string
+		Permissions *WorkflowPermissions
+	}
+	mock.lockSetWorkflowPermissions.RLock()
+	calls = mock.calls.SetWorkflowPermissions
+	mock.lockSetWorkflowPermissions.RUnlock()
+	return calls
+}
+
+// SyncFork calls SyncForkFunc.
+func (mock *ClientMock) SyncFork(name string, branch string) error {
+	if mock.SyncForkFunc == nil {
+		panic("ClientMock.SyncForkFunc: method is nil but Client.SyncFork was just called")
+	}
+	callInfo := struct {
+		Name   string
+		Branch string
+	}{
+		Name:   name,
+		Branch: branch,
+	}
+	mock.lockSyncFork.Lock()
+	mock.calls.SyncFork = append(mock.calls.SyncFork, callInfo)
+	mock.lockSyncFork.Unlock()
+	return mock.SyncForkFunc(name, branch)
+}
+
+// SyncForkCalls gets all the calls that were made to SyncFork.
+// Check the length with:
+//
+//	len(mockedClient.SyncForkCalls())
+func (mock *ClientMock) SyncForkCalls() []struct {
+	Name   string
+	Branch string
+} {
+	var calls []struct {
+		Name   string
+		Branch string
+	}
+	mock.lockSyncFork.RLock()
+	calls = mock.calls.SyncFork
+	mock.lockSyncFork.RUnlock()
+	return calls
+}
+
+// TokenScopes calls TokenScopesFunc.
+func (mock *ClientMock) TokenScopes() ([]string, error) {
+	if mock.TokenScopesFunc == nil {
+		panic("ClientMock.TokenScopesFunc: method is nil but Client.TokenScopes was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockTokenScopes.Lock()
+	mock.calls.TokenScopes = append(mock.calls.TokenScopes, callInfo)
+	mock.lockTokenScopes.Unlock()
+	return mock.TokenScopesFunc()
+}
+
+// TokenScopesCalls gets all the calls that were made to TokenScopes.
+// Check the length with:
+//
+//	len(mockedClient.TokenScopesCalls())
+func (mock *ClientMock) TokenScopesCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockTokenScopes.RLock()
+	calls = mock.calls.TokenScopes
+	mock.lockTokenScopes.RUnlock()
+	return calls
+}
+
+// UnarchiveRepo calls UnarchiveRepoFunc.
+func (mock *ClientMock) UnarchiveRepo(name string) (*Repository, error) {
+	if mock.UnarchiveRepoFunc == nil {
+		panic("ClientMock.UnarchiveRepoFunc: method is nil but Client.UnarchiveRepo was just called")
+	}
+	callInfo := struct {
+		Name string
+	}{
+		Name: name,
+	}
+	mock.lockUnarchiveRepo.Lock()
+	mock.calls.UnarchiveRepo = append(mock.calls.UnarchiveRepo, callInfo)
+	mock.lockUnarchiveRepo.Unlock()
+	return mock.UnarchiveRepoFunc(name)
+}
+
+// UnarchiveRepoCalls gets all the calls that were made to UnarchiveRepo.
+// Check the length with:
+//
+//	len(mockedClient.UnarchiveRepoCalls())
+func (mock *ClientMock) UnarchiveRepoCalls() []struct {
+	Name string
+} {
+	var calls []struct {
+		Name string
+	}
+	mock.lockUnarchiveRepo.RLock()
+	calls = mock.calls.UnarchiveRepo
+	mock.lockUnarchiveRepo.RUnlock()
+	return calls
+}
+
+// UpdateSecurityAndAnalysis calls UpdateSecurityAndAnalysisFunc.
+func (mock *ClientMock) UpdateSecurityAndAnalysis(name string, settings *SecurityAndAnalysis) error {
+	if mock.UpdateSecurityAndAnalysisFunc == nil {
+		panic("ClientMock.UpdateSecurityAndAnalysisFunc: method is nil but Client.UpdateSecurityAndAnalysis was just called")
+	}
+	callInfo := struct {
+		Name     string
+		Settings *SecurityAndAnalysis
+	}{
+		Name:     name,
+		Settings: settings,
+	}
+	mock.lockUpdateSecurityAndAnalysis.Lock()
+	mock.calls.UpdateSecurityAndAnalysis = append(mock.calls.UpdateSecurityAndAnalysis, callInfo)
+	mock.lockUpdateSecurityAndAnalysis.Unlock()
+	return mock.UpdateSecurityAndAnalysisFunc(name, settings)
+}
+
+// UpdateSecurityAndAnalysisCalls gets all the calls that were made to UpdateSecurityAndAnalysis.
+// Check the length with:
+//
+//	len(mockedClient.UpdateSecurityAndAnalysisCalls())
+func (mock *ClientMock) UpdateSecurityAndAnalysisCalls() []struct {
+	Name     string
+	Settings *SecurityAndAnalysis
+} {
+	var calls []struct {
+		Name     string
+		Settings *SecurityAndAnalysis
+	}
+	mock.lockUpdateSecurityAndAnalysis.RLock()
+	calls = mock.calls.UpdateSecurityAndAnalysis
+	mock.lockUpdateSecurityAndAnalysis.RUnlock()
+	return calls
+}
+
+// VulnerabilityAlertsEnabled calls VulnerabilityAlertsEnabledFunc.
+func (mock *ClientMock) VulnerabilityAlertsEnabled(name string) (bool, error) {
+	if mock.VulnerabilityAlertsEnabledFunc == nil {
+		panic("ClientMock.VulnerabilityAlertsEnabledFunc: method is nil but Client.VulnerabilityAlertsEnabled was just called")
+	}
+	callInfo := struct {
+		Name string
+	}{
+		Name: name,
+	}
+	mock.lockVulnerabilityAlertsEnabled.Lock()
+	mock.calls.VulnerabilityAlertsEnabled = append(mock.calls.VulnerabilityAlertsEnabled, callInfo)
+	mock.lockVulnerabilityAlertsEnabled.Unlock()
+	return mock.VulnerabilityAlertsEnabledFunc(name)
+}
+
+// VulnerabilityAlertsEnabledCalls gets all the calls that were made to VulnerabilityAlertsEnabled.
+// Check the length with:
+//
+//	len(mockedClient.VulnerabilityAlertsEnabledCalls())
+func (mock *ClientMock) VulnerabilityAlertsEnabledCalls() []struct {
+	Name string
+} {
+	var calls []struct {
+		Name string
+	}
+	mock.lockVulnerabilityAlertsEnabled.RLock()
+	calls = mock.calls.VulnerabilityAlertsEnabled
+	mock.lockVulnerabilityAlertsEnabled.RUnlock()
 	return calls
 }
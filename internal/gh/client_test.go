@@ -4,7 +4,10 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/cli/go-gh/pkg/api"
@@ -38,14 +41,14 @@ func TestClient_CurrentUser(t *testing.T) {
 						user.Login = "test-user"
 						return nil
 					}
-					if path == "user/orgs" {
-						orgs := resp.(*[]*Account)
-						*orgs = append(*orgs, &Account{Login: "org1"})
-						*orgs = append(*orgs, &Account{Login: "org2"})
-						return nil
-					}
 					return errors.New("unexpected path")
 				},
+				RequestFunc: func(method, path string, body io.Reader) (*http.Response, error) {
+					if path == "user/orgs?per_page=100" {
+						return newJSONResponse(`[{"login": "org1"}, {"login": "org2"}]`, ""), nil
+					}
+					return nil, errors.New("unexpected path")
+				},
 			},
 			expected: &User{
 				Login: "test-user",
@@ -71,7 +74,7 @@ func TestClient_CurrentUser(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.desc, func(t *testing.T) {
-			client := NewClient(tt.restClient, tt.execFunc)
+			client := NewClient("", tt.restClient, nil, tt.execFunc)
 			actual, err := client.CurrentUser()
 
 			if tt.err == "" {
@@ -190,7 +193,7 @@ func TestClient_CreateRepo(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.desc, func(t *testing.T) {
-			client := NewClient(tt.restClient, nil)
+			client := NewClient("", tt.restClient, nil, nil)
 			actual, err := client.CreateRepo(tt.args.owner, tt.args.name, tt.args.vis)
 
 			if tt.err == "" {
@@ -257,7 +260,7 @@ func TestClient_GetAccount(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.desc, func(t *testing.T) {
-			client := NewClient(tt.restClient, tt.execFunc)
+			client := NewClient("", tt.restClient, nil, tt.execFunc)
 			actual, err := client.GetAccount("someone")
 
 			if tt.err == "" {
@@ -326,7 +329,7 @@ func TestClient_GetRepo(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.desc, func(t *testing.T) {
-			client := NewClient(tt.restClient, tt.execFunc)
+			client := NewClient("", tt.restClient, nil, tt.execFunc)
 			actual, err := client.GetRepo("test-owner/test-repo")
 
 			if tt.err == "" {
@@ -339,3 +342,624 @@ func TestClient_GetRepo(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_EnterpriseHost(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v3/user", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"login": "test-user"}`)
+	})
+	mux.HandleFunc("/api/v3/user/orgs", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			fmt.Fprint(w, `[{"login": "org2"}]`)
+			return
+		}
+		w.Header().Set("Link", `<https://ghe.example.com/api/v3/user/orgs?page=2>; rel="next"`)
+		fmt.Fprint(w, `[{"login": "org1"}]`)
+	})
+	mux.HandleFunc("/api/v3/users/org1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"login": "org1", "type": "Organization"}`)
+	})
+	restClient, requests := newFakeHost(t, "ghe.example.com", mux)
+
+	client := NewClient("ghe.example.com", restClient, nil, func(args ...string) (bytes.Buffer, bytes.Buffer, error) {
+		expected := []string{"config", "get", "git_protocol", "-h", "ghe.example.com"}
+		if !cmp.Equal(args, expected) {
+			return bytes.Buffer{}, bytes.Buffer{}, errors.New("unexpected args")
+		}
+		return *bytes.NewBufferString("ssh\n"), bytes.Buffer{}, nil
+	})
+
+	user, err := client.CurrentUser()
+	assert.NoError(t, err)
+	assert.Equal(t, &User{
+		Login: "test-user",
+		Orgs: []*Account{
+			{Login: "org1"},
+			{Login: "org2"},
+		},
+		GitProtocol: ProtocolSSH,
+	}, user)
+
+	account, err := client.GetAccount("org1")
+	assert.NoError(t, err)
+	assert.Equal(t, &Account{Login: "org1", Type: AccountTypeOrg}, account)
+
+	account, err = client.GetAccount("unknown")
+	assert.NoError(t, err)
+	assert.Nil(t, account)
+
+	for _, req := range *requests {
+		assert.Equal(t, "ghe.example.com", req.Host)
+		assert.Equal(t, "token test-token", req.Header.Get("Authorization"))
+	}
+}
+
+// newFakeHost returns a REST client for host that sends all requests
+// to an httptest server using handler. The requests made are recorded.
+func newFakeHost(t *testing.T, host string, handler http.Handler) (RESTClient, *[]*http.Request) {
+	t.Helper()
+
+	requests := stubFakeHost(t, handler)
+	restClient, err := NewRESTClient(host, "test-token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return restClient, requests
+}
+
+// stubFakeHost stubs the API client constructors so that all requests
+// are sent to an httptest server using handler. The requests made are recorded.
+func stubFakeHost(t *testing.T, handler http.Handler) *[]*http.Request {
+	t.Helper()
+
+	requests := []*http.Request{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r)
+		handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	transport := &fakeHostTransport{
+		addr: server.Listener.Addr().String(),
+	}
+	originalREST := newAPIRESTClient
+	newAPIRESTClient = func(opts *api.ClientOptions) (api.RESTClient, error) {
+		opts.Transport = transport
+		return originalREST(opts)
+	}
+	originalGQL := newAPIGQLClient
+	newAPIGQLClient = func(opts *api.ClientOptions) (api.GQLClient, error) {
+		opts.Transport = transport
+		return originalGQL(opts)
+	}
+	t.Cleanup(func() {
+		newAPIRESTClient = originalREST
+		newAPIGQLClient = originalGQL
+	})
+
+	return &requests
+}
+
+// fakeHostTransport redirects requests to an httptest server,
+// leaving the Host header untouched.
+type fakeHostTransport struct {
+	addr string
+}
+
+func (t *fakeHostTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Host = req.URL.Host
+	req.URL.Scheme = "http"
+	req.URL.Host = t.addr
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestClient_TokenScopes(t *testing.T) {
+	tests := []struct {
+		desc     string
+		scopes   []string
+		expected []string
+	}{
+		{
+			desc:     "returns the scopes granted to the token",
+			scopes:   []string{"gist, read:org, repo"},
+			expected: []string{"gist", "read:org", "repo"},
+		},
+		{
+			desc:     "returns nil for tokens without scopes",
+			scopes:   nil,
+			expected: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			restClient, _ := newFakeHost(t, "github.com", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				for _, scope := range tt.scopes {
+					w.Header().Add("X-OAuth-Scopes", scope)
+				}
+				fmt.Fprint(w, `{"login": "test-user"}`)
+			}))
+			client := NewClient("github.com", restClient, nil, nil)
+
+			actual, err := client.TokenScopes()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestClient_ListEmailsAndKeys(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/user/emails", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"email": "test@example.com", "primary": true, "verified": true}]`)
+	})
+	mux.HandleFunc("/user/gpg_keys", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{
+			"id": 1,
+			"key_id": "3262EFF25BA0D270",
+			"emails": [{"email": "test@example.com", "verified": true}],
+			"subkeys": [{"id": 2, "key_id": "4A595D4C72EE49C7"}]
+		}]`)
+	})
+	mux.HandleFunc("/user/keys", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			body, _ := io.ReadAll(r.Body)
+			assert.JSONEq(t, `{"title": "laptop", "key": "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIB"}`, string(body))
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"id": 5, "title": "laptop", "key": "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIB"}`)
+			return
+		}
+		fmt.Fprint(w, `[{"id": 4, "title": "desktop", "key": "ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQ"}]`)
+	})
+	mux.HandleFunc("/user/ssh_signing_keys", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"id": 3, "title": "laptop", "key": "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIA"}]`)
+	})
+	restClient, _ := newFakeHost(t, "github.com", mux)
+	client := NewClient("github.com", restClient, nil, nil)
+
+	emails, err := client.ListEmails()
+	assert.NoError(t, err)
+	assert.Equal(t, []*Email{{Email: "test@example.com", Primary: true, Verified: true}}, emails)
+
+	gpgKeys, err := client.ListGPGKeys()
+	assert.NoError(t, err)
+	assert.Equal(t, []*GPGKey{{
+		ID:      1,
+		KeyID:   "3262EFF25BA0D270",
+		Emails:  []*Email{{Email: "test@example.com", Verified: true}},
+		Subkeys: []*GPGKey{{ID: 2, KeyID: "4A595D4C72EE49C7"}},
+	}}, gpgKeys)
+
+	sshKeys, err := client.ListSSHSigningKeys()
+	assert.NoError(t, err)
+	assert.Equal(t, []*SSHSigningKey{{ID: 3, Title: "laptop", Key: "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIA"}}, sshKeys)
+
+	authKeys, err := client.ListSSHKeys()
+	assert.NoError(t, err)
+	assert.Equal(t, []*SSHKey{{ID: 4, Title: "desktop", Key: "ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQ"}}, authKeys)
+
+	added, err := client.AddSSHKey("laptop", "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIB")
+	assert.NoError(t, err)
+	assert.Equal(t, &SSHKey{ID: 5, Title: "laptop", Key: "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIB"}, added)
+}
+
+func TestClient_UnarchiveRepo(t *testing.T) {
+	restClient, _ := newFakeHost(t, "github.com", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPatch, r.Method)
+		assert.Equal(t, "/repos/some-org/some-repo", r.URL.Path)
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"archived": false}`, string(body))
+		fmt.Fprint(w, `{"full_name": "some-org/some-repo", "archived": false}`)
+	}))
+	client := NewClient("github.com", restClient, nil, nil)
+
+	repo, err := client.UnarchiveRepo("some-org/some-repo")
+	assert.NoError(t, err)
+	assert.Equal(t, &Repository{FullName: "some-org/some-repo"}, repo)
+}
+
+func TestClient_CreateFork(t *testing.T) {
+	restClient, _ := newFakeHost(t, "github.com", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/repos/other-org/some-repo/forks", r.URL.Path)
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"organization": "some-org", "name": "renamed"}`, string(body))
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprint(w, `{"full_name": "some-org/renamed", "fork": true, "default_branch": "main"}`)
+	}))
+	client := NewClient("github.com", restClient, nil, nil)
+
+	repo, err := client.CreateFork("other-org/some-repo", "some-org", "renamed")
+	assert.NoError(t, err)
+	assert.Equal(t, &Repository{FullName: "some-org/renamed", Fork: true, DefaultBranch: "main"}, repo)
+}
+
+func TestClient_HasBranch(t *testing.T) {
+	restClient, _ := newFakeHost(t, "github.com", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/some-org/some-repo/branches/main":
+			fmt.Fprint(w, `{"name": "main"}`)
+		case "/repos/some-org/some-repo/branches/missing":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"message": "Branch not found"}`)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	client := NewClient("github.com", restClient, nil, nil)
+
+	ok, err := client.HasBranch("some-org/some-repo", "main")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = client.HasBranch("some-org/some-repo", "missing")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestClient_SyncFork(t *testing.T) {
+	restClient, _ := newFakeHost(t, "github.com", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/repos/some-org/some-repo/merge-upstream", r.URL.Path)
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"branch": "main"}`, string(body))
+		fmt.Fprint(w, `{"message": "Successfully fetched and fast-forwarded from upstream other-org:main."}`)
+	}))
+	client := NewClient("github.com", restClient, nil, nil)
+
+	err := client.SyncFork("some-org/some-repo", "main")
+	assert.NoError(t, err)
+}
+
+func TestClient_CreateRepo_TranslatesErrors(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/users/some-org", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"login": "some-org", "type": "Organization"}`)
+	})
+	mux.HandleFunc("/orgs/some-org/repos", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		fmt.Fprint(w, `{
+			"message": "Repository creation failed.",
+			"errors": [{
+				"resource": "Repository",
+				"code": "custom",
+				"field": "name",
+				"message": "name already exists on this account"
+			}]
+		}`)
+	})
+	restClient, _ := newFakeHost(t, "github.com", mux)
+	client := NewClient("github.com", restClient, nil, nil)
+
+	repo, err := client.CreateRepo("some-org", "some-repo", VisibilityPublic)
+	assert.Nil(t, repo)
+	assert.EqualError(t, err, "A repo with that name already exists on this account.")
+}
+
+func TestClient_ListTemplates(t *testing.T) {
+	tests := []struct {
+		desc     string
+		host     string
+		apiHost  string
+		path     string
+		pages    []string
+		expected []*Repository
+		err      string
+	}{
+		{
+			desc:    "returns the template repos across all pages",
+			host:    "github.com",
+			apiHost: "api.github.com",
+			path:    "/graphql",
+			pages: []string{
+				`{"data": {"repositoryOwner": {"repositories": {
+					"nodes": [
+						{"name": "a-template", "nameWithOwner": "some-org/a-template",
+						 "description": "A template", "url": "https://github.com/some-org/a-template",
+						 "isTemplate": true, "visibility": "PUBLIC"},
+						{"name": "b-repo", "nameWithOwner": "some-org/b-repo", "isTemplate": false}
+					],
+					"pageInfo": {"hasNextPage": true, "endCursor": "cursor1"}
+				}}}}`,
+				`{"data": {"repositoryOwner": {"repositories": {
+					"nodes": [
+						{"name": "c-template", "nameWithOwner": "some-org/c-template",
+						 "url": "https://github.com/some-org/c-template",
+						 "isTemplate": true, "visibility": "PRIVATE"}
+					],
+					"pageInfo": {"hasNextPage": false, "endCursor": "cursor2"}
+				}}}}`,
+			},
+			expected: []*Repository{
+				{
+					Name:        "a-template",
+					FullName:    "some-org/a-template",
+					Owner:       &Account{Login: "some-org"},
+					Description: "A template",
+					Visibility:  VisibilityPublic,
+					IsTemplate:  true,
+					URL:         "https://github.com/some-org/a-template",
+				},
+				{
+					Name:       "c-template",
+					FullName:   "some-org/c-template",
+					Owner:      &Account{Login: "some-org"},
+					Visibility: VisibilityPrivate,
+					IsTemplate: true,
+					URL:        "https://github.com/some-org/c-template",
+				},
+			},
+		},
+		{
+			desc:    "uses the enterprise graphql endpoint",
+			host:    "ghe.example.com",
+			apiHost: "ghe.example.com",
+			path:    "/api/graphql",
+			pages: []string{
+				`{"data": {"repositoryOwner": {"repositories": {
+					"nodes": [],
+					"pageInfo": {"hasNextPage": false, "endCursor": null}
+				}}}}`,
+			},
+			expected: []*Repository{},
+		},
+		{
+			desc:    "returns an empty slice for unknown owners",
+			host:    "github.com",
+			apiHost: "api.github.com",
+			path:    "/graphql",
+			pages: []string{
+				`{"data": {"repositoryOwner": null}}`,
+			},
+			expected: []*Repository{},
+		},
+		{
+			desc:    "returns graphql errors",
+			host:    "github.com",
+			apiHost: "api.github.com",
+			path:    "/graphql",
+			pages: []string{
+				`{"data": null, "errors": [{"message": "Something went wrong"}]}`,
+			},
+			expected: nil,
+			err:      "GraphQL: Something went wrong",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			cursors := []interface{}{}
+			count := 0
+			requests := stubFakeHost(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body := struct {
+					Query     string
+					Variables map[string]interface{}
+				}{}
+				_ = json.NewDecoder(r.Body).Decode(&body)
+				assert.Contains(t, body.Query, "query ListTemplates")
+				assert.Equal(t, "some-org", body.Variables["owner"])
+				cursors = append(cursors, body.Variables["cursor"])
+
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprint(w, tt.pages[count])
+				count++
+			}))
+			graphQLClient, err := NewGraphQLClient(tt.host, "test-token")
+			assert.NoError(t, err)
+			client := NewClient(tt.host, nil, graphQLClient, nil)
+
+			actual, err := client.ListTemplates("some-org")
+
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+			assert.Equal(t, tt.expected, actual)
+			assert.Equal(t, len(tt.pages), len(*requests))
+			for i, req := range *requests {
+				assert.Equal(t, http.MethodPost, req.Method)
+				assert.Equal(t, tt.path, req.URL.Path)
+				assert.Equal(t, tt.apiHost, req.Host)
+				if i == 0 {
+					assert.Nil(t, cursors[i])
+				} else {
+					assert.Equal(t, "cursor1", cursors[i])
+				}
+			}
+		})
+	}
+}
+
+func TestClient_Teams(t *testing.T) {
+	restClient, requests := newFakeHost(t, "github.com", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /orgs/some-org/teams/core":
+			fmt.Fprint(w, `{"id": 1, "name": "Core", "slug": "core"}`)
+		case "GET /repos/some-org/some-repo/teams":
+			fmt.Fprint(w, `[{"id": 1, "slug": "core", "permission": "push"}, {"id": 2, "slug": "docs", "permission": "pull"}]`)
+		case "PUT /orgs/some-org/teams/docs/repos/some-org/some-repo":
+			body, _ := io.ReadAll(r.Body)
+			assert.JSONEq(t, `{"permission": "push"}`, string(body))
+			w.WriteHeader(http.StatusNoContent)
+		case "GET /repos/some-org/some-repo/collaborators/some-user":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"message": "Not Found"}`)
+		}
+	}))
+	client := NewClient("github.com", restClient, nil, nil)
+
+	team, err := client.GetTeam("some-org", "core")
+	assert.NoError(t, err)
+	assert.Equal(t, &Team{ID: 1, Name: "Core", Slug: "core"}, team)
+
+	team, err = client.GetTeam("some-org", "missing")
+	assert.NoError(t, err)
+	assert.Nil(t, team)
+
+	teams, err := client.ListRepoTeams("some-org/some-repo")
+	assert.NoError(t, err)
+	assert.Equal(t, []*Team{
+		{ID: 1, Slug: "core", Permission: "push"},
+		{ID: 2, Slug: "docs", Permission: "pull"},
+	}, teams)
+	assert.True(t, teams[0].CanWrite())
+	assert.False(t, teams[1].CanWrite())
+
+	err = client.SetTeamPermission("some-org", "docs", "some-org/some-repo", "push")
+	assert.NoError(t, err)
+
+	ok, err := client.IsCollaborator("some-org/some-repo", "some-user")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = client.IsCollaborator("some-org/some-repo", "other-user")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	assert.Len(t, *requests, 6)
+}
+
+func TestClient_SecurityFeatures(t *testing.T) {
+	restClient, _ := newFakeHost(t, "github.com", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "GET /repos/some-org/on/vulnerability-alerts":
+			w.WriteHeader(http.StatusNoContent)
+		case "GET /repos/some-org/off/vulnerability-alerts":
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"message": "Vulnerability alerts are disabled."}`)
+		case "PUT /repos/some-org/off/vulnerability-alerts",
+			"PUT /repos/some-org/off/automated-security-fixes",
+			"PUT /repos/some-org/off/private-vulnerability-reporting":
+			w.WriteHeader(http.StatusNoContent)
+		case "GET /repos/some-org/on/automated-security-fixes":
+			fmt.Fprint(w, `{"enabled": true, "paused": false}`)
+		case "GET /repos/some-org/on/private-vulnerability-reporting":
+			fmt.Fprint(w, `{"enabled": true}`)
+		case "GET /repos/some-org/on/code-scanning/default-setup":
+			fmt.Fprint(w, `{"state": "configured", "languages": ["go"]}`)
+		case "GET /repos/some-org/off/code-scanning/default-setup":
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"message": "Code Security must be enabled for this repository to use code scanning."}`)
+		case "PATCH /repos/some-org/off/code-scanning/default-setup":
+			body, _ := io.ReadAll(r.Body)
+			assert.JSONEq(t, `{"state": "configured"}`, string(body))
+			w.WriteHeader(http.StatusAccepted)
+			fmt.Fprint(w, `{"run_id": 42}`)
+		case "PATCH /repos/some-org/off":
+			body, _ := io.ReadAll(r.Body)
+			assert.JSONEq(t, `{"security_and_analysis": {"secret_scanning": {"status": "enabled"}}}`, string(body))
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			fmt.Fprint(w, `{"message": "Secret scanning is not available for this repository."}`)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	client := NewClient("github.com", restClient, nil, nil)
+
+	ok, err := client.VulnerabilityAlertsEnabled("some-org/on")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = client.VulnerabilityAlertsEnabled("some-org/off")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	assert.NoError(t, client.EnableVulnerabilityAlerts("some-org/off"))
+
+	ok, err = client.AutomatedSecurityFixesEnabled("some-org/on")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.NoError(t, client.EnableAutomatedSecurityFixes("some-org/off"))
+
+	ok, err = client.PrivateVulnerabilityReportingEnabled("some-org/on")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.NoError(t, client.EnablePrivateVulnerabilityReporting("some-org/off"))
+
+	ok, err = client.CodeScanningDefaultSetupEnabled("some-org/on")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	_, err = client.CodeScanningDefaultSetupEnabled("some-org/off")
+	unavailable := &UnavailableError{}
+	assert.ErrorAs(t, err, &unavailable)
+	assert.Equal(t, "Code Security must be enabled for this repository to use code scanning.", unavailable.Reason)
+	assert.NoError(t, client.EnableCodeScanningDefaultSetup("some-org/off"))
+
+	err = client.UpdateSecurityAndAnalysis("some-org/off", &SecurityAndAnalysis{
+		SecretScanning: &SecurityFeature{Status: StatusEnabled},
+	})
+	assert.ErrorAs(t, err, &unavailable)
+	assert.Equal(t, "Secret scanning is not available for this repository.", unavailable.Reason)
+}
+
+func TestClient_ActionsPermissions(t *testing.T) {
+	restClient, _ := newFakeHost(t, "github.com", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		body, _ := io.ReadAll(r.Body)
+		switch r.Method + " " + r.URL.Path {
+		case "GET /repos/some-org/some-repo/actions/permissions":
+			fmt.Fprint(w, `{"enabled": true, "allowed_actions": "all", "selected_actions_url": "..."}`)
+		case "PUT /repos/some-org/some-repo/actions/permissions":
+			assert.JSONEq(t, `{"enabled": true, "allowed_actions": "selected"}`, string(body))
+			w.WriteHeader(http.StatusNoContent)
+		case "GET /repos/some-org/some-repo/actions/permissions/selected-actions":
+			fmt.Fprint(w, `{"github_owned_allowed": true, "verified_allowed": false, "patterns_allowed": ["docker/*"]}`)
+		case "PUT /repos/some-org/some-repo/actions/permissions/selected-actions":
+			assert.JSONEq(t, `{"github_owned_allowed": true, "verified_allowed": false, "patterns_allowed": []}`, string(body))
+			w.WriteHeader(http.StatusNoContent)
+		case "GET /repos/some-org/some-repo/actions/permissions/workflow":
+			fmt.Fprint(w, `{"default_workflow_permissions": "write", "can_approve_pull_request_reviews": true}`)
+		case "PUT /repos/some-org/some-repo/actions/permissions/workflow":
+			assert.JSONEq(t, `{"default_workflow_permissions": "read", "can_approve_pull_request_reviews": false}`, string(body))
+			w.WriteHeader(http.StatusNoContent)
+		case "GET /repos/some-org/other-repo/actions/permissions":
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"message": "Not Found"}`)
+		case "PUT /repos/some-org/other-repo/actions/permissions/workflow":
+			w.WriteHeader(http.StatusConflict)
+			fmt.Fprint(w, `{"message": "The organization's policy does not allow this setting."}`)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	client := NewClient("github.com", restClient, nil, nil)
+
+	permissions, err := client.GetActionsPermissions("some-org/some-repo")
+	assert.NoError(t, err)
+	assert.Equal(t, &ActionsPermissions{Enabled: true, AllowedActions: AllowedActionsAll}, permissions)
+	assert.NoError(t, client.SetActionsPermissions("some-org/some-repo", &ActionsPermissions{
+		Enabled:        true,
+		AllowedActions: AllowedActionsSelected,
+	}))
+
+	actions, err := client.GetSelectedActions("some-org/some-repo")
+	assert.NoError(t, err)
+	assert.Equal(t, &SelectedActions{GithubOwnedAllowed: true, PatternsAllowed: []string{"docker/*"}}, actions)
+	assert.NoError(t, client.SetSelectedActions("some-org/some-repo", &SelectedActions{
+		GithubOwnedAllowed: true,
+		PatternsAllowed:    []string{},
+	}))
+
+	workflow, err := client.GetWorkflowPermissions("some-org/some-repo")
+	assert.NoError(t, err)
+	assert.Equal(t, &WorkflowPermissions{
+		DefaultWorkflowPermissions:   WorkflowPermissionsWrite,
+		CanApprovePullRequestReviews: true,
+	}, workflow)
+	assert.NoError(t, client.SetWorkflowPermissions("some-org/some-repo", &WorkflowPermissions{
+		DefaultWorkflowPermissions: WorkflowPermissionsRead,
+	}))
+
+	unavailable := &UnavailableError{}
+	_, err = client.GetActionsPermissions("some-org/other-repo")
+	assert.ErrorAs(t, err, &unavailable)
+	err = client.SetWorkflowPermissions("some-org/other-repo", &WorkflowPermissions{
+		DefaultWorkflowPermissions: WorkflowPermissionsRead,
+	})
+	assert.ErrorAs(t, err, &unavailable)
+	assert.Equal(t, "The organization's policy does not allow this setting.", unavailable.Reason)
+}
@@ -0,0 +1,155 @@
+package gh
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/cli/go-gh/pkg/api"
+)
+
+var (
+	orgReposPathRE = regexp.MustCompile(`^(?:/api/v3)?/orgs/([^/]+)/repos$`)
+	ssoURLRE       = regexp.MustCompile(`url=(\S+)`)
+)
+
+// APIError is a GitHub API error with an actionable message.
+type APIError struct {
+	// What went wrong.
+	Message string
+	// How to fix it (optional).
+	Hint string
+	// The underlying error.
+	Err error
+}
+
+func (e *APIError) Error() string {
+	return e.Message
+}
+
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
+
+// TranslateError converts common GitHub API failures into an [APIError]
+// with a friendlier message and a suggested fix.
+// All other errors are returned unchanged.
+func TranslateError(err error) error {
+	httpErr := &api.HTTPError{}
+	if !errors.As(err, httpErr) {
+		return err
+	}
+	switch httpErr.StatusCode {
+	case http.StatusUnauthorized:
+		return &APIError{
+			Message: "The GitHub token is invalid or has expired.",
+			Hint:    fmt.Sprintf("To re-authenticate, run: gh auth login -h %s", hostForURL(httpErr)),
+			Err:     err,
+		}
+	case http.StatusForbidden:
+		if sso := httpErr.Headers.Get("X-GitHub-SSO"); sso != "" {
+			hint := "Authorize the token for SAML single sign-on in your GitHub settings."
+			if m := ssoURLRE.FindStringSubmatch(sso); m != nil {
+				hint = fmt.Sprintf("To authorize the token, visit: %s", m[1])
+			}
+			return &APIError{
+				Message: "The organization requires SAML single sign-on authorization for this token.",
+				Hint:    hint,
+				Err:     err,
+			}
+		}
+		if scope := missingScope(httpErr); scope != "" {
+			return missingScopeError(err, httpErr, scope)
+		}
+	case http.StatusNotFound:
+		if m := orgReposPathRE.FindStringSubmatch(requestPath(httpErr)); m != nil {
+			return &APIError{
+				Message: fmt.Sprintf("Unable to create repos in the '%s' org.", m[1]),
+				Hint:    "Make sure you are a member of the org with permission to create repos.",
+				Err:     err,
+			}
+		}
+		// GitHub responds with a 404 (rather than a 403) for some endpoints.
+		if scope := missingScope(httpErr); scope != "" {
+			return missingScopeError(err, httpErr, scope)
+		}
+	case http.StatusUnprocessableEntity:
+		if hasErrorMessage(httpErr, "name already exists on this account") {
+			return &APIError{
+				Message: "A repo with that name already exists on this account.",
+				Hint:    "Choose a different name, or add the existing repo as a remote.",
+				Err:     err,
+			}
+		}
+	}
+	return err
+}
+
+// missingScope returns the narrowest OAuth scope accepted by the endpoint
+// if the token was granted none of them (or an empty string if it was).
+func missingScope(err *api.HTTPError) string {
+	granted := ParseScopes(err.Headers)
+	accepted := ParseScopes(http.Header{"X-Oauth-Scopes": err.Headers.Values("X-Accepted-Oauth-Scopes")})
+	if granted == nil || len(accepted) == 0 {
+		return "" // not an OAuth token, or no scopes needed
+	}
+	if len(MissingScopes(granted, accepted)) < len(accepted) {
+		return "" // one of the accepted scopes was granted
+	}
+	// Prefer a scope that doesn't imply any of the others (user:email over user).
+	for _, scope := range accepted {
+		if !impliesAny(scope, accepted) {
+			return scope
+		}
+	}
+	return accepted[0]
+}
+
+// impliesAny returns true if scope implies any of scopes.
+func impliesAny(scope string, scopes []string) bool {
+	for _, implied := range impliedScopes[scope] {
+		for _, s := range scopes {
+			if implied == s {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func missingScopeError(err error, httpErr *api.HTTPError, scope string) *APIError {
+	return &APIError{
+		Message: fmt.Sprintf("The GitHub token is missing the '%s' scope.", scope),
+		Hint:    fmt.Sprintf("To add it, run: gh auth refresh -h %s -s %s", hostForURL(httpErr), scope),
+		Err:     err,
+	}
+}
+
+func hasErrorMessage(err *api.HTTPError, msg string) bool {
+	if strings.Contains(err.Message, msg) {
+		return true
+	}
+	for _, item := range err.Errors {
+		if strings.Contains(item.Message, msg) {
+			return true
+		}
+	}
+	return false
+}
+
+func requestPath(err *api.HTTPError) string {
+	if err.RequestURL == nil {
+		return ""
+	}
+	return err.RequestURL.Path
+}
+
+// hostForURL returns the GitHub host for the API request URL.
+func hostForURL(err *api.HTTPError) string {
+	if err.RequestURL == nil {
+		return "github.com"
+	}
+	return strings.TrimPrefix(err.RequestURL.Hostname(), "api.")
+}
@@ -0,0 +1,169 @@
+package gh
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/cli/go-gh/pkg/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTranslateError(t *testing.T) {
+	mustParse := func(s string) *url.URL {
+		u, _ := url.Parse(s)
+		return u
+	}
+
+	tests := []struct {
+		desc    string
+		err     error
+		message string
+		hint    string
+	}{
+		{
+			desc: "translates 401 errors",
+			err: api.HTTPError{
+				StatusCode: 401,
+				Message:    "Bad credentials",
+				RequestURL: mustParse("https://ghe.example.com/api/v3/user"),
+			},
+			message: "The GitHub token is invalid or has expired.",
+			hint:    "To re-authenticate, run: gh auth login -h ghe.example.com",
+		},
+		{
+			desc: "translates 401 errors from api.github.com",
+			err: api.HTTPError{
+				StatusCode: 401,
+				Message:    "Bad credentials",
+				RequestURL: mustParse("https://api.github.com/user"),
+			},
+			message: "The GitHub token is invalid or has expired.",
+			hint:    "To re-authenticate, run: gh auth login -h github.com",
+		},
+		{
+			desc: "translates 403 SAML SSO errors",
+			err: api.HTTPError{
+				StatusCode: 403,
+				Message:    "Resource protected by organization SAML enforcement.",
+				Headers: http.Header{
+					"X-Github-Sso": []string{
+						"required; url=https://github.com/orgs/some-org/sso?authorization_request=abc123",
+					},
+				},
+			},
+			message: "The organization requires SAML single sign-on authorization for this token.",
+			hint:    "To authorize the token, visit: https://github.com/orgs/some-org/sso?authorization_request=abc123",
+		},
+		{
+			desc: "translates 403 SAML SSO errors without an authorization URL",
+			err: api.HTTPError{
+				StatusCode: 403,
+				Headers: http.Header{
+					"X-Github-Sso": []string{"partial-results; organizations=21955855"},
+				},
+			},
+			message: "The organization requires SAML single sign-on authorization for this token.",
+			hint:    "Authorize the token for SAML single sign-on in your GitHub settings.",
+		},
+		{
+			desc: "translates 404 errors for endpoints needing a missing scope",
+			err: api.HTTPError{
+				StatusCode: 404,
+				Message:    "Not Found",
+				RequestURL: mustParse("https://api.github.com/user/emails"),
+				Headers: http.Header{
+					"X-Oauth-Scopes":          []string{"repo, read:org"},
+					"X-Accepted-Oauth-Scopes": []string{"user, user:email"},
+				},
+			},
+			message: "The GitHub token is missing the 'user:email' scope.",
+			hint:    "To add it, run: gh auth refresh -h github.com -s user:email",
+		},
+		{
+			desc: "translates 403 errors for endpoints needing a missing scope",
+			err: api.HTTPError{
+				StatusCode: 403,
+				RequestURL: mustParse("https://api.github.com/user/gpg_keys"),
+				Headers: http.Header{
+					"X-Oauth-Scopes":          []string{"repo"},
+					"X-Accepted-Oauth-Scopes": []string{"admin:gpg_key, read:gpg_key, write:gpg_key"},
+				},
+			},
+			message: "The GitHub token is missing the 'read:gpg_key' scope.",
+			hint:    "To add it, run: gh auth refresh -h github.com -s read:gpg_key",
+		},
+		{
+			desc: "translates 404 errors when creating org repos",
+			err: api.HTTPError{
+				StatusCode: 404,
+				Message:    "Not Found",
+				RequestURL: mustParse("https://api.github.com/orgs/some-org/repos"),
+			},
+			message: "Unable to create repos in the 'some-org' org.",
+			hint:    "Make sure you are a member of the org with permission to create repos.",
+		},
+		{
+			desc: "translates 422 errors for existing repo names",
+			err: api.HTTPError{
+				StatusCode: 422,
+				Message:    "Repository creation failed.",
+				Errors: []api.HTTPErrorItem{
+					{
+						Resource: "Repository",
+						Code:     "custom",
+						Field:    "name",
+						Message:  "name already exists on this account",
+					},
+				},
+			},
+			message: "A repo with that name already exists on this account.",
+			hint:    "Choose a different name, or add the existing repo as a remote.",
+		},
+		{
+			desc: "returns other http errors unchanged",
+			err: api.HTTPError{
+				StatusCode: 404,
+				Message:    "Not Found",
+				RequestURL: mustParse("https://api.github.com/repos/some-org/some-repo"),
+			},
+			message: "HTTP 404: Not Found (https://api.github.com/repos/some-org/some-repo)",
+		},
+		{
+			desc: "returns 404 errors unchanged when an accepted scope was granted",
+			err: api.HTTPError{
+				StatusCode: 404,
+				Message:    "Not Found",
+				RequestURL: mustParse("https://api.github.com/repos/some-org/some-repo"),
+				Headers: http.Header{
+					"X-Oauth-Scopes":          []string{"repo, read:org"},
+					"X-Accepted-Oauth-Scopes": []string{"repo"},
+				},
+			},
+			message: "HTTP 404: Not Found (https://api.github.com/repos/some-org/some-repo)",
+		},
+		{
+			desc:    "returns non-http errors unchanged",
+			err:     errors.New("reticulating splines"),
+			message: "reticulating splines",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := TranslateError(tt.err)
+			assert.EqualError(t, err, tt.message)
+
+			apiErr := &APIError{}
+			if tt.hint == "" {
+				assert.False(t, errors.As(err, &apiErr))
+				assert.Equal(t, tt.err, err)
+			} else {
+				assert.True(t, errors.As(err, &apiErr))
+				assert.Equal(t, tt.hint, apiErr.Hint)
+				// the original error is still available
+				assert.True(t, errors.As(err, &api.HTTPError{}))
+			}
+		})
+	}
+}
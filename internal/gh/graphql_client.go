@@ -0,0 +1,30 @@
+package gh
+
+import (
+	"github.com/cli/go-gh"
+	"github.com/cli/go-gh/pkg/api"
+)
+
+//go:generate moq -rm -out graphql_client_mock.go . GraphQLClient
+
+type GraphQLClient interface {
+	Do(query string, variables map[string]interface{}, response interface{}) error
+	Mutate(name string, mutation interface{}, variables map[string]interface{}) error
+	Query(name string, query interface{}, variables map[string]interface{}) error
+}
+
+var (
+	// for stubbing
+	newAPIGQLClient = gh.GQLClient
+)
+
+// NewGraphQLClient returns a GraphQL client for host that authenticates with token.
+// If host is empty, then the gh default host is used
+// (either $GH_HOST or the host gh is authenticated with).
+// If token is empty, then the gh token for host is used.
+func NewGraphQLClient(host string, token string) (GraphQLClient, error) { //nolint: ireturn
+	return newAPIGQLClient(&api.ClientOptions{
+		Host:      host,
+		AuthToken: token,
+	})
+}
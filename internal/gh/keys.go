@@ -0,0 +1,81 @@
+package gh
+
+import (
+	"strings"
+)
+
+// Email is an email address of the current user.
+type Email struct {
+	Email    string `json:"email"`
+	Primary  bool   `json:"primary"`
+	Verified bool   `json:"verified"`
+}
+
+// GPGKey is a GPG key registered by the current user.
+type GPGKey struct {
+	ID      int       `json:"id"`
+	KeyID   string    `json:"key_id"`
+	Emails  []*Email  `json:"emails"`
+	Subkeys []*GPGKey `json:"subkeys"`
+}
+
+// HasKeyID returns true if id identifies the key or one of its subkeys.
+// The id may be a short or long key ID or a fingerprint (as in user.signingkey).
+func (k *GPGKey) HasKeyID(id string) bool {
+	id = strings.ToUpper(strings.TrimSuffix(strings.TrimPrefix(id, "0x"), "!"))
+	if len(id) < 8 {
+		return false
+	}
+	keyID := strings.ToUpper(k.KeyID)
+	// Key IDs are the low order bits of the fingerprint.
+	if keyID != "" && (strings.HasSuffix(id, keyID) || strings.HasSuffix(keyID, id)) {
+		return true
+	}
+	for _, subkey := range k.Subkeys {
+		if subkey.HasKeyID(id) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasEmail returns true if email is one of the key's verified emails.
+func (k *GPGKey) HasEmail(email string) bool {
+	for _, e := range k.Emails {
+		if e.Verified && strings.EqualFold(e.Email, email) {
+			return true
+		}
+	}
+	return false
+}
+
+// SSHKey is an SSH authentication key registered by the current user.
+type SSHKey struct {
+	ID    int    `json:"id"`
+	Title string `json:"title"`
+	Key   string `json:"key"`
+}
+
+// SSHKeyRequest is the request body for adding an SSH key.
+type SSHKeyRequest struct {
+	Title string `json:"title"`
+	Key   string `json:"key"`
+}
+
+// SSHSigningKey is an SSH key registered by the current user for signing commits.
+type SSHSigningKey struct {
+	ID    int    `json:"id"`
+	Title string `json:"title"`
+	Key   string `json:"key"`
+}
+
+// SameSSHKey returns true if a and b are the same public key,
+// ignoring any trailing comment (e.g. "ssh-ed25519 AAAA... user@host").
+func SameSSHKey(a string, b string) bool {
+	fa := strings.Fields(a)
+	fb := strings.Fields(b)
+	if len(fa) < 2 || len(fb) < 2 {
+		return false
+	}
+	return fa[0] == fb[0] && fa[1] == fb[1]
+}
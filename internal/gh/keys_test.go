@@ -0,0 +1,53 @@
+package gh
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGPGKey_HasKeyID(t *testing.T) {
+	key := &GPGKey{
+		KeyID: "3262EFF25BA0D270",
+		Subkeys: []*GPGKey{
+			{KeyID: "4A595D4C72EE49C7"},
+		},
+	}
+	tests := []struct {
+		desc     string
+		id       string
+		expected bool
+	}{
+		{desc: "matches long key IDs", id: "3262EFF25BA0D270", expected: true},
+		{desc: "matches short key IDs", id: "5BA0D270", expected: true},
+		{desc: "matches fingerprints", id: "0x9D8A41C2E1C1FA3C5E6B31D73262EFF25BA0D270", expected: true},
+		{desc: "matches case insensitively", id: "3262eff25ba0d270", expected: true},
+		{desc: "matches subkeys", id: "4A595D4C72EE49C7!", expected: true},
+		{desc: "does not match other keys", id: "AAAAAAAABBBBBBBB", expected: false},
+		{desc: "does not match overly short IDs", id: "D270", expected: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.expected, key.HasKeyID(tt.id))
+		})
+	}
+}
+
+func TestGPGKey_HasEmail(t *testing.T) {
+	key := &GPGKey{
+		Emails: []*Email{
+			{Email: "test@example.com", Verified: true},
+			{Email: "other@example.com", Verified: false},
+		},
+	}
+	assert.True(t, key.HasEmail("Test@Example.com"))
+	assert.False(t, key.HasEmail("other@example.com"))
+	assert.False(t, key.HasEmail("unknown@example.com"))
+}
+
+func TestSameSSHKey(t *testing.T) {
+	assert.True(t, SameSSHKey("ssh-ed25519 AAAA user@host", "ssh-ed25519 AAAA"))
+	assert.False(t, SameSSHKey("ssh-ed25519 AAAA", "ssh-ed25519 BBBB"))
+	assert.False(t, SameSSHKey("ssh-rsa AAAA", "ssh-ed25519 AAAA"))
+	assert.False(t, SameSSHKey("", "ssh-ed25519 AAAA"))
+}
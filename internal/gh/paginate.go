@@ -0,0 +1,42 @@
+package gh
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+)
+
+var (
+	linkRE = regexp.MustCompile(`<([^>]+)>;\s*rel="([^"]+)"`)
+)
+
+// Paginate makes GET requests for path and returns the combined items
+// from every page, following the `next` URL in the `Link` response header.
+func Paginate[T any](client RESTClient, path string) ([]T, error) {
+	items := []T{}
+	for path != "" {
+		resp, err := client.Request(http.MethodGet, path, nil)
+		if err != nil {
+			return nil, err
+		}
+		page := []T{}
+		err = json.NewDecoder(resp.Body).Decode(&page)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, page...)
+		path = nextPage(resp)
+	}
+	return items, nil
+}
+
+// nextPage returns the URL of the next page (or an empty string if none).
+func nextPage(resp *http.Response) string {
+	for _, m := range linkRE.FindAllStringSubmatch(resp.Header.Get("Link"), -1) {
+		if len(m) > 2 && m[2] == "next" {
+			return m[1]
+		}
+	}
+	return ""
+}
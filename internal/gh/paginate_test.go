@@ -0,0 +1,130 @@
+package gh
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaginate(t *testing.T) {
+	tests := []struct {
+		desc       string
+		restClient *RESTClientMock
+		expected   []*Account
+		err        string
+	}{
+		{
+			desc: "returns the items from a single page",
+			restClient: &RESTClientMock{
+				RequestFunc: func(method, path string, body io.Reader) (*http.Response, error) {
+					if path == "user/orgs" {
+						return newJSONResponse(`[{"login": "org1"}]`, ""), nil
+					}
+					return nil, errors.New("unexpected path: " + path)
+				},
+			},
+			expected: []*Account{
+				{Login: "org1"},
+			},
+		},
+
+		{
+			desc: "follows next links and combines the items from every page",
+			restClient: &RESTClientMock{
+				RequestFunc: func(method, path string, body io.Reader) (*http.Response, error) {
+					switch path {
+					case "user/orgs":
+						return newJSONResponse(
+							`[{"login": "org1"}, {"login": "org2"}]`,
+							`<https://api.github.com/user/orgs?page=2>; rel="next", `+
+								`<https://api.github.com/user/orgs?page=3>; rel="last"`,
+						), nil
+					case "https://api.github.com/user/orgs?page=2":
+						return newJSONResponse(
+							`[{"login": "org3"}]`,
+							`<https://api.github.com/user/orgs?page=3>; rel="next", `+
+								`<https://api.github.com/user/orgs?page=1>; rel="first"`,
+						), nil
+					case "https://api.github.com/user/orgs?page=3":
+						return newJSONResponse(
+							`[{"login": "org4"}]`,
+							`<https://api.github.com/user/orgs?page=1>; rel="first"`,
+						), nil
+					}
+					return nil, errors.New("unexpected path: " + path)
+				},
+			},
+			expected: []*Account{
+				{Login: "org1"},
+				{Login: "org2"},
+				{Login: "org3"},
+				{Login: "org4"},
+			},
+		},
+
+		{
+			desc: "returns an empty slice when there are no items",
+			restClient: &RESTClientMock{
+				RequestFunc: func(method, path string, body io.Reader) (*http.Response, error) {
+					return newJSONResponse(`[]`, ""), nil
+				},
+			},
+			expected: []*Account{},
+		},
+
+		{
+			desc: "returns rest api errors",
+			restClient: &RESTClientMock{
+				RequestFunc: func(method, path string, body io.Reader) (*http.Response, error) {
+					return nil, errors.New("reticulating splines")
+				},
+			},
+			expected: nil,
+			err:      "reticulating splines",
+		},
+
+		{
+			desc: "returns decoding errors",
+			restClient: &RESTClientMock{
+				RequestFunc: func(method, path string, body io.Reader) (*http.Response, error) {
+					return newJSONResponse(`{"message": "not a list"}`, ""), nil
+				},
+			},
+			expected: nil,
+			err:      "cannot unmarshal",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			actual, err := Paginate[*Account](tt.restClient, "user/orgs")
+
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+
+			assert.Equal(t, tt.expected, actual)
+			for _, call := range tt.restClient.RequestCalls() {
+				assert.Equal(t, http.MethodGet, call.Method)
+			}
+		})
+	}
+}
+
+func newJSONResponse(body string, link string) *http.Response {
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	if link != "" {
+		header.Set("Link", link)
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     header,
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
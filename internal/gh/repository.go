@@ -1,6 +1,12 @@
 package gh
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
 
 // Protocol is an enum representing the git URL protocol.
 type Protocol string
@@ -12,6 +18,46 @@ const (
 	ProtocolUnknown Protocol = ""
 )
 
+var (
+	// scp-like SSH URLs (git@github.com:owner/name.git).
+	scpURLRE = regexp.MustCompile(`^(?:[^@/]+@)?([^:/]+):(.+)$`)
+)
+
+// URLProtocol returns the protocol of a git remote URL.
+func URLProtocol(remoteURL string) Protocol {
+	switch {
+	case strings.HasPrefix(remoteURL, "https://"), strings.HasPrefix(remoteURL, "http://"):
+		return ProtocolHTTPS
+	case strings.HasPrefix(remoteURL, "git://"):
+		return ProtocolGit
+	case strings.HasPrefix(remoteURL, "ssh://"), scpURLRE.MatchString(remoteURL):
+		return ProtocolSSH
+	default:
+		return ProtocolUnknown
+	}
+}
+
+// ParseRemoteURL returns the host and full name ("owner/name")
+// of the repo at a git remote URL in any of the supported protocols.
+func ParseRemoteURL(remoteURL string) (string, string, error) {
+	var host, path string
+	if m := scpURLRE.FindStringSubmatch(remoteURL); m != nil && !strings.Contains(remoteURL, "://") {
+		host, path = m[1], m[2]
+	} else {
+		u, err := url.Parse(remoteURL)
+		if err != nil {
+			return "", "", fmt.Errorf("invalid remote URL: %w", err)
+		}
+		host, path = u.Hostname(), u.Path
+	}
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	parts := strings.Split(path, "/")
+	if host == "" || len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("not a GitHub repo URL: %s", remoteURL)
+	}
+	return strings.ToLower(host), path, nil
+}
+
 // Visibility is an enum representing a repo' visibility.
 type Visibility string
 
@@ -36,29 +82,54 @@ var (
 
 // Repository is a GitHub repo.
 type Repository struct {
-	Name       string     `json:"name"`
-	FullName   string     `json:"full_name"`
-	Owner      *Account   `json:"owner"`
-	Visibility Visibility `json:"visibility"`
-	URL        string     `json:"html_url"`
-	CloneURL   string     `json:"clone_url"`
-	SSHURL     string     `json:"ssh_url"`
-	GitURL     string     `json:"git_url"`
+	Name          string      `json:"name"`
+	FullName      string      `json:"full_name"`
+	Owner         *Account    `json:"owner"`
+	Description   string      `json:"description"`
+	Visibility    Visibility  `json:"visibility"`
+	IsTemplate    bool        `json:"is_template"`
+	Archived      bool        `json:"archived"`
+	Fork          bool        `json:"fork"`
+	Parent        *Repository `json:"parent"`
+	DefaultBranch string      `json:"default_branch"`
+	URL           string      `json:"html_url"`
+	CloneURL      string      `json:"clone_url"`
+	SSHURL        string      `json:"ssh_url"`
+	GitURL        string      `json:"git_url"`
+	// Only visible to repo admins.
+	SecurityAndAnalysis *SecurityAndAnalysis `json:"security_and_analysis"`
+}
+
+// newRepository returns a repository for owner/name on host,
+// with the URLs populated the same way the GitHub API would.
+func newRepository(host string, owner string, name string) *Repository {
+	return &Repository{
+		Name:     name,
+		FullName: fmt.Sprintf("%s/%s", owner, name),
+		Owner: &Account{
+			Login: owner,
+		},
+		URL:      fmt.Sprintf("https://%s/%s/%s", host, owner, name),
+		CloneURL: fmt.Sprintf("https://%s/%s/%s.git", host, owner, name),
+		SSHURL:   fmt.Sprintf("git@%s:%s/%s.git", host, owner, name),
+		GitURL:   fmt.Sprintf("git://%s/%s/%s.git", host, owner, name),
+	}
 }
 
 // RemoteURL returns the URL for the given protocol.
 func (r *Repository) RemoteURL(protocol Protocol) string {
 	// attempt to return the preferred type (if present)
+	var url string
 	switch protocol {
 	case ProtocolGit:
-		return r.GitURL
-	case ProtocolHTTPS:
-		return r.CloneURL
+		url = r.GitURL
 	case ProtocolSSH:
-		return r.SSHURL
-	default:
-		return r.CloneURL
+		url = r.SSHURL
+	}
+	if url == "" {
+		url = r.CloneURL
 	}
+	return url
 }
 
 type RepositoryRequest struct {
@@ -66,3 +137,11 @@ type RepositoryRequest struct {
 	Private    bool       `json:"private"`
 	Visibility Visibility `json:"visibility"`
 }
+
+// ForkRequest is the request body for forking a repo.
+type ForkRequest struct {
+	// The org to fork into (defaults to the current user).
+	Organization string `json:"organization,omitempty"`
+	// The name of the fork (defaults to the name of the source repo).
+	Name string `json:"name,omitempty"`
+}
@@ -27,6 +27,26 @@ func TestRepository_RemoteURL(t *testing.T) {
 				"":             "https://github.com",
 			},
 		},
+		{
+			desc: "falls back to the clone URL when the preferred URL is missing",
+			repo: &Repository{
+				CloneURL: "https://ghe.example.com/owner/repo.git",
+			},
+			responses: map[Protocol]string{
+				ProtocolHTTPS: "https://ghe.example.com/owner/repo.git",
+				ProtocolGit:   "https://ghe.example.com/owner/repo.git",
+				ProtocolSSH:   "https://ghe.example.com/owner/repo.git",
+			},
+		},
+		{
+			desc: "returns host specific URLs for repos built from a remote",
+			repo: newRepository("ghe.example.com", "owner", "repo"),
+			responses: map[Protocol]string{
+				ProtocolHTTPS: "https://ghe.example.com/owner/repo.git",
+				ProtocolGit:   "git://ghe.example.com/owner/repo.git",
+				ProtocolSSH:   "git@ghe.example.com:owner/repo.git",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.desc, func(t *testing.T) {
@@ -37,3 +57,44 @@ func TestRepository_RemoteURL(t *testing.T) {
 		})
 	}
 }
+
+func TestParseRemoteURL(t *testing.T) {
+	tests := []struct {
+		url      string
+		host     string
+		fullName string
+		err      string
+	}{
+		{url: "https://github.com/owner/repo.git", host: "github.com", fullName: "owner/repo"},
+		{url: "https://github.com/owner/repo", host: "github.com", fullName: "owner/repo"},
+		{url: "https://user@GitHub.com/owner/repo/", host: "github.com", fullName: "owner/repo"},
+		{url: "git@github.com:owner/repo.git", host: "github.com", fullName: "owner/repo"},
+		{url: "git@ghe.example.com:owner/repo", host: "ghe.example.com", fullName: "owner/repo"},
+		{url: "ssh://git@github.com/owner/repo.git", host: "github.com", fullName: "owner/repo"},
+		{url: "ssh://git@github.com:22/owner/repo.git", host: "github.com", fullName: "owner/repo"},
+		{url: "git://github.com/owner/repo.git", host: "github.com", fullName: "owner/repo"},
+		{url: "https://git.example.com/group/subgroup/repo.git", err: "not a GitHub repo URL"},
+		{url: "/path/to/repo.git", err: "not a GitHub repo URL"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			host, fullName, err := ParseRemoteURL(tt.url)
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+			assert.Equal(t, tt.host, host)
+			assert.Equal(t, tt.fullName, fullName)
+		})
+	}
+}
+
+func TestURLProtocol(t *testing.T) {
+	assert.Equal(t, ProtocolHTTPS, URLProtocol("https://github.com/owner/repo.git"))
+	assert.Equal(t, ProtocolHTTPS, URLProtocol("http://ghe.example.com/owner/repo.git"))
+	assert.Equal(t, ProtocolSSH, URLProtocol("git@github.com:owner/repo.git"))
+	assert.Equal(t, ProtocolSSH, URLProtocol("ssh://git@github.com/owner/repo.git"))
+	assert.Equal(t, ProtocolGit, URLProtocol("git://github.com/owner/repo.git"))
+	assert.Equal(t, ProtocolUnknown, URLProtocol("/path/to/repo.git"))
+}
@@ -1,9 +1,12 @@
 package gh
 
 import (
+	"context"
 	"io"
+	"net/http"
 
 	"github.com/cli/go-gh"
+	"github.com/cli/go-gh/pkg/api"
 )
 
 //go:generate moq -rm -out rest_client_mock.go . RESTClient
@@ -14,8 +17,36 @@ type RESTClient interface {
 	Patch(path string, body io.Reader, response interface{}) error
 	Post(path string, body io.Reader, response interface{}) error
 	Put(path string, body io.Reader, response interface{}) error
+	Request(method string, path string, body io.Reader) (*http.Response, error)
+	DoWithContext(ctx context.Context, method string, path string, body io.Reader, response interface{}) error
+	RequestWithContext(ctx context.Context, method string, path string, body io.Reader) (*http.Response, error)
 }
 
-func NewRESTClient() (RESTClient, error) { //nolint: ireturn
-	return gh.RESTClient(nil)
+var (
+	// for stubbing
+	newAPIRESTClient = gh.RESTClient
+)
+
+// NewRESTClient returns a REST client for host that authenticates with token.
+// If host is empty, then the gh default host is used
+// (either $GH_HOST or the host gh is authenticated with).
+// If token is empty, then the gh token for host is used.
+func NewRESTClient(host string, token string) (RESTClient, error) { //nolint: ireturn
+	return newAPIRESTClient(&api.ClientOptions{
+		Host:      host,
+		AuthToken: token,
+	})
+}
+
+// NewAppRESTClient returns a REST client for host that authenticates
+// as a GitHub App using jwt (see [NewAppJWT]).
+// Only the `/app` endpoints can be called with this client.
+func NewAppRESTClient(host string, jwt string) (RESTClient, error) { //nolint: ireturn
+	return newAPIRESTClient(&api.ClientOptions{
+		Host:      host,
+		AuthToken: jwt,
+		Headers: map[string]string{
+			"Authorization": "Bearer " + jwt,
+		},
+	})
 }
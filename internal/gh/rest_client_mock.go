@@ -4,7 +4,9 @@
 package gh
 
 import (
+	"context"
 	"io"
+	"net/http"
 	"sync"
 )
 
@@ -21,6 +23,9 @@ var _ RESTClient = &RESTClientMock{}
 //			DeleteFunc: func(path string, response interface{}) error {
 //				panic("mock out the Delete method")
 //			},
+//			DoWithContextFunc: func(ctx context.Context, method string, path string, body io.Reader, response interface{}) error {
+//				panic("mock out the DoWithContext method")
+//			},
 //			GetFunc: func(path string, response interface{}) error {
 //				panic("mock out the Get method")
 //			},
@@ -33,6 +38,12 @@ var _ RESTClient = &RESTClientMock{}
 //			PutFunc: func(path string, body io.Reader, response interface{}) error {
 //				panic("mock out the Put method")
 //			},
+//			RequestFunc: func(method string, path string, body io.Reader) (*http.Response, error) {
+//				panic("mock out the Request method")
+//			},
+//			RequestWithContextFunc: func(ctx context.Context, method string, path string, body io.Reader) (*http.Response, error) {
+//				panic("mock out the RequestWithContext method")
+//			},
 //		}
 //
 //		// use mockedRESTClient in code that requires RESTClient
@@ -43,6 +54,9 @@ type RESTClientMock struct {
 	// DeleteFunc mocks the Delete method.
 	DeleteFunc func(path string, response interface{}) error
 
+	// DoWithContextFunc mocks the DoWithContext method.
+	DoWithContextFunc func(ctx context.Context, method string, path string, body io.Reader, response interface{}) error
+
 	// GetFunc mocks the Get method.
 	GetFunc func(path string, response interface{}) error
 
@@ -55,6 +69,12 @@ type RESTClientMock struct {
 	// PutFunc mocks the Put method.
 	PutFunc func(path string, body io.Reader, response interface{}) error
 
+	// RequestFunc mocks the Request method.
+	RequestFunc func(method string, path string, body io.Reader) (*http.Response, error)
+
+	// RequestWithContextFunc mocks the RequestWithContext method.
+	RequestWithContextFunc func(ctx context.Context, method string, path string, body io.Reader) (*http.Response, error)
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// Delete holds details about calls to the Delete method.
@@ -64,6 +84,19 @@ type RESTClientMock struct {
 			// Response is the response argument value.
 			Response interface{}
 		}
+		// DoWithContext holds details about calls to the DoWithContext method.
+		DoWithContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Method is the method argument value.
+			Method string
+			// Path is the path argument value.
+			Path string
+			// Body is the body argument value.
+			Body io.Reader
+			// Response is the response argument value.
+			Response interface{}
+		}
 		// Get holds details about calls to the Get method.
 		Get []struct {
 			// Path is the path argument value.
@@ -98,12 +131,35 @@ type RESTClientMock struct {
 			// Response is the response argument value.
 			Response interface{}
 		}
+		// Request holds details about calls to the Request method.
+		Request []struct {
+			// Method is the method argument value.
+			Method string
+			// Path is the path argument value.
+			Path string
+			// Body is the body argument value.
+			Body io.Reader
+		}
+		// RequestWithContext holds details about calls to the RequestWithContext method.
+		RequestWithContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Method is the method argument value.
+			Method string
+			// Path is the path argument value.
+			Path string
+			// Body is the body argument value.
+			Body io.Reader
+		}
 	}
-	lockDelete sync.RWMutex
-	lockGet    sync.RWMutex
-	lockPatch  sync.RWMutex
-	lockPost   sync.RWMutex
-	lockPut    sync.RWMutex
+	lockDelete             sync.RWMutex
+	lockDoWithContext      sync.RWMutex
+	lockGet                sync.RWMutex
+	lockPatch              sync.RWMutex
+	lockPost               sync.RWMutex
+	lockPut                sync.RWMutex
+	lockRequest            sync.RWMutex
+	lockRequestWithContext sync.RWMutex
 }
 
 // Delete calls DeleteFunc.
@@ -142,6 +198,54 @@ func (mock *RESTClientMock) DeleteCalls() []struct {
 	return calls
 }
 
+// DoWithContext calls DoWithContextFunc.
+func (mock *RESTClientMock) DoWithContext(ctx context.Context, method string, path string, body io.Reader, response interface{}) error {
+	if mock.DoWithContextFunc == nil {
+		panic("RESTClientMock.DoWithContextFunc: method is nil but RESTClient.DoWithContext was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Method   string
+		Path     string
+		Body     io.Reader
+		Response interface{}
+	}{
+		Ctx:      ctx,
+		Method:   method,
+		Path:     path,
+		Body:     body,
+		Response: response,
+	}
+	mock.lockDoWithContext.Lock()
+	mock.calls.DoWithContext = append(mock.calls.DoWithContext, callInfo)
+	mock.lockDoWithContext.Unlock()
+	return mock.DoWithContextFunc(ctx, method, path, body, response)
+}
+
+// DoWithContextCalls gets all the calls that were made to DoWithContext.
+// Check the length with:
+//
+//	len(mockedRESTClient.DoWithContextCalls())
+func (mock *RESTClientMock) DoWithContextCalls() []struct {
+	Ctx      context.Context
+	Method   string
+	Path     string
+	Body     io.Reader
+	Response interface{}
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Method   string
+		Path     string
+		Body     io.Reader
+		Response interface{}
+	}
+	mock.lockDoWithContext.RLock()
+	calls = mock.calls.DoWithContext
+	mock.lockDoWithContext.RUnlock()
+	return calls
+}
+
 // Get calls GetFunc.
 func (mock *RESTClientMock) Get(path string, response interface{}) error {
 	if mock.GetFunc == nil {
@@ -297,3 +401,87 @@ func (mock *RESTClientMock) PutCalls() []struct {
 	mock.lockPut.RUnlock()
 	return calls
 }
+
+// Request calls RequestFunc.
+func (mock *RESTClientMock) Request(method string, path string, body io.Reader) (*http.Response, error) {
+	if mock.RequestFunc == nil {
+		panic("RESTClientMock.RequestFunc: method is nil but RESTClient.Request was just called")
+	}
+	callInfo := struct {
+		Method string
+		Path   string
+		Body   io.Reader
+	}{
+		Method: method,
+		Path:   path,
+		Body:   body,
+	}
+	mock.lockRequest.Lock()
+	mock.calls.Request = append(mock.calls.Request, callInfo)
+	mock.lockRequest.Unlock()
+	return mock.RequestFunc(method, path, body)
+}
+
+// RequestCalls gets all the calls that were made to Request.
+// Check the length with:
+//
+//	len(mockedRESTClient.RequestCalls())
+func (mock *RESTClientMock) RequestCalls() []struct {
+	Method string
+	Path   string
+	Body   io.Reader
+} {
+	var calls []struct {
+		Method string
+		Path   string
+		Body   io.Reader
+	}
+	mock.lockRequest.RLock()
+	calls = mock.calls.Request
+	mock.lockRequest.RUnlock()
+	return calls
+}
+
+// RequestWithContext calls RequestWithContextFunc.
+func (mock *RESTClientMock) RequestWithContext(ctx context.Context, method string, path string, body io.Reader) (*http.Response, error) {
+	if mock.RequestWithContextFunc == nil {
+		panic("RESTClientMock.RequestWithContextFunc: method is nil but RESTClient.RequestWithContext was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Method string
+		Path   string
+		Body   io.Reader
+	}{
+		Ctx:    ctx,
+		Method: method,
+		Path:   path,
+		Body:   body,
+	}
+	mock.lockRequestWithContext.Lock()
+	mock.calls.RequestWithContext = append(mock.calls.RequestWithContext, callInfo)
+	mock.lockRequestWithContext.Unlock()
+	return mock.RequestWithContextFunc(ctx, method, path, body)
+}
+
+// RequestWithContextCalls gets all the calls that were made to RequestWithContext.
+// Check the length with:
+//
+//	len(mockedRESTClient.RequestWithContextCalls())
+func (mock *RESTClientMock) RequestWithContextCalls() []struct {
+	Ctx    context.Context
+	Method string
+	Path   string
+	Body   io.Reader
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Method string
+		Path   string
+		Body   io.Reader
+	}
+	mock.lockRequestWithContext.RLock()
+	calls = mock.calls.RequestWithContext
+	mock.lockRequestWithContext.RUnlock()
+	return calls
+}
@@ -0,0 +1,222 @@
+package gh
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cli/go-gh/pkg/api"
+)
+
+// RetryOptions configures a RetryClient.
+type RetryOptions struct {
+	// The maximum number of times to retry a request.
+	MaxRetries int
+	// The delay before the first backoff retry.
+	// Doubled (with jitter) for each subsequent retry.
+	BaseDelay time.Duration
+	// The maximum total time to wait between retries of a request.
+	// Requests that would need to wait longer fail immediately.
+	MaxWait time.Duration
+}
+
+// NewRetryClient returns a RESTClient that retries requests made with client
+// when they fail due to rate limits or server errors.
+// Requests (and the waits between them) are abandoned once ctx is done.
+func NewRetryClient(ctx context.Context, client RESTClient, opts RetryOptions) *RetryClient {
+	return &RetryClient{
+		ctx:    ctx,
+		client: client,
+		opts:   opts,
+		now:    time.Now,
+		sleep:  sleepContext,
+		jitter: rand.Int63n, //nolint: gosec
+	}
+}
+
+// RetryClient is a RESTClient decorator that retries rate limited requests.
+//
+// Rate limited responses are retried after the delay indicated by the
+// `Retry-After` or `X-RateLimit-Reset` headers. Secondary rate limit
+// (abuse) and server error responses without those headers are retried
+// using jittered exponential backoff. Server errors are not retried for
+// POST requests, since they may have already taken effect.
+type RetryClient struct {
+	ctx    context.Context //nolint: containedctx // the gh client methods don't take one
+	client RESTClient
+	opts   RetryOptions
+
+	// for stubbing
+	now    func() time.Time
+	sleep  func(ctx context.Context, d time.Duration) error
+	jitter func(int64) int64
+}
+
+var _ RESTClient = &RetryClient{}
+
+func (c *RetryClient) Delete(path string, response interface{}) error {
+	return c.DoWithContext(c.ctx, http.MethodDelete, path, nil, response)
+}
+
+func (c *RetryClient) Get(path string, response interface{}) error {
+	return c.DoWithContext(c.ctx, http.MethodGet, path, nil, response)
+}
+
+func (c *RetryClient) Patch(path string, body io.Reader, response interface{}) error {
+	return c.DoWithContext(c.ctx, http.MethodPatch, path, body, response)
+}
+
+func (c *RetryClient) Post(path string, body io.Reader, response interface{}) error {
+	return c.DoWithContext(c.ctx, http.MethodPost, path, body, response)
+}
+
+func (c *RetryClient) Put(path string, body io.Reader, response interface{}) error {
+	return c.DoWithContext(c.ctx, http.MethodPut, path, body, response)
+}
+
+func (c *RetryClient) Request(method string, path string, body io.Reader) (*http.Response, error) {
+	return c.RequestWithContext(c.ctx, method, path, body)
+}
+
+func (c *RetryClient) DoWithContext(
+	ctx context.Context, method string, path string, body io.Reader, response interface{},
+) error {
+	return c.do(ctx, method, body, func(body io.Reader) error {
+		return c.client.DoWithContext(ctx, method, path, body, response)
+	})
+}
+
+func (c *RetryClient) RequestWithContext(
+	ctx context.Context, method string, path string, body io.Reader,
+) (*http.Response, error) {
+	var resp *http.Response
+	err := c.do(ctx, method, body, func(body io.Reader) error {
+		var err error
+		resp, err = c.client.RequestWithContext(ctx, method, path, body)
+		return err
+	})
+	return resp, err
+}
+
+// do calls fn until it succeeds, returns a non-retryable error,
+// or the retry limits are reached.
+func (c *RetryClient) do(ctx context.Context, method string, body io.Reader, fn func(body io.Reader) error) error {
+	// Buffer the body so it can be resent.
+	var data []byte
+	if body != nil {
+		var err error
+		data, err = io.ReadAll(body)
+		if err != nil {
+			return err
+		}
+	}
+
+	waited := time.Duration(0)
+	for attempt := 0; ; attempt++ {
+		var body io.Reader
+		if data != nil {
+			body = bytes.NewReader(data)
+		}
+		err := fn(body)
+		if err == nil {
+			return nil
+		}
+		if attempt >= c.opts.MaxRetries {
+			return err
+		}
+		delay, ok := c.retryDelay(method, err, attempt)
+		if !ok || waited+delay > c.opts.MaxWait {
+			return err
+		}
+		if err := c.sleep(ctx, delay); err != nil {
+			return err
+		}
+		waited += delay
+	}
+}
+
+// retryDelay returns how long to wait before retrying a request that failed
+// with err, and false if the request should not be retried.
+func (c *RetryClient) retryDelay(method string, err error, attempt int) (time.Duration, bool) {
+	httpErr := &api.HTTPError{}
+	if !errors.As(err, httpErr) {
+		return 0, false
+	}
+
+	// Explicit instructions from the API take precedence.
+	if delay, ok := retryAfter(httpErr.Headers); ok {
+		return delay, true
+	}
+	if httpErr.Headers.Get("X-RateLimit-Remaining") == "0" {
+		if delay, ok := c.rateLimitReset(httpErr.Headers); ok {
+			return delay, true
+		}
+	}
+
+	switch {
+	case httpErr.StatusCode == http.StatusTooManyRequests:
+		return c.backoff(attempt), true
+	case httpErr.StatusCode == http.StatusForbidden && isSecondaryRateLimit(httpErr):
+		return c.backoff(attempt), true
+	case httpErr.StatusCode >= 500 && method != http.MethodPost:
+		return c.backoff(attempt), true
+	default:
+		return 0, false
+	}
+}
+
+// backoff returns the jittered exponential backoff delay for attempt.
+func (c *RetryClient) backoff(attempt int) time.Duration {
+	delay := c.opts.BaseDelay << attempt
+	if delay <= 0 {
+		return 0
+	}
+	// Wait somewhere between half and all of the delay.
+	half := int64(delay / 2)
+	return time.Duration(half + c.jitter(half+1))
+}
+
+// rateLimitReset returns the time remaining until the rate limit resets.
+func (c *RetryClient) rateLimitReset(header http.Header) (time.Duration, bool) {
+	reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	delay := time.Unix(reset, 0).Sub(c.now())
+	if delay < 0 {
+		delay = 0
+	}
+	return delay, true
+}
+
+// sleepContext waits for d, returning the ctx error if it is done first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// retryAfter returns the delay in the `Retry-After` header (if present).
+func retryAfter(header http.Header) (time.Duration, bool) {
+	seconds, err := strconv.Atoi(header.Get("Retry-After"))
+	if err != nil || seconds < 0 {
+		return 0, false
+	}
+	return time.Duration(seconds) * time.Second, true
+}
+
+func isSecondaryRateLimit(err *api.HTTPError) bool {
+	msg := strings.ToLower(err.Message)
+	return strings.Contains(msg, "secondary rate limit") || strings.Contains(msg, "abuse")
+}
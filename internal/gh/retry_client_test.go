@@ -0,0 +1,223 @@
+package gh
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeResponse struct {
+	status  int
+	headers map[string]string
+	body    string
+}
+
+func TestRetryClient(t *testing.T) {
+	now := time.Date(2023, 4, 1, 12, 0, 0, 0, time.UTC)
+	rateLimited := map[string]string{
+		"X-RateLimit-Remaining": "0",
+		"X-RateLimit-Reset":     strconv.FormatInt(now.Add(10*time.Second).Unix(), 10),
+	}
+	ok := fakeResponse{status: 200, body: `{"login": "test-user"}`}
+
+	tests := []struct {
+		desc      string
+		method    string
+		opts      RetryOptions
+		responses []fakeResponse
+		delays    []time.Duration
+		err       string
+	}{
+		{
+			desc:      "does not retry successful requests",
+			responses: []fakeResponse{ok},
+			delays:    []time.Duration{},
+		},
+		{
+			desc: "honors retry-after on 429 responses",
+			responses: []fakeResponse{
+				{status: 429, headers: map[string]string{"Retry-After": "3"}},
+				ok,
+			},
+			delays: []time.Duration{3 * time.Second},
+		},
+		{
+			desc: "waits for the rate limit reset on 403 responses",
+			responses: []fakeResponse{
+				{status: 403, headers: rateLimited, body: `{"message": "API rate limit exceeded"}`},
+				ok,
+			},
+			delays: []time.Duration{10 * time.Second},
+		},
+		{
+			desc: "backs off on secondary rate limit responses",
+			responses: []fakeResponse{
+				{status: 403, body: `{"message": "You have exceeded a secondary rate limit."}`},
+				{status: 403, body: `{"message": "You have triggered an abuse detection mechanism."}`},
+				ok,
+			},
+			delays: []time.Duration{1 * time.Second, 2 * time.Second},
+		},
+		{
+			desc: "backs off on server errors",
+			responses: []fakeResponse{
+				{status: 500},
+				{status: 502},
+				{status: 503},
+				ok,
+			},
+			delays: []time.Duration{1 * time.Second, 2 * time.Second, 4 * time.Second},
+		},
+		{
+			desc:   "does not retry server errors for POST requests",
+			method: http.MethodPost,
+			responses: []fakeResponse{
+				{status: 502},
+				ok,
+			},
+			delays: []time.Duration{},
+			err:    "HTTP 502",
+		},
+		{
+			desc:   "resends the body when retrying POST requests",
+			method: http.MethodPost,
+			responses: []fakeResponse{
+				{status: 429, headers: map[string]string{"Retry-After": "1"}},
+				ok,
+			},
+			delays: []time.Duration{1 * time.Second},
+		},
+		{
+			desc: "does not retry other client errors",
+			responses: []fakeResponse{
+				{status: 403, body: `{"message": "Resource not accessible by integration"}`},
+				ok,
+			},
+			delays: []time.Duration{},
+			err:    "Resource not accessible by integration",
+		},
+		{
+			desc: "gives up after the max number of retries",
+			opts: RetryOptions{MaxRetries: 2, BaseDelay: time.Second, MaxWait: time.Hour},
+			responses: []fakeResponse{
+				{status: 429, headers: map[string]string{"Retry-After": "1"}},
+				{status: 429, headers: map[string]string{"Retry-After": "1"}},
+				{status: 429, headers: map[string]string{"Retry-After": "1"}},
+				ok,
+			},
+			delays: []time.Duration{1 * time.Second, 1 * time.Second},
+			err:    "HTTP 429",
+		},
+		{
+			desc: "gives up rather than exceed the max wait",
+			opts: RetryOptions{MaxRetries: 5, BaseDelay: time.Second, MaxWait: 5 * time.Second},
+			responses: []fakeResponse{
+				{status: 429, headers: map[string]string{"Retry-After": "3"}},
+				{status: 429, headers: map[string]string{"Retry-After": "3"}},
+				ok,
+			},
+			delays: []time.Duration{3 * time.Second},
+			err:    "HTTP 429",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			bodies := []string{}
+			count := 0
+			requests := stubFakeHost(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				bodies = append(bodies, string(body))
+
+				resp := tt.responses[count]
+				count++
+				for k, v := range resp.headers {
+					w.Header().Set(k, v)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(resp.status)
+				fmt.Fprint(w, resp.body)
+			}))
+			restClient, err := NewRESTClient("github.com", "test-token")
+			require.NoError(t, err)
+
+			opts := tt.opts
+			if opts.MaxRetries == 0 {
+				opts = RetryOptions{MaxRetries: 5, BaseDelay: time.Second, MaxWait: time.Minute}
+			}
+			client := NewRetryClient(context.Background(), restClient, opts)
+			delays := []time.Duration{}
+			client.now = func() time.Time { return now }
+			client.sleep = func(ctx context.Context, d time.Duration) error {
+				delays = append(delays, d)
+				return nil
+			}
+			client.jitter = func(n int64) int64 { return n - 1 } // no jitter
+
+			user := &User{}
+			if tt.method == http.MethodPost {
+				err = client.Post("user", strings.NewReader(`{"name": "test"}`), user)
+			} else {
+				err = client.Get("user", user)
+			}
+
+			if tt.err == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, "test-user", user.Login)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+			assert.Equal(t, tt.delays, delays)
+			assert.Equal(t, len(tt.delays)+1, len(*requests))
+			if tt.method == http.MethodPost {
+				for _, body := range bodies {
+					assert.Equal(t, `{"name": "test"}`, body)
+				}
+			}
+		})
+	}
+}
+
+func TestRetryClient_Backoff(t *testing.T) {
+	client := NewRetryClient(context.Background(), &RESTClientMock{}, RetryOptions{
+		BaseDelay: time.Second,
+	})
+	for attempt := 0; attempt < 5; attempt++ {
+		max := time.Second << attempt
+		for i := 0; i < 20; i++ {
+			delay := client.backoff(attempt)
+			assert.GreaterOrEqual(t, delay, max/2)
+			assert.LessOrEqual(t, delay, max)
+		}
+	}
+}
+
+func TestRetryClient_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	requests := stubFakeHost(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cancel() // e.g. Ctrl-C while waiting to retry
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	restClient, err := NewRESTClient("github.com", "test-token")
+	require.NoError(t, err)
+	client := NewRetryClient(ctx, restClient, RetryOptions{
+		MaxRetries: 5, BaseDelay: time.Minute, MaxWait: time.Hour,
+	})
+
+	err = client.Get("user", &User{})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, len(*requests))
+
+	// no more requests once canceled
+	err = client.Get("user", &User{})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, len(*requests))
+}
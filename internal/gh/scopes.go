@@ -0,0 +1,66 @@
+package gh
+
+import (
+	"net/http"
+	"strings"
+)
+
+var (
+	// impliedScopes maps OAuth scopes to the narrower scopes they include.
+	// See https://docs.github.com/en/apps/oauth-apps/building-oauth-apps/scopes-for-oauth-apps
+	impliedScopes = map[string][]string{
+		"repo":                  {"repo:status", "repo_deployment", "public_repo", "repo:invite", "security_events"},
+		"admin:org":             {"write:org", "read:org", "manage_runners:org"},
+		"write:org":             {"read:org"},
+		"admin:public_key":      {"write:public_key", "read:public_key"},
+		"write:public_key":      {"read:public_key"},
+		"admin:repo_hook":       {"write:repo_hook", "read:repo_hook"},
+		"write:repo_hook":       {"read:repo_hook"},
+		"user":                  {"read:user", "user:email", "user:follow"},
+		"admin:gpg_key":         {"write:gpg_key", "read:gpg_key"},
+		"write:gpg_key":         {"read:gpg_key"},
+		"admin:ssh_signing_key": {"write:ssh_signing_key", "read:ssh_signing_key"},
+		"write:ssh_signing_key": {"read:ssh_signing_key"},
+		"write:packages":        {"read:packages"},
+		"project":               {"read:project"},
+	}
+)
+
+// ParseScopes parses the value of the `X-OAuth-Scopes` response header.
+// Returns nil if the header is missing, which is the case for tokens
+// that do not use OAuth scopes (fine-grained and app tokens).
+func ParseScopes(header http.Header) []string {
+	values := header.Values("X-OAuth-Scopes")
+	if len(values) == 0 {
+		return nil
+	}
+	scopes := []string{}
+	for _, value := range values {
+		for _, scope := range strings.Split(value, ",") {
+			if scope = strings.TrimSpace(scope); scope != "" {
+				scopes = append(scopes, scope)
+			}
+		}
+	}
+	return scopes
+}
+
+// MissingScopes returns the scopes in required that are not
+// included (directly or implicitly) in granted.
+func MissingScopes(granted []string, required []string) []string {
+	has := map[string]bool{}
+	for _, scope := range granted {
+		has[scope] = true
+		for _, implied := range impliedScopes[scope] {
+			has[implied] = true
+		}
+	}
+	missing := []string{}
+	for _, scope := range required {
+		if !has[scope] {
+			has[scope] = true // dedupe
+			missing = append(missing, scope)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,86 @@
+package gh
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseScopes(t *testing.T) {
+	tests := []struct {
+		desc     string
+		header   http.Header
+		expected []string
+	}{
+		{
+			desc: "parses a comma separated list of scopes",
+			header: http.Header{
+				"X-Oauth-Scopes": []string{"gist, read:org, repo, workflow"},
+			},
+			expected: []string{"gist", "read:org", "repo", "workflow"},
+		},
+		{
+			desc: "returns an empty slice when the token has no scopes",
+			header: http.Header{
+				"X-Oauth-Scopes": []string{""},
+			},
+			expected: []string{},
+		},
+		{
+			desc:     "returns nil when the header is missing",
+			header:   http.Header{},
+			expected: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ParseScopes(tt.header))
+		})
+	}
+}
+
+func TestMissingScopes(t *testing.T) {
+	tests := []struct {
+		desc     string
+		granted  []string
+		required []string
+		expected []string
+	}{
+		{
+			desc:     "returns an empty slice when all scopes are granted",
+			granted:  []string{"repo", "read:org", "workflow"},
+			required: []string{"repo", "workflow"},
+			expected: []string{},
+		},
+		{
+			desc:     "returns the scopes that are not granted",
+			granted:  []string{"repo"},
+			required: []string{"repo", "read:org", "workflow"},
+			expected: []string{"read:org", "workflow"},
+		},
+		{
+			desc:     "treats broader scopes as including narrower ones",
+			granted:  []string{"repo", "admin:org", "admin:public_key"},
+			required: []string{"public_repo", "read:org", "write:org", "read:public_key"},
+			expected: []string{},
+		},
+		{
+			desc:     "does not treat narrower scopes as including broader ones",
+			granted:  []string{"public_repo", "read:org"},
+			required: []string{"repo", "write:org"},
+			expected: []string{"repo", "write:org"},
+		},
+		{
+			desc:     "does not repeat missing scopes",
+			granted:  []string{},
+			required: []string{"repo", "repo"},
+			expected: []string{"repo"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.expected, MissingScopes(tt.granted, tt.required))
+		})
+	}
+}
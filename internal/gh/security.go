@@ -0,0 +1,74 @@
+package gh
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/cli/go-gh/pkg/api"
+)
+
+// Security feature statuses (in [SecurityAndAnalysis]).
+const (
+	StatusEnabled  = "enabled"
+	StatusDisabled = "disabled"
+)
+
+// SecurityAndAnalysis is the security and analysis settings of a repo.
+// Only visible to (and settable by) repo admins.
+type SecurityAndAnalysis struct {
+	AdvancedSecurity             *SecurityFeature `json:"advanced_security,omitempty"`
+	SecretScanning               *SecurityFeature `json:"secret_scanning,omitempty"`
+	SecretScanningPushProtection *SecurityFeature `json:"secret_scanning_push_protection,omitempty"`
+}
+
+// SecurityFeature is the status of a security and analysis feature.
+type SecurityFeature struct {
+	// StatusEnabled or StatusDisabled.
+	Status string `json:"status"`
+}
+
+// Enabled returns true if the feature is enabled.
+func (f *SecurityFeature) Enabled() bool {
+	return f != nil && f.Status == StatusEnabled
+}
+
+// UnavailableError is returned when a security feature can not be used
+// by a repo (usually because the owner's plan doesn't include it).
+type UnavailableError struct {
+	// Why, as reported by GitHub.
+	Reason string
+	// The underlying error.
+	Err error
+}
+
+func (e *UnavailableError) Error() string {
+	return "unavailable: " + e.Reason
+}
+
+func (e *UnavailableError) Unwrap() error {
+	return e.Err
+}
+
+// translateSecurityError converts the errors GitHub responds with
+// for features a repo can't use into an [UnavailableError].
+// Errors with a more specific cause (e.g. a missing scope)
+// are translated by [TranslateError] instead.
+func translateSecurityError(err error) error {
+	err = TranslateError(err)
+	apiErr := &APIError{}
+	if errors.As(err, &apiErr) {
+		return err
+	}
+	httpErr := &api.HTTPError{}
+	if !errors.As(err, httpErr) {
+		return err
+	}
+	switch httpErr.StatusCode {
+	case http.StatusForbidden, http.StatusNotFound, http.StatusConflict, http.StatusUnprocessableEntity:
+		return &UnavailableError{
+			Reason: httpErr.Message,
+			Err:    err,
+		}
+	}
+	return err
+}
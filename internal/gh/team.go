@@ -0,0 +1,20 @@
+package gh
+
+// Team is a GitHub org team.
+type Team struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+	// The team's permission on a repo (pull, triage, push, maintain or admin)
+	// when listed for that repo.
+	Permission string `json:"permission"`
+}
+
+// CanWrite returns true if the team's permission allows pushing to the repo.
+func (t *Team) CanWrite() bool {
+	switch t.Permission {
+	case "push", "maintain", "admin":
+		return true
+	}
+	return false
+}
@@ -5,6 +5,7 @@ package git
 
 import (
 	"bytes"
+	"context"
 	"sync"
 )
 
@@ -18,15 +19,45 @@ var _ Client = &ClientMock{}
 //
 //		// make and configure a mocked Client
 //		mockedClient := &ClientMock{
+//			AddFunc: func(ctx context.Context, paths ...string) error {
+//				panic("mock out the Add method")
+//			},
+//			AddRemoteFunc: func(ctx context.Context, name string, url string) error {
+//				panic("mock out the AddRemote method")
+//			},
+//			CheckoutFunc: func(ctx context.Context, branch string, upstream string) error {
+//				panic("mock out the Checkout method")
+//			},
+//			CloneFunc: func(ctx context.Context, url string, progress ProgressFunc) error {
+//				panic("mock out the Clone method")
+//			},
+//			CommitFunc: func(ctx context.Context, message string, opts CommitOptions) error {
+//				panic("mock out the Commit method")
+//			},
+//			ConfigFunc: func(key string) (string, error) {
+//				panic("mock out the Config method")
+//			},
 //			ExecFunc: func(args ...string) (bytes.Buffer, bytes.Buffer, error) {
 //				panic("mock out the Exec method")
 //			},
+//			ExecContextFunc: func(ctx context.Context, args ...string) (bytes.Buffer, bytes.Buffer, error) {
+//				panic("mock out the ExecContext method")
+//			},
+//			FetchFunc: func(ctx context.Context, remote string, progress ProgressFunc) error {
+//				panic("mock out the Fetch method")
+//			},
+//			GetenvFunc: func(key string) string {
+//				panic("mock out the Getenv method")
+//			},
 //			HasCommitsFunc: func() bool {
 //				panic("mock out the HasCommits method")
 //			},
 //			HasRemoteFunc: func(name string) bool {
 //				panic("mock out the HasRemote method")
 //			},
+//			InitFunc: func(ctx context.Context) error {
+//				panic("mock out the Init method")
+//			},
 //			IsDirtyFunc: func() bool {
 //				panic("mock out the IsDirty method")
 //			},
@@ -36,6 +67,27 @@ var _ Client = &ClientMock{}
 //			IsInstalledFunc: func() bool {
 //				panic("mock out the IsInstalled method")
 //			},
+//			LocalConfigFunc: func(key string) (string, error) {
+//				panic("mock out the LocalConfig method")
+//			},
+//			PushFunc: func(ctx context.Context, remote string, progress ProgressFunc) error {
+//				panic("mock out the Push method")
+//			},
+//			RemoteURLFunc: func(name string) (string, error) {
+//				panic("mock out the RemoteURL method")
+//			},
+//			SetConfigFunc: func(ctx context.Context, key string, value string) error {
+//				panic("mock out the SetConfig method")
+//			},
+//			SetRemoteHeadFunc: func(ctx context.Context, remote string) error {
+//				panic("mock out the SetRemoteHead method")
+//			},
+//			SetRemoteURLFunc: func(ctx context.Context, name string, url string) error {
+//				panic("mock out the SetRemoteURL method")
+//			},
+//			SetUpstreamFunc: func(ctx context.Context, upstream string) error {
+//				panic("mock out the SetUpstream method")
+//			},
 //			StatusLinesFunc: func() ([]string, error) {
 //				panic("mock out the StatusLines method")
 //			},
@@ -46,15 +98,45 @@ var _ Client = &ClientMock{}
 //
 //	}
 type ClientMock struct {
+	// AddFunc mocks the Add method.
+	AddFunc func(ctx context.Context, paths ...string) error
+
+	// AddRemoteFunc mocks the AddRemote method.
+	AddRemoteFunc func(ctx context.Context, name string, url string) error
+
+	// CheckoutFunc mocks the Checkout method.
+	CheckoutFunc func(ctx context.Context, branch string, upstream string) error
+
+	// CloneFunc mocks the Clone method.
+	CloneFunc func(ctx context.Context, url string, progress ProgressFunc) error
+
+	// CommitFunc mocks the Commit method.
+	CommitFunc func(ctx context.Context, message string, opts CommitOptions) error
+
+	// ConfigFunc mocks the Config method.
+	ConfigFunc func(key string) (string, error)
+
 	// ExecFunc mocks the Exec method.
 	ExecFunc func(args ...string) (bytes.Buffer, bytes.Buffer, error)
 
+	// ExecContextFunc mocks the ExecContext method.
+	ExecContextFunc func(ctx context.Context, args ...string) (bytes.Buffer, bytes.Buffer, error)
+
+	// FetchFunc mocks the Fetch method.
+	FetchFunc func(ctx context.Context, remote string, progress ProgressFunc) error
+
+	// GetenvFunc mocks the Getenv method.
+	GetenvFunc func(key string) string
+
 	// HasCommitsFunc mocks the HasCommits method.
 	HasCommitsFunc func() bool
 
 	// HasRemoteFunc mocks the HasRemote method.
 	HasRemoteFunc func(name string) bool
 
+	// InitFunc mocks the Init method.
+	InitFunc func(ctx context.Context) error
+
 	// IsDirtyFunc mocks the IsDirty method.
 	IsDirtyFunc func() bool
 
@@ -64,16 +146,106 @@ type ClientMock struct {
 	// IsInstalledFunc mocks the IsInstalled method.
 	IsInstalledFunc func() bool
 
+	// LocalConfigFunc mocks the LocalConfig method.
+	LocalConfigFunc func(key string) (string, error)
+
+	// PushFunc mocks the Push method.
+	PushFunc func(ctx context.Context, remote string, progress ProgressFunc) error
+
+	// RemoteURLFunc mocks the RemoteURL method.
+	RemoteURLFunc func(name string) (string, error)
+
+	// SetConfigFunc mocks the SetConfig method.
+	SetConfigFunc func(ctx context.Context, key string, value string) error
+
+	// SetRemoteHeadFunc mocks the SetRemoteHead method.
+	SetRemoteHeadFunc func(ctx context.Context, remote string) error
+
+	// SetRemoteURLFunc mocks the SetRemoteURL method.
+	SetRemoteURLFunc func(ctx context.Context, name string, url string) error
+
+	// SetUpstreamFunc mocks the SetUpstream method.
+	SetUpstreamFunc func(ctx context.Context, upstream string) error
+
 	// StatusLinesFunc mocks the StatusLines method.
 	StatusLinesFunc func() ([]string, error)
 
 	// calls tracks calls to the methods.
 	calls struct {
+		// Add holds details about calls to the Add method.
+		Add []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Paths is the paths argument value.
+			Paths []string
+		}
+		// AddRemote holds details about calls to the AddRemote method.
+		AddRemote []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Name is the name argument value.
+			Name string
+			// URL is the url argument value.
+			URL string
+		}
+		// Checkout holds details about calls to the Checkout method.
+		Checkout []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Branch is the branch argument value.
+			Branch string
+			// Upstream is the upstream argument value.
+			Upstream string
+		}
+		// Clone holds details about calls to the Clone method.
+		Clone []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// URL is the url argument value.
+			URL string
+			// Progress is the progress argument value.
+			Progress ProgressFunc
+		}
+		// Commit holds details about calls to the Commit method.
+		Commit []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Message is the message argument value.
+			Message string
+			// Opts is the opts argument value.
+			Opts CommitOptions
+		}
+		// Config holds details about calls to the Config method.
+		Config []struct {
+			// Key is the key argument value.
+			Key string
+		}
 		// Exec holds details about calls to the Exec method.
 		Exec []struct {
 			// Args is the args argument value.
 			Args []string
 		}
+		// ExecContext holds details about calls to the ExecContext method.
+		ExecContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Args is the args argument value.
+			Args []string
+		}
+		// Fetch holds details about calls to the Fetch method.
+		Fetch []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Remote is the remote argument value.
+			Remote string
+			// Progress is the progress argument value.
+			Progress ProgressFunc
+		}
+		// Getenv holds details about calls to the Getenv method.
+		Getenv []struct {
+			// Key is the key argument value.
+			Key string
+		}
 		// HasCommits holds details about calls to the HasCommits method.
 		HasCommits []struct {
 		}
@@ -82,6 +254,11 @@ type ClientMock struct {
 			// Name is the name argument value.
 			Name string
 		}
+		// Init holds details about calls to the Init method.
+		Init []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
 		// IsDirty holds details about calls to the IsDirty method.
 		IsDirty []struct {
 		}
@@ -91,19 +268,315 @@ type ClientMock struct {
 		// IsInstalled holds details about calls to the IsInstalled method.
 		IsInstalled []struct {
 		}
+		// LocalConfig holds details about calls to the LocalConfig method.
+		LocalConfig []struct {
+			// Key is the key argument value.
+			Key string
+		}
+		// Push holds details about calls to the Push method.
+		Push []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Remote is the remote argument value.
+			Remote string
+			// Progress is the progress argument value.
+			Progress ProgressFunc
+		}
+		// RemoteURL holds details about calls to the RemoteURL method.
+		RemoteURL []struct {
+			// Name is the name argument value.
+			Name string
+		}
+		// SetConfig holds details about calls to the SetConfig method.
+		SetConfig []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+			// Value is the value argument value.
+			Value string
+		}
+		// SetRemoteHead holds details about calls to the SetRemoteHead method.
+		SetRemoteHead []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Remote is the remote argument value.
+			Remote string
+		}
+		// SetRemoteURL holds details about calls to the SetRemoteURL method.
+		SetRemoteURL []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Name is the name argument value.
+			Name string
+			// URL is the url argument value.
+			URL string
+		}
+		// SetUpstream holds details about calls to the SetUpstream method.
+		SetUpstream []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Upstream is the upstream argument value.
+			Upstream string
+		}
 		// StatusLines holds details about calls to the StatusLines method.
 		StatusLines []struct {
 		}
 	}
+	lockAdd           sync.RWMutex
+	lockAddRemote     sync.RWMutex
+	lockCheckout      sync.RWMutex
+	lockClone         sync.RWMutex
+	lockCommit        sync.RWMutex
+	lockConfig        sync.RWMutex
 	lockExec          sync.RWMutex
+	lockExecContext   sync.RWMutex
+	lockFetch         sync.RWMutex
+	lockGetenv        sync.RWMutex
 	lockHasCommits    sync.RWMutex
 	lockHasRemote     sync.RWMutex
+	lockInit          sync.RWMutex
 	lockIsDirty       sync.RWMutex
 	lockIsInitialized sync.RWMutex
 	lockIsInstalled   sync.RWMutex
+	lockLocalConfig   sync.RWMutex
+	lockPush          sync.RWMutex
+	lockRemoteURL     sync.RWMutex
+	lockSetConfig     sync.RWMutex
+	lockSetRemoteHead sync.RWMutex
+	lockSetRemoteURL  sync.RWMutex
+	lockSetUpstream   sync.RWMutex
 	lockStatusLines   sync.RWMutex
 }
 
+// Add calls AddFunc.
+func (mock *ClientMock) Add(ctx context.Context, paths ...string) error {
+	if mock.AddFunc == nil {
+		panic("ClientMock.AddFunc: method is nil but Client.Add was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Paths []string
+	}{
+		Ctx:   ctx,
+		Paths: paths,
+	}
+	mock.lockAdd.Lock()
+	mock.calls.Add = append(mock.calls.Add, callInfo)
+	mock.lockAdd.Unlock()
+	return mock.AddFunc(ctx, paths...)
+}
+
+// AddCalls gets all the calls that were made to Add.
+// Check the length with:
+//
+//	len(mockedClient.AddCalls())
+func (mock *ClientMock) AddCalls() []struct {
+	Ctx   context.Context
+	Paths []string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Paths []string
+	}
+	mock.lockAdd.RLock()
+	calls = mock.calls.Add
+	mock.lockAdd.RUnlock()
+	return calls
+}
+
+// AddRemote calls AddRemoteFunc.
+func (mock *ClientMock) AddRemote(ctx context.Context, name string, url string) error {
+	if mock.AddRemoteFunc == nil {
+		panic("ClientMock.AddRemoteFunc: method is nil but Client.AddRemote was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Name string
+		URL  string
+	}{
+		Ctx:  ctx,
+		Name: name,
+		URL:  url,
+	}
+	mock.lockAddRemote.Lock()
+	mock.calls.AddRemote = append(mock.calls.AddRemote, callInfo)
+	mock.lockAddRemote.Unlock()
+	return mock.AddRemoteFunc(ctx, name, url)
+}
+
+// AddRemoteCalls gets all the calls that were made to AddRemote.
+// Check the length with:
+//
+//	len(mockedClient.AddRemoteCalls())
+func (mock *ClientMock) AddRemoteCalls() []struct {
+	Ctx  context.Context
+	Name string
+	URL  string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Name string
+		URL  string
+	}
+	mock.lockAddRemote.RLock()
+	calls = mock.calls.AddRemote
+	mock.lockAddRemote.RUnlock()
+	return calls
+}
+
+// Checkout calls CheckoutFunc.
+func (mock *ClientMock) Checkout(ctx context.Context, branch string, upstream string) error {
+	if mock.CheckoutFunc == nil {
+		panic("ClientMock.CheckoutFunc: method is nil but Client.Checkout was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Branch   string
+		Upstream string
+	}{
+		Ctx:      ctx,
+		Branch:   branch,
+		Upstream: upstream,
+	}
+	mock.lockCheckout.Lock()
+	mock.calls.Checkout = append(mock.calls.Checkout, callInfo)
+	mock.lockCheckout.Unlock()
+	return mock.CheckoutFunc(ctx, branch, upstream)
+}
+
+// CheckoutCalls gets all the calls that were made to Checkout.
+// Check the length with:
+//
+//	len(mockedClient.CheckoutCalls())
+func (mock *ClientMock) CheckoutCalls() []struct {
+	Ctx      context.Context
+	Branch   string
+	Upstream string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Branch   string
+		Upstream string
+	}
+	mock.lockCheckout.RLock()
+	calls = mock.calls.Checkout
+	mock.lockCheckout.RUnlock()
+	return calls
+}
+
+// Clone calls CloneFunc.
+func (mock *ClientMock) Clone(ctx context.Context, url string, progress ProgressFunc) error {
+	if mock.CloneFunc == nil {
+		panic("ClientMock.CloneFunc: method is nil but Client.Clone was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		URL      string
+		Progress ProgressFunc
+	}{
+		Ctx:      ctx,
+		URL:      url,
+		Progress: progress,
+	}
+	mock.lockClone.Lock()
+	mock.calls.Clone = append(mock.calls.Clone, callInfo)
+	mock.lockClone.Unlock()
+	return mock.CloneFunc(ctx, url, progress)
+}
+
+// CloneCalls gets all the calls that were made to Clone.
+// Check the length with:
+//
+//	len(mockedClient.CloneCalls())
+func (mock *ClientMock) CloneCalls() []struct {
+	Ctx      context.Context
+	URL      string
+	Progress ProgressFunc
+} {
+	var calls []struct {
+		Ctx      context.Context
+		URL      string
+		Progress ProgressFunc
+	}
+	mock.lockClone.RLock()
+	calls = mock.calls.Clone
+	mock.lockClone.RUnlock()
+	return calls
+}
+
+// Commit calls CommitFunc.
+func (mock *ClientMock) Commit(ctx context.Context, message string, opts CommitOptions) error {
+	if mock.CommitFunc == nil {
+		panic("ClientMock.CommitFunc: method is nil but Client.Commit was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Message string
+		Opts    CommitOptions
+	}{
+		Ctx:     ctx,
+		Message: message,
+		Opts:    opts,
+	}
+	mock.lockCommit.Lock()
+	mock.calls.Commit = append(mock.calls.Commit, callInfo)
+	mock.lockCommit.Unlock()
+	return mock.CommitFunc(ctx, message, opts)
+}
+
+// CommitCalls gets all the calls that were made to Commit.
+// Check the length with:
+//
+//	len(mockedClient.CommitCalls())
+func (mock *ClientMock) CommitCalls() []struct {
+	Ctx     context.Context
+	Message string
+	Opts    CommitOptions
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Message string
+		Opts    CommitOptions
+	}
+	mock.lockCommit.RLock()
+	calls = mock.calls.Commit
+	mock.lockCommit.RUnlock()
+	return calls
+}
+
+// Config calls ConfigFunc.
+func (mock *ClientMock) Config(key string) (string, error) {
+	if mock.ConfigFunc == nil {
+		panic("ClientMock.ConfigFunc: method is nil but Client.Config was just called")
+	}
+	callInfo := struct {
+		Key string
+	}{
+		Key: key,
+	}
+	mock.lockConfig.Lock()
+	mock.calls.Config = append(mock.calls.Config, callInfo)
+	mock.lockConfig.Unlock()
+	return mock.ConfigFunc(key)
+}
+
+// ConfigCalls gets all the calls that were made to Config.
+// Check the length with:
+//
+//	len(mockedClient.ConfigCalls())
+func (mock *ClientMock) ConfigCalls() []struct {
+	Key string
+} {
+	var calls []struct {
+		Key string
+	}
+	mock.lockConfig.RLock()
+	calls = mock.calls.Config
+	mock.lockConfig.RUnlock()
+	return calls
+}
+
 // Exec calls ExecFunc.
 func (mock *ClientMock) Exec(args ...string) (bytes.Buffer, bytes.Buffer, error) {
 	if mock.ExecFunc == nil {
@@ -136,6 +609,114 @@ func (mock *ClientMock) ExecCalls() []struct {
 	return calls
 }
 
+// ExecContext calls ExecContextFunc.
+func (mock *ClientMock) ExecContext(ctx context.Context, args ...string) (bytes.Buffer, bytes.Buffer, error) {
+	if mock.ExecContextFunc == nil {
+		panic("ClientMock.ExecContextFunc: method is nil but Client.ExecContext was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Args []string
+	}{
+		Ctx:  ctx,
+		Args: args,
+	}
+	mock.lockExecContext.Lock()
+	mock.calls.ExecContext = append(mock.calls.ExecContext, callInfo)
+	mock.lockExecContext.Unlock()
+	return mock.ExecContextFunc(ctx, args...)
+}
+
+// ExecContextCalls gets all the calls that were made to ExecContext.
+// Check the length with:
+//
+//	len(mockedClient.ExecContextCalls())
+func (mock *ClientMock) ExecContextCalls() []struct {
+	Ctx  context.Context
+	Args []string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Args []string
+	}
+	mock.lockExecContext.RLock()
+	calls = mock.calls.ExecContext
+	mock.lockExecContext.RUnlock()
+	return calls
+}
+
+// Fetch calls FetchFunc.
+func (mock *ClientMock) Fetch(ctx context.Context, remote string, progress ProgressFunc) error {
+	if mock.FetchFunc == nil {
+		panic("ClientMock.FetchFunc: method is nil but Client.Fetch was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Remote   string
+		Progress ProgressFunc
+	}{
+		Ctx:      ctx,
+		Remote:   remote,
+		Progress: progress,
+	}
+	mock.lockFetch.Lock()
+	mock.calls.Fetch = append(mock.calls.Fetch, callInfo)
+	mock.lockFetch.Unlock()
+	return mock.FetchFunc(ctx, remote, progress)
+}
+
+// FetchCalls gets all the calls that were made to Fetch.
+// Check the length with:
+//
+//	len(mockedClient.FetchCalls())
+func (mock *ClientMock) FetchCalls() []struct {
+	Ctx      context.Context
+	Remote   string
+	Progress ProgressFunc
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Remote   string
+		Progress ProgressFunc
+	}
+	mock.lockFetch.RLock()
+	calls = mock.calls.Fetch
+	mock.lockFetch.RUnlock()
+	return calls
+}
+
+// Getenv calls GetenvFunc.
+func (mock *ClientMock) Getenv(key string) string {
+	if mock.GetenvFunc == nil {
+		panic("ClientMock.GetenvFunc: method is nil but Client.Getenv was just called")
+	}
+	callInfo := struct {
+		Key string
+	}{
+		Key: key,
+	}
+	mock.lockGetenv.Lock()
+	mock.calls.Getenv = append(mock.calls.Getenv, callInfo)
+	mock.lockGetenv.Unlock()
+	return mock.GetenvFunc(key)
+}
+
+// GetenvCalls gets all the calls that were made to Getenv.
+// Check the length with:
+//
+//	len(mockedClient.GetenvCalls())
+func (mock *ClientMock) GetenvCalls() []struct {
+	Key string
+} {
+	var calls []struct {
+		Key string
+	}
+	mock.lockGetenv.RLock()
+	calls = mock.calls.Getenv
+	mock.lockGetenv.RUnlock()
+	return calls
+}
+
 // HasCommits calls HasCommitsFunc.
 func (mock *ClientMock) HasCommits() bool {
 	if mock.HasCommitsFunc == nil {
@@ -195,6 +776,38 @@ func (mock *ClientMock) HasRemoteCalls() []struct {
 	return calls
 }
 
+// Init calls InitFunc.
+func (mock *ClientMock) Init(ctx context.Context) error {
+	if mock.InitFunc == nil {
+		panic("ClientMock.InitFunc: method is nil but Client.Init was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockInit.Lock()
+	mock.calls.Init = append(mock.calls.Init, callInfo)
+	mock.lockInit.Unlock()
+	return mock.InitFunc(ctx)
+}
+
+// InitCalls gets all the calls that were made to Init.
+// Check the length with:
+//
+//	len(mockedClient.InitCalls())
+func (mock *ClientMock) InitCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockInit.RLock()
+	calls = mock.calls.Init
+	mock.lockInit.RUnlock()
+	return calls
+}
+
 // IsDirty calls IsDirtyFunc.
 func (mock *ClientMock) IsDirty() bool {
 	if mock.IsDirtyFunc == nil {
@@ -276,6 +889,262 @@ func (mock *ClientMock) IsInstalledCalls() []struct {
 	return calls
 }
 
+// LocalConfig calls LocalConfigFunc.
+func (mock *ClientMock) LocalConfig(key string) (string, error) {
+	if mock.LocalConfigFunc == nil {
+		panic("ClientMock.LocalConfigFunc: method is nil but Client.LocalConfig was just called")
+	}
+	callInfo := struct {
+		Key string
+	}{
+		Key: key,
+	}
+	mock.lockLocalConfig.Lock()
+	mock.calls.LocalConfig = append(mock.calls.LocalConfig, callInfo)
+	mock.lockLocalConfig.Unlock()
+	return mock.LocalConfigFunc(key)
+}
+
+// LocalConfigCalls gets all the calls that were made to LocalConfig.
+// Check the length with:
+//
+//	len(mockedClient.LocalConfigCalls())
+func (mock *ClientMock) LocalConfigCalls() []struct {
+	Key string
+} {
+	var calls []struct {
+		Key string
+	}
+	mock.lockLocalConfig.RLock()
+	calls = mock.calls.LocalConfig
+	mock.lockLocalConfig.RUnlock()
+	return calls
+}
+
+// Push calls PushFunc.
+func (mock *ClientMock) Push(ctx context.Context, remote string, progress ProgressFunc) error {
+	if mock.PushFunc == nil {
+		panic("ClientMock.PushFunc: method is nil but Client.Push was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Remote   string
+		Progress ProgressFunc
+	}{
+		Ctx:      ctx,
+		Remote:   remote,
+		Progress: progress,
+	}
+	mock.lockPush.Lock()
+	mock.calls.Push = append(mock.calls.Push, callInfo)
+	mock.lockPush.Unlock()
+	return mock.PushFunc(ctx, remote, progress)
+}
+
+// PushCalls gets all the calls that were made to Push.
+// Check the length with:
+//
+//	len(mockedClient.PushCalls())
+func (mock *ClientMock) PushCalls() []struct {
+	Ctx      context.Context
+	Remote   string
+	Progress ProgressFunc
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Remote   string
+		Progress ProgressFunc
+	}
+	mock.lockPush.RLock()
+	calls = mock.calls.Push
+	mock.lockPush.RUnlock()
+	return calls
+}
+
+// RemoteURL calls RemoteURLFunc.
+func (mock *ClientMock) RemoteURL(name string) (string, error) {
+	if mock.RemoteURLFunc == nil {
+		panic("ClientMock.RemoteURLFunc: method is nil but Client.RemoteURL was just called")
+	}
+	callInfo := struct {
+		Name string
+	}{
+		Name: name,
+	}
+	mock.lockRemoteURL.Lock()
+	mock.calls.RemoteURL = append(mock.calls.RemoteURL, callInfo)
+	mock.lockRemoteURL.Unlock()
+	return mock.RemoteURLFunc(name)
+}
+
+// RemoteURLCalls gets all the calls that were made to RemoteURL.
+// Check the length with:
+//
+//	len(mockedClient.RemoteURLCalls())
+func (mock *ClientMock) RemoteURLCalls() []struct {
+	Name string
+} {
+	var calls []struct {
+		Name string
+	}
+	mock.lockRemoteURL.RLock()
+	calls = mock.calls.RemoteURL
+	mock.lockRemoteURL.RUnlock()
+	return calls
+}
+
+// SetConfig calls SetConfigFunc.
+func (mock *ClientMock) SetConfig(ctx context.Context, key string, value string) error {
+	if mock.SetConfigFunc == nil {
+		panic("ClientMock.SetConfigFunc: method is nil but Client.SetConfig was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Key   string
+		Value string
+	}{
+		Ctx:   ctx,
+		Key:   key,
+		Value: value,
+	}
+	mock.lockSetConfig.Lock()
+	mock.calls.SetConfig = append(mock.calls.SetConfig, callInfo)
+	mock.lockSetConfig.Unlock()
+	return mock.SetConfigFunc(ctx, key, value)
+}
+
+// SetConfigCalls gets all the calls that were made to SetConfig.
+// Check the length with:
+//
+//	len(mockedClient.SetConfigCalls())
+func (mock *ClientMock) SetConfigCalls() []struct {
+	Ctx   context.Context
+	Key   string
+	Value string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Key   string
+		Value string
+	}
+	mock.lockSetConfig.RLock()
+	calls = mock.calls.SetConfig
+	mock.lockSetConfig.RUnlock()
+	return calls
+}
+
+// SetRemoteHead calls SetRemoteHeadFunc.
+func (mock *ClientMock) SetRemoteHead(ctx context.Context, remote string) error {
+	if mock.SetRemoteHeadFunc == nil {
+		panic("ClientMock.SetRemoteHeadFunc: method is nil but Client.SetRemoteHead was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Remote string
+	}{
+		Ctx:    ctx,
+		Remote: remote,
+	}
+	mock.lockSetRemoteHead.Lock()
+	mock.calls.SetRemoteHead = append(mock.calls.SetRemoteHead, callInfo)
+	mock.lockSetRemoteHead.Unlock()
+	return mock.SetRemoteHeadFunc(ctx, remote)
+}
+
+// SetRemoteHeadCalls gets all the calls that were made to SetRemoteHead.
+// Check the length with:
+//
+//	len(mockedClient.SetRemoteHeadCalls())
+func (mock *ClientMock) SetRemoteHeadCalls() []struct {
+	Ctx    context.Context
+	Remote string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Remote string
+	}
+	mock.lockSetRemoteHead.RLock()
+	calls = mock.calls.SetRemoteHead
+	mock.lockSetRemoteHead.RUnlock()
+	return calls
+}
+
+// SetRemoteURL calls SetRemoteURLFunc.
+func (mock *ClientMock) SetRemoteURL(ctx context.Context, name string, url string) error {
+	if mock.SetRemoteURLFunc == nil {
+		panic("ClientMock.SetRemoteURLFunc: method is nil but Client.SetRemoteURL was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Name string
+		URL  string
+	}{
+		Ctx:  ctx,
+		Name: name,
+		URL:  url,
+	}
+	mock.lockSetRemoteURL.Lock()
+	mock.calls.SetRemoteURL = append(mock.calls.SetRemoteURL, callInfo)
+	mock.lockSetRemoteURL.Unlock()
+	return mock.SetRemoteURLFunc(ctx, name, url)
+}
+
+// SetRemoteURLCalls gets all the calls that were made to SetRemoteURL.
+// Check the length with:
+//
+//	len(mockedClient.SetRemoteURLCalls())
+func (mock *ClientMock) SetRemoteURLCalls() []struct {
+	Ctx  context.Context
+	Name string
+	URL  string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Name string
+		URL  string
+	}
+	mock.lockSetRemoteURL.RLock()
+	calls = mock.calls.SetRemoteURL
+	mock.lockSetRemoteURL.RUnlock()
+	return calls
+}
+
+// SetUpstream calls SetUpstreamFunc.
+func (mock *ClientMock) SetUpstream(ctx context.Context, upstream string) error {
+	if mock.SetUpstreamFunc == nil {
+		panic("ClientMock.SetUpstreamFunc: method is nil but Client.SetUpstream was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Upstream string
+	}{
+		Ctx:      ctx,
+		Upstream: upstream,
+	}
+	mock.lockSetUpstream.Lock()
+	mock.calls.SetUpstream = append(mock.calls.SetUpstream, callInfo)
+	mock.lockSetUpstream.Unlock()
+	return mock.SetUpstreamFunc(ctx, upstream)
+}
+
+// SetUpstreamCalls gets all the calls that were made to SetUpstream.
+// Check the length with:
+//
+//	len(mockedClient.SetUpstreamCalls())
+func (mock *ClientMock) SetUpstreamCalls() []struct {
+	Ctx      context.Context
+	Upstream string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Upstream string
+	}
+	mock.lockSetUpstream.RLock()
+	calls = mock.calls.SetUpstream
+	mock.lockSetUpstream.RUnlock()
+	return calls
+}
+
 // StatusLines calls StatusLinesFunc.
 func (mock *ClientMock) StatusLines() ([]string, error) {
 	if mock.StatusLinesFunc == nil {
@@ -0,0 +1,531 @@
+package git
+
+import (
+	"context"
+	"net/http"
+	"net/http/cgi"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testIdentity = []string{
+	"GIT_AUTHOR_NAME=Test User",
+	"GIT_AUTHOR_EMAIL=test@example.com",
+	"GIT_COMMITTER_NAME=Test User",
+	"GIT_COMMITTER_EMAIL=test@example.com",
+}
+
+// TestClientConformance runs the same scenarios against every backend,
+// so that they are interchangeable.
+func TestClientConformance(t *testing.T) {
+	backends := []Backend{BackendSystem, BackendGo}
+	for _, backend := range backends {
+		backend := backend
+		newClient := func(dir string, opts ...Option) Client {
+			opts = append([]Option{WithBackend(backend), WithEnv(testIdentity...)}, opts...)
+			return NewClient(dir, opts...)
+		}
+		t.Run(string(backend), func(t *testing.T) {
+			t.Run("init", func(t *testing.T) {
+				testConformanceInit(t, newClient)
+			})
+			t.Run("status, add and commit", func(t *testing.T) {
+				testConformanceCommit(t, newClient)
+			})
+			t.Run("remotes, push and fetch", func(t *testing.T) {
+				testConformanceRemotes(t, newClient)
+			})
+			t.Run("push and fetch over HTTP", func(t *testing.T) {
+				testConformanceHTTP(t, newClient)
+			})
+			t.Run("config", func(t *testing.T) {
+				testConformanceConfig(t, newClient)
+			})
+			t.Run("clone", func(t *testing.T) {
+				testConformanceClone(t, newClient)
+			})
+			t.Run("checkout", func(t *testing.T) {
+				testConformanceCheckout(t, newClient)
+			})
+		})
+	}
+}
+
+func testConformanceInit(t *testing.T, newClient func(dir string, opts ...Option) Client) {
+	t.Helper()
+	ctx := context.Background()
+	client := newClient(t.TempDir())
+
+	assert.True(t, client.IsInstalled())
+	assert.False(t, client.IsInitialized())
+
+	require.NoError(t, client.Init(ctx))
+	assert.True(t, client.IsInitialized())
+	assert.False(t, client.HasCommits())
+	assert.False(t, client.IsDirty())
+
+	// re-running init is harmless
+	assert.NoError(t, client.Init(ctx))
+}
+
+func testConformanceCommit(t *testing.T, newClient func(dir string, opts ...Option) Client) {
+	t.Helper()
+	ctx := context.Background()
+	dir := t.TempDir()
+	client := newClient(dir)
+	require.NoError(t, client.Init(ctx))
+
+	writeFile(t, dir, "foo.txt", "aaa")
+	assertStatus(t, client, "?? foo.txt")
+
+	require.NoError(t, client.Add(ctx, "foo.txt"))
+	assertStatus(t, client, "A  foo.txt")
+
+	require.NoError(t, client.Commit(ctx, "add foo", CommitOptions{NoSign: true, NoVerify: true}))
+	assert.True(t, client.HasCommits())
+	assertStatus(t, client)
+
+	writeFile(t, dir, "foo.txt", "bbb")
+	writeFile(t, dir, "bar.txt", "bbb")
+	assertStatus(t, client, " M foo.txt", "?? bar.txt")
+
+	require.NoError(t, client.Add(ctx, "foo.txt"))
+	assertStatus(t, client, "M  foo.txt", "?? bar.txt")
+
+	require.NoError(t, client.Add(ctx, "."))
+	assertStatus(t, client, "A  bar.txt", "M  foo.txt")
+
+	require.NoError(t, client.Commit(ctx, "update foo, add bar", CommitOptions{NoSign: true, NoVerify: true}))
+	assertStatus(t, client)
+
+	// the commit identity comes from the env
+	repo, err := gogit.PlainOpen(dir)
+	require.NoError(t, err)
+	head, err := repo.Head()
+	require.NoError(t, err)
+	commit, err := repo.CommitObject(head.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, "update foo, add bar", commit.Message[:len("update foo, add bar")])
+	assert.Equal(t, "Test User", commit.Author.Name)
+	assert.Equal(t, "test@example.com", commit.Committer.Email)
+}
+
+func testConformanceRemotes(t *testing.T, newClient func(dir string, opts ...Option) Client) {
+	t.Helper()
+	ctx := context.Background()
+	opts := CommitOptions{NoSign: true, NoVerify: true}
+
+	dir := t.TempDir()
+	client := newClient(dir)
+	require.NoError(t, client.Init(ctx))
+	writeFile(t, dir, "foo.txt", "aaa")
+	require.NoError(t, client.Add(ctx, "."))
+	require.NoError(t, client.Commit(ctx, "add foo", opts))
+	branch := currentTestBranch(t, dir)
+
+	remoteDir := newBareRepo(t, branch)
+	url := "file://" + filepath.ToSlash(remoteDir)
+
+	// remote add
+	assert.False(t, client.HasRemote("origin"))
+	require.NoError(t, client.AddRemote(ctx, "origin", "file:///tmp/elsewhere"))
+	assert.True(t, client.HasRemote("origin"))
+	assert.False(t, client.HasRemote("unknown"))
+
+	// remote get-url and set-url
+	actual, err := client.RemoteURL("origin")
+	require.NoError(t, err)
+	assert.Equal(t, "file:///tmp/elsewhere", actual)
+	require.NoError(t, client.SetRemoteURL(ctx, "origin", url))
+	actual, err = client.RemoteURL("origin")
+	require.NoError(t, err)
+	assert.Equal(t, url, actual)
+	_, err = client.RemoteURL("unknown")
+	assert.Error(t, err)
+	assert.Error(t, client.SetRemoteURL(ctx, "unknown", url))
+
+	// fetching an empty remote is fine
+	require.NoError(t, client.Fetch(ctx, "origin", nil))
+
+	// push
+	require.NoError(t, client.Push(ctx, "origin", nil))
+	remote, err := gogit.PlainOpen(remoteDir)
+	require.NoError(t, err)
+	pushed, err := remote.Reference(plumbing.NewBranchReferenceName(branch), false)
+	require.NoError(t, err)
+	assert.Equal(t, headHash(t, dir), pushed.Hash())
+	assertUpstream(t, dir, branch, "origin", branch)
+
+	// fetch (commits pushed from another clone)
+	otherDir := t.TempDir()
+	other := newClient(otherDir)
+	require.NoError(t, other.Init(ctx))
+	require.NoError(t, other.AddRemote(ctx, "origin", url))
+	require.NoError(t, other.Fetch(ctx, "origin", nil))
+	require.NoError(t, other.SetRemoteHead(ctx, "origin"))
+	// start the clone from the fetched commit
+	otherRepo, err := gogit.PlainOpen(otherDir)
+	require.NoError(t, err)
+	require.NoError(t, otherRepo.Storer.SetReference(
+		plumbing.NewHashReference(plumbing.NewBranchReferenceName(branch), pushed.Hash()),
+	))
+	require.NoError(t, otherRepo.Storer.SetReference(
+		plumbing.NewSymbolicReference(plumbing.HEAD, plumbing.NewBranchReferenceName(branch)),
+	))
+	wt, err := otherRepo.Worktree()
+	require.NoError(t, err)
+	require.NoError(t, wt.Reset(&gogit.ResetOptions{Mode: gogit.HardReset}))
+	writeFile(t, otherDir, "bar.txt", "bbb")
+	require.NoError(t, other.Add(ctx, "."))
+	require.NoError(t, other.Commit(ctx, "add bar", opts))
+	require.NoError(t, other.Push(ctx, "origin", nil))
+
+	require.NoError(t, client.Fetch(ctx, "origin", nil))
+	repo, err := gogit.PlainOpen(dir)
+	require.NoError(t, err)
+	fetched, err := repo.Reference(plumbing.NewRemoteReferenceName("origin", branch), false)
+	require.NoError(t, err)
+	assert.Equal(t, headHash(t, otherDir), fetched.Hash())
+
+	// set-head
+	require.NoError(t, client.SetRemoteHead(ctx, "origin"))
+	remoteHead, err := repo.Reference(plumbing.NewRemoteHEADReferenceName("origin"), false)
+	require.NoError(t, err)
+	assert.Equal(t, plumbing.NewRemoteReferenceName("origin", branch), remoteHead.Target())
+
+	// upstream
+	require.NoError(t, setUpstream(repo, branch, "", ""))
+	require.NoError(t, client.SetUpstream(ctx, "origin/HEAD"))
+	assertUpstream(t, dir, branch, "origin", branch)
+	assert.Error(t, client.SetUpstream(ctx, "origin/unknown"))
+}
+
+func testConformanceHTTP(t *testing.T, newClient func(dir string, opts ...Option) Client) {
+	t.Helper()
+	ctx := context.Background()
+	opts := CommitOptions{NoSign: true, NoVerify: true}
+
+	dir := t.TempDir()
+	client := newClient(dir)
+	require.NoError(t, client.Init(ctx))
+	writeFile(t, dir, "foo.txt", "aaa")
+	require.NoError(t, client.Add(ctx, "."))
+	require.NoError(t, client.Commit(ctx, "add foo", opts))
+	branch := currentTestBranch(t, dir)
+
+	remoteDir, serverURL := newHTTPRemote(t, branch, "x-access-token", "some-token")
+	url := serverURL + "/repo.git"
+	// Never prompt for (or use the host's) credentials.
+	env := WithEnv("GIT_CONFIG_GLOBAL="+os.DevNull, "GIT_CONFIG_NOSYSTEM=1", "GIT_TERMINAL_PROMPT=0")
+	credentials := WithCredentials(serverURL, func() (string, string) {
+		return "x-access-token", "some-token"
+	})
+
+	// without credentials
+	anonymous := newClient(dir, env, WithTimeout(10*time.Second))
+	require.NoError(t, anonymous.AddRemote(ctx, "origin", url))
+	assert.Error(t, anonymous.Push(ctx, "origin", nil))
+
+	// push
+	client = newClient(dir, env, credentials)
+	require.NoError(t, client.Push(ctx, "origin", nil))
+	remote, err := gogit.PlainOpen(remoteDir)
+	require.NoError(t, err)
+	pushed, err := remote.Reference(plumbing.NewBranchReferenceName(branch), false)
+	require.NoError(t, err)
+	assert.Equal(t, headHash(t, dir), pushed.Hash())
+
+	// clone, and push from the clone
+	otherDir := filepath.Join(t.TempDir(), "other")
+	other := newClient(otherDir, env, credentials)
+	require.NoError(t, other.Clone(ctx, url, nil))
+	writeFile(t, otherDir, "bar.txt", "bbb")
+	require.NoError(t, other.Add(ctx, "."))
+	require.NoError(t, other.Commit(ctx, "add bar", opts))
+	require.NoError(t, other.Push(ctx, "origin", nil))
+
+	// fetch
+	require.NoError(t, client.Fetch(ctx, "origin", nil))
+	repo, err := gogit.PlainOpen(dir)
+	require.NoError(t, err)
+	fetched, err := repo.Reference(plumbing.NewRemoteReferenceName("origin", branch), false)
+	require.NoError(t, err)
+	assert.Equal(t, headHash(t, otherDir), fetched.Hash())
+}
+
+func testConformanceConfig(t *testing.T, newClient func(dir string, opts ...Option) Client) {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", home)
+	require.NoError(t, os.MkdirAll(filepath.Join(home, "git"), 0750))
+	require.NoError(t, os.WriteFile(filepath.Join(home, "git", "config"), []byte("[gh-setup]\n\tglobal = true\n"), 0600))
+
+	dir := t.TempDir()
+	client := newClient(dir)
+	require.NoError(t, client.Init(context.Background()))
+
+	f, err := os.OpenFile(filepath.Join(dir, ".git", "config"), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	require.NoError(t, err)
+	_, err = f.WriteString("[user]\n\temail = local@example.com\n" +
+		"[url \"git@example.com:\"]\n\tinsteadOf = https://example.com/\n")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	value, err := client.Config("user.email")
+	assert.NoError(t, err)
+	assert.Equal(t, "local@example.com", value)
+
+	value, err = client.Config("url.git@example.com:.insteadof")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://example.com/", value)
+
+	value, err = client.Config("gh-setup.unset")
+	assert.NoError(t, err)
+	assert.Equal(t, "", value)
+
+	value, err = client.Config("gh-setup.global")
+	assert.NoError(t, err)
+	assert.Equal(t, "true", value)
+
+	_, err = client.Config("invalid")
+	assert.Error(t, err)
+
+	// local only
+	value, err = client.LocalConfig("user.email")
+	assert.NoError(t, err)
+	assert.Equal(t, "local@example.com", value)
+
+	value, err = client.LocalConfig("gh-setup.global")
+	assert.NoError(t, err)
+	assert.Equal(t, "", value)
+
+	_, err = client.LocalConfig("invalid")
+	assert.Error(t, err)
+
+	// set (and overwrite)
+	ctx := context.Background()
+	require.NoError(t, client.SetConfig(ctx, "pull.rebase", "false"))
+	require.NoError(t, client.SetConfig(ctx, "pull.rebase", "true"))
+	value, err = client.Config("pull.rebase")
+	assert.NoError(t, err)
+	assert.Equal(t, "true", value)
+
+	require.NoError(t, client.SetConfig(ctx, "url.git@example.com:.insteadOf", "https://example.org/"))
+	value, err = client.Config("url.git@example.com:.insteadof")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://example.org/", value)
+
+	// other settings are left alone
+	value, err = client.Config("user.email")
+	assert.NoError(t, err)
+	assert.Equal(t, "local@example.com", value)
+
+	assert.Error(t, client.SetConfig(ctx, "invalid", "value"))
+}
+
+func testConformanceClone(t *testing.T, newClient func(dir string, opts ...Option) Client) {
+	t.Helper()
+	ctx := context.Background()
+	opts := CommitOptions{NoSign: true, NoVerify: true}
+
+	// empty remote
+	remoteDir := newBareRepo(t, "main")
+	url := "file://" + filepath.ToSlash(remoteDir)
+	emptyDir := filepath.Join(t.TempDir(), "empty")
+	empty := newClient(emptyDir)
+	require.NoError(t, empty.Clone(ctx, url, nil))
+	assert.True(t, empty.IsInitialized())
+	assert.False(t, empty.HasCommits())
+	actual, err := empty.RemoteURL("origin")
+	require.NoError(t, err)
+	assert.Equal(t, url, actual)
+
+	// push some commits to it from elsewhere
+	srcDir := t.TempDir()
+	src := newClient(srcDir)
+	require.NoError(t, src.Init(ctx))
+	writeFile(t, srcDir, "foo.txt", "aaa")
+	require.NoError(t, src.Add(ctx, "."))
+	require.NoError(t, src.Commit(ctx, "add foo", opts))
+	branch := currentTestBranch(t, srcDir)
+	remoteDir = newBareRepo(t, branch)
+	url = "file://" + filepath.ToSlash(remoteDir)
+	require.NoError(t, src.AddRemote(ctx, "origin", url))
+	require.NoError(t, src.Push(ctx, "origin", nil))
+
+	// clone into a new dir
+	dir := filepath.Join(t.TempDir(), "nested", "clone")
+	client := newClient(dir)
+	require.NoError(t, client.Clone(ctx, url, nil))
+	assert.True(t, client.HasCommits())
+	assert.Equal(t, headHash(t, srcDir), headHash(t, dir))
+	assert.Equal(t, branch, currentTestBranch(t, dir))
+	content, err := os.ReadFile(filepath.Join(dir, "foo.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "aaa", string(content))
+	assertStatus(t, client)
+	assertUpstream(t, dir, branch, "origin", branch)
+
+	// clone into a non-empty dir
+	assert.Error(t, newClient(srcDir).Clone(ctx, url, nil))
+}
+
+func testConformanceCheckout(t *testing.T, newClient func(dir string, opts ...Option) Client) {
+	t.Helper()
+	ctx := context.Background()
+
+	// a remote with some commits
+	srcDir := t.TempDir()
+	src := newClient(srcDir)
+	require.NoError(t, src.Init(ctx))
+	writeFile(t, srcDir, "foo.txt", "aaa")
+	require.NoError(t, os.MkdirAll(filepath.Join(srcDir, "bin"), 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(srcDir, "bin", "run"), []byte("#!/bin/sh"), 0o700))
+	require.NoError(t, src.Add(ctx, "."))
+	require.NoError(t, src.Commit(ctx, "add foo", CommitOptions{NoSign: true, NoVerify: true}))
+	branch := currentTestBranch(t, srcDir)
+	remoteDir := newBareRepo(t, branch)
+	url := "file://" + filepath.ToSlash(remoteDir)
+	require.NoError(t, src.AddRemote(ctx, "origin", url))
+	require.NoError(t, src.Push(ctx, "origin", nil))
+
+	// a new repo with untracked files
+	dir := t.TempDir()
+	client := newClient(dir)
+	require.NoError(t, client.Init(ctx))
+	require.NoError(t, client.AddRemote(ctx, "upstream", url))
+	require.NoError(t, client.Fetch(ctx, "upstream", nil))
+	writeFile(t, dir, "bar.txt", "bbb")
+
+	assert.Error(t, client.Checkout(ctx, branch, "upstream/missing"))
+	require.NoError(t, client.Checkout(ctx, branch, "upstream/"+branch))
+	assert.True(t, client.HasCommits())
+	assert.Equal(t, branch, currentTestBranch(t, dir))
+	assert.Equal(t, headHash(t, srcDir), headHash(t, dir))
+	assertUpstream(t, dir, branch, "upstream", branch)
+	assertStatus(t, client, "?? bar.txt")
+	info, err := os.Stat(filepath.Join(dir, "bin", "run"))
+	require.NoError(t, err)
+	assert.NotZero(t, info.Mode()&0o100, "keeps the executable bit")
+
+	// untracked files are not overwritten
+	dir = t.TempDir()
+	client = newClient(dir)
+	require.NoError(t, client.Init(ctx))
+	require.NoError(t, client.AddRemote(ctx, "upstream", url))
+	require.NoError(t, client.Fetch(ctx, "upstream", nil))
+	writeFile(t, dir, "foo.txt", "mine")
+
+	assert.Error(t, client.Checkout(ctx, branch, "upstream/"+branch))
+	assert.False(t, client.HasCommits())
+	content, err := os.ReadFile(filepath.Join(dir, "foo.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "mine", string(content))
+}
+
+func writeFile(t *testing.T, dir string, name string, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0600))
+}
+
+func assertStatus(t *testing.T, client Client, expected ...string) {
+	t.Helper()
+	if expected == nil {
+		expected = []string{}
+	}
+	lines, err := client.StatusLines()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, lines)
+	assert.Equal(t, len(expected) > 0, client.IsDirty())
+}
+
+// newBareRepo creates a bare repo whose HEAD points to branch.
+func newBareRepo(t *testing.T, branch string) string {
+	t.Helper()
+	dir := t.TempDir()
+	repo, err := gogit.PlainInit(dir, true)
+	require.NoError(t, err)
+	require.NoError(t, repo.Storer.SetReference(
+		plumbing.NewSymbolicReference(plumbing.HEAD, plumbing.NewBranchReferenceName(branch)),
+	))
+	return dir
+}
+
+// newHTTPRemote serves a bare repo (whose HEAD points to branch) as
+// repo.git over smart HTTP, requiring basic auth with username and password.
+// It returns the repo dir and the server URL.
+func newHTTPRemote(t *testing.T, branch string, username string, password string) (string, string) {
+	t.Helper()
+	stdout, _, err := Exec("--exec-path")
+	require.NoError(t, err)
+	backend := filepath.Join(strings.TrimSpace(stdout.String()), "git-http-backend")
+	if _, err := os.Stat(backend); err != nil {
+		t.Skipf("git-http-backend not installed: %s", err)
+	}
+
+	root := t.TempDir()
+	dir := filepath.Join(root, "repo.git")
+	repo, err := gogit.PlainInit(dir, true)
+	require.NoError(t, err)
+	require.NoError(t, repo.Storer.SetReference(
+		plumbing.NewSymbolicReference(plumbing.HEAD, plumbing.NewBranchReferenceName(branch)),
+	))
+	cfg, err := repo.Config()
+	require.NoError(t, err)
+	cfg.Raw.Section("http").SetOption("receivepack", "true")
+	require.NoError(t, repo.SetConfig(cfg))
+
+	handler := &cgi.Handler{
+		Path: backend,
+		Env:  []string{"GIT_PROJECT_ROOT=" + root, "GIT_HTTP_EXPORT_ALL=1"},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if u, p, ok := r.BasicAuth(); !ok || u != username || p != password {
+			w.Header().Set("WWW-Authenticate", `Basic realm="test"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+	return dir, server.URL
+}
+
+func currentTestBranch(t *testing.T, dir string) string {
+	t.Helper()
+	repo, err := gogit.PlainOpen(dir)
+	require.NoError(t, err)
+	branch, err := currentBranch(repo)
+	require.NoError(t, err)
+	return branch.Short()
+}
+
+func headHash(t *testing.T, dir string) plumbing.Hash {
+	t.Helper()
+	repo, err := gogit.PlainOpen(dir)
+	require.NoError(t, err)
+	head, err := repo.Head()
+	require.NoError(t, err)
+	return head.Hash()
+}
+
+func assertUpstream(t *testing.T, dir string, branch string, remote string, merge string) {
+	t.Helper()
+	repo, err := gogit.PlainOpen(dir)
+	require.NoError(t, err)
+	cfg, err := repo.Config()
+	require.NoError(t, err)
+	require.Contains(t, cfg.Branches, branch)
+	assert.Equal(t, remote, cfg.Branches[branch].Remote)
+	assert.Equal(t, plumbing.NewBranchReferenceName(merge), cfg.Branches[branch].Merge)
+}
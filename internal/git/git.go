@@ -2,33 +2,275 @@ package git
 
 import (
 	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
 )
 
 //go:generate moq -rm -out client_mock.go . Client
 
-// A git CLI client.
+// A git client.
 type Client interface {
+	// Add stages paths for the next commit.
+	Add(ctx context.Context, paths ...string) error
+	// AddRemote adds a remote named name for url.
+	AddRemote(ctx context.Context, name string, url string) error
+	// Checkout creates branch at upstream (a remote ref such as "upstream/main"),
+	// tracking it, and checks it out. Untracked files are kept, but (like git)
+	// it fails rather than overwrite them.
+	Checkout(ctx context.Context, branch string, upstream string) error
+	// Clone clones url into the working dir (which is created if missing).
+	// If progress is non-nil, it is called with progress updates.
+	Clone(ctx context.Context, url string, progress ProgressFunc) error
+	// Commit commits the staged changes with message.
+	Commit(ctx context.Context, message string, opts CommitOptions) error
+	// Config returns the value of the git config key (e.g. "user.email"),
+	// or an empty string if it is not set.
+	Config(key string) (string, error)
 	// Exec executes git with args.
 	Exec(args ...string) (bytes.Buffer, bytes.Buffer, error)
+	// ExecContext executes git with args, interrupting it when ctx is done.
+	ExecContext(ctx context.Context, args ...string) (bytes.Buffer, bytes.Buffer, error)
+	// Fetch fetches from remote.
+	// If progress is non-nil, it is called with progress updates.
+	Fetch(ctx context.Context, remote string, progress ProgressFunc) error
+	// Getenv returns the value of the environment variable key as seen by git
+	// (the client env, falling back to the process env).
+	Getenv(key string) string
 	// HasCommits returns true if there are commits in the working dir.
 	HasCommits() bool
 	// HasRemote returns true if name has been configured as a remote.
 	HasRemote(name string) bool
+	// Init initializes a repo in the working dir.
+	Init(ctx context.Context) error
 	// IsDirty returns true if there are uncommitted files.
 	IsDirty() bool
 	// IsInitialized returns true if the working dir has been initialized.
 	IsInitialized() bool
 	// IsInstalled returns true if git is installed.
 	IsInstalled() bool
+	// LocalConfig returns the value of the git config key in the local repo
+	// config (ignoring the global and system ones), or an empty string if it is not set.
+	LocalConfig(key string) (string, error)
+	// Push pushes the current branch to remote and sets it as the upstream.
+	// If progress is non-nil, it is called with progress updates.
+	Push(ctx context.Context, remote string, progress ProgressFunc) error
+	// RemoteURL returns the URL of the remote named name.
+	RemoteURL(name string) (string, error)
+	// SetConfig sets the git config key (e.g. "pull.rebase")
+	// to value in the local repo config.
+	SetConfig(ctx context.Context, key string, value string) error
+	// SetRemoteHead sets `<remote>/HEAD` to the default branch of remote.
+	SetRemoteHead(ctx context.Context, remote string) error
+	// SetRemoteURL changes the URL of the remote named name.
+	SetRemoteURL(ctx context.Context, name string, url string) error
+	// SetUpstream sets the upstream of the current branch
+	// (upstream is a remote ref such as "origin/main" or "origin/HEAD").
+	SetUpstream(ctx context.Context, upstream string) error
 	// StatusLines returns the result of `git status --porcelain`.
 	StatusLines() ([]string, error)
 }
 
+// CommitOptions configures Client.Commit.
+type CommitOptions struct {
+	// Skip the pre-commit and commit-msg hooks.
+	NoVerify bool
+	// Do not sign the commit (even if commit.gpgsign is set).
+	NoSign bool
+}
+
+// Backend identifies a Client implementation.
+type Backend string
+
+const (
+	// BackendAuto uses the git binary if it is installed,
+	// falling back to the pure Go implementation if not.
+	BackendAuto Backend = "auto"
+	// BackendSystem uses the git binary.
+	BackendSystem Backend = "system"
+	// BackendGo uses a pure Go implementation of git.
+	BackendGo Backend = "go"
+)
+
+// Backends lists the valid backends.
+var Backends = []Backend{BackendAuto, BackendSystem, BackendGo}
+
+// Validate returns an error if b is not a known backend.
+func (b Backend) Validate() error {
+	for _, backend := range Backends {
+		if b == backend {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid git backend: %s", b)
+}
+
+const (
+	// DefaultTimeout is the default per-command timeout.
+	DefaultTimeout = 10 * time.Minute
+)
+
 var (
 	// DefaultClient is the default Git client.
-	DefaultClient Client = &systemClient{}
+	DefaultClient Client = NewClient("")
+
+	// ErrTimeout is returned (wrapped) when a git command takes longer
+	// than the client timeout. It usually means git is waiting for input
+	// that will never come (a credential prompt, an SSH host key question,
+	// a hardware key touch, etc).
+	ErrTimeout = errors.New("timed out")
+
+	// ErrUnsupported is returned (wrapped) when a backend does not support
+	// an operation.
+	ErrUnsupported = errors.New("not supported")
 )
 
+type options struct {
+	backend        Backend
+	credentials    func() (string, string)
+	credentialsURL string
+	env            []string
+	gitPath        string
+	timeout        time.Duration
+}
+
+// Option configures a Client.
+type Option func(o *options)
+
+// WithBackend sets the client implementation to use.
+// Defaults to BackendAuto.
+func WithBackend(backend Backend) Option {
+	return func(o *options) {
+		o.backend = backend
+	}
+}
+
+// WithCredentials sets a func returning the username and password for
+// the HTTP(S) remotes under baseURL (e.g. "https://github.com").
+// The git binary is given them by a credential helper that replaces
+// the configured ones for baseURL.
+// An empty password means the configured credentials are used.
+func WithCredentials(baseURL string, fn func() (username string, password string)) Option {
+	return func(o *options) {
+		o.credentials = fn
+		o.credentialsURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// WithEnv adds environment variables (in "KEY=value" form) to those
+// inherited from the current process. Typically used for GIT_* overrides
+// such as GIT_AUTHOR_NAME or GIT_SSH_COMMAND.
+func WithEnv(env ...string) Option {
+	return func(o *options) {
+		o.env = append(o.env, env...)
+	}
+}
+
+// WithGitPath sets the git binary to use.
+// Defaults to the first git executable in PATH.
+func WithGitPath(path string) Option {
+	return func(o *options) {
+		o.gitPath = path
+	}
+}
+
+// WithTimeout sets the maximum time a single git command may run.
+// A zero timeout means commands may run indefinitely.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.timeout = timeout
+	}
+}
+
+// NewClient returns a Client that operates on the repo in dir.
+// If dir is empty, then the current working directory is used.
+func NewClient(dir string, opts ...Option) Client {
+	o := options{
+		backend: BackendAuto,
+		timeout: DefaultTimeout,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	system := &systemClient{
+		dir:            dir,
+		env:            o.env,
+		gitPath:        o.gitPath,
+		timeout:        o.timeout,
+		credentials:    o.credentials,
+		credentialsURL: o.credentialsURL,
+	}
+	switch o.backend { //nolint: exhaustive
+	case BackendGo:
+		return newGoClient(dir, o)
+	case BackendAuto:
+		if !system.IsInstalled() {
+			return newGoClient(dir, o)
+		}
+	}
+	return system
+}
+
+// Add stages paths for the next commit.
+func Add(ctx context.Context, paths ...string) error {
+	return DefaultClient.Add(ctx, paths...)
+}
+
+// AddRemote adds a remote named name for url.
+func AddRemote(ctx context.Context, name string, url string) error {
+	return DefaultClient.AddRemote(ctx, name, url)
+}
+
+// Checkout creates branch at upstream, tracking it, and checks it out.
+func Checkout(ctx context.Context, branch string, upstream string) error {
+	return DefaultClient.Checkout(ctx, branch, upstream)
+}
+
+// Clone clones url into the working dir (which is created if missing).
+func Clone(ctx context.Context, url string, progress ProgressFunc) error {
+	return DefaultClient.Clone(ctx, url, progress)
+}
+
+// Commit commits the staged changes with message.
+func Commit(ctx context.Context, message string, opts CommitOptions) error {
+	return DefaultClient.Commit(ctx, message, opts)
+}
+
+// Config returns the value of the git config key (e.g. "user.email"),
+// or an empty string if it is not set.
+func Config(key string) (string, error) {
+	return DefaultClient.Config(key)
+}
+
+// Exec executes git with args.
+// Note that any errors returned also include stderr text.
+func Exec(args ...string) (bytes.Buffer, bytes.Buffer, error) {
+	return DefaultClient.Exec(args...)
+}
+
+// ExecContext executes git with args, interrupting it when ctx is done.
+// Errors wrap [ErrTimeout] if the command timed out,
+// or ctx.Err() if ctx was canceled.
+func ExecContext(ctx context.Context, args ...string) (bytes.Buffer, bytes.Buffer, error) {
+	return DefaultClient.ExecContext(ctx, args...)
+}
+
+// Fetch fetches from remote.
+// If progress is non-nil, it is called with progress updates.
+func Fetch(ctx context.Context, remote string, progress ProgressFunc) error {
+	return DefaultClient.Fetch(ctx, remote, progress)
+}
+
+// Getenv returns the value of the environment variable key as seen by git
+// (the client env, falling back to the process env).
+func Getenv(key string) string {
+	return DefaultClient.Getenv(key)
+}
+
 // HasCommits returns true if there are commits in the working dir.
 func HasCommits() bool {
 	return DefaultClient.HasCommits()
@@ -39,10 +281,9 @@ func HasRemote(name string) bool {
 	return DefaultClient.HasRemote(name)
 }
 
-// Exec executes git with args.
-// Note that any errors returned also include stderr text.
-func Exec(args ...string) (bytes.Buffer, bytes.Buffer, error) {
-	return DefaultClient.Exec(args...)
+// Init initializes a repo in the working dir.
+func Init(ctx context.Context) error {
+	return DefaultClient.Init(ctx)
 }
 
 // IsDirty returns true if there are uncommitted files.
@@ -60,7 +301,81 @@ func IsInstalled() bool {
 	return DefaultClient.IsInstalled()
 }
 
+// LocalConfig returns the value of the git config key in the local repo
+// config (ignoring the global and system ones), or an empty string if it is not set.
+func LocalConfig(key string) (string, error) {
+	return DefaultClient.LocalConfig(key)
+}
+
+// Push pushes the current branch to remote and sets it as the upstream.
+// If progress is non-nil, it is called with progress updates.
+func Push(ctx context.Context, remote string, progress ProgressFunc) error {
+	return DefaultClient.Push(ctx, remote, progress)
+}
+
+// RemoteURL returns the URL of the remote named name.
+func RemoteURL(name string) (string, error) {
+	return DefaultClient.RemoteURL(name)
+}
+
+// SetConfig sets the git config key to value in the local repo config.
+func SetConfig(ctx context.Context, key string, value string) error {
+	return DefaultClient.SetConfig(ctx, key, value)
+}
+
+// SetRemoteHead sets `<remote>/HEAD` to the default branch of remote.
+func SetRemoteHead(ctx context.Context, remote string) error {
+	return DefaultClient.SetRemoteHead(ctx, remote)
+}
+
+// SetRemoteURL changes the URL of the remote named name.
+func SetRemoteURL(ctx context.Context, name string, url string) error {
+	return DefaultClient.SetRemoteURL(ctx, name, url)
+}
+
+// SetUpstream sets the upstream of the current branch.
+func SetUpstream(ctx context.Context, upstream string) error {
+	return DefaultClient.SetUpstream(ctx, upstream)
+}
+
 // StatusLines returns the result of `git status --porcelain`.
 func StatusLines() ([]string, error) {
 	return DefaultClient.StatusLines()
 }
+
+// splitConfigKey splits a config key such as "url.git@github.com:.insteadOf"
+// into its section, subsection and name.
+func splitConfigKey(key string) (string, string, string, error) {
+	first := strings.Index(key, ".")
+	last := strings.LastIndex(key, ".")
+	if first <= 0 || last == len(key)-1 {
+		return "", "", "", fmt.Errorf("git config: invalid key: %s", key)
+	}
+	if first == last {
+		return key[:first], "", key[last+1:], nil
+	}
+	return key[:first], key[first+1 : last], key[last+1:], nil
+}
+
+// contextError returns the error for an operation that was stopped
+// because ctx was canceled or timed out.
+func contextError(ctx context.Context, name string, timeout time.Duration) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		if timeout > 0 {
+			return fmt.Errorf("%s %w after %s", name, ErrTimeout, timeout)
+		}
+		return fmt.Errorf("%s %w", name, ErrTimeout)
+	}
+	return fmt.Errorf("%s: %w", name, ctx.Err())
+}
+
+// getenv returns the value of key in env (the last one wins),
+// falling back to the process env.
+func getenv(env []string, key string) string {
+	for i := len(env) - 1; i >= 0; i-- {
+		if strings.HasPrefix(env[i], key+"=") {
+			return strings.TrimPrefix(env[i], key+"=")
+		}
+	}
+	return os.Getenv(key)
+}
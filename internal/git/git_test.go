@@ -1,8 +1,13 @@
 package git
 
 import (
+	"context"
+	"errors"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/twelvelabs/termite/testutil"
@@ -47,6 +52,172 @@ func TestExec(t *testing.T) {
 	assert.Contains(t, stdout.String(), "git version")
 }
 
+func TestNewClient(t *testing.T) {
+	t.Run("runs git in dir", func(t *testing.T) {
+		dir := t.TempDir()
+		client := NewClient(dir)
+
+		testutil.InTempDir(t, func(tmpDir string) {
+			assert.False(t, client.IsInitialized())
+			_, _, err := client.Exec("init")
+			assert.NoError(t, err)
+			assert.True(t, client.IsInitialized())
+
+			// the process working dir is untouched
+			assert.False(t, IsInitialized())
+			assert.DirExists(t, filepath.Join(dir, ".git"))
+		})
+	})
+
+	t.Run("adds env vars to the git environment", func(t *testing.T) {
+		dir := t.TempDir()
+		client := NewClient(dir, WithEnv(
+			"GIT_AUTHOR_NAME=Some Author",
+			"GIT_AUTHOR_EMAIL=author@example.com",
+			"GIT_COMMITTER_NAME=Some Committer",
+			"GIT_COMMITTER_EMAIL=committer@example.com",
+		))
+		_, _, err := client.Exec("init")
+		assert.NoError(t, err)
+		_, _, err = client.Exec("commit", "--allow-empty", "--no-gpg-sign", "--no-verify", "-m", "empty")
+		assert.NoError(t, err)
+
+		stdout, _, err := client.Exec("log", "-1", "--format=%an <%ae> %cn <%ce>")
+		assert.NoError(t, err)
+		assert.Equal(t,
+			"Some Author <author@example.com> Some Committer <committer@example.com>",
+			strings.TrimSpace(stdout.String()),
+		)
+	})
+
+	t.Run("uses the given git binary", func(t *testing.T) {
+		gitPath, err := filepath.Abs(filepath.Join("testdata", "missing-git"))
+		assert.NoError(t, err)
+		client := NewClient("", WithBackend(BackendSystem), WithGitPath(gitPath))
+
+		assert.False(t, client.IsInstalled())
+		_, _, err = client.Exec("--version")
+		assert.ErrorContains(t, err, "could not find git executable "+gitPath)
+
+		client = NewClient("", WithBackend(BackendSystem), WithGitPath("git"))
+		assert.True(t, client.IsInstalled())
+	})
+
+	t.Run("selects the backend", func(t *testing.T) {
+		missing := WithGitPath(filepath.Join("testdata", "missing-git"))
+
+		assert.IsType(t, &systemClient{}, NewClient(""))
+		assert.IsType(t, &systemClient{}, NewClient("", WithBackend(BackendSystem), missing))
+		assert.IsType(t, &goClient{}, NewClient("", WithBackend(BackendGo)))
+		// falls back to go when git is not installed
+		assert.IsType(t, &goClient{}, NewClient("", missing))
+	})
+}
+
+func TestExecContext(t *testing.T) {
+	// An alias that hangs (like git waiting on a credential prompt).
+	hang := []string{"-c", "alias.hang=!sleep 5", "hang"}
+
+	tests := []struct {
+		desc    string
+		timeout time.Duration
+		ctx     func() (context.Context, context.CancelFunc)
+		args    []string
+		err     error
+		errMsg  string
+	}{
+		{
+			desc:    "runs commands that finish within the timeout",
+			timeout: time.Minute,
+			args:    []string{"--version"},
+		},
+		{
+			desc:    "returns a timeout error when the command takes too long",
+			timeout: 100 * time.Millisecond,
+			args:    hang,
+			err:     ErrTimeout,
+			errMsg:  "git hang timed out after 100ms",
+		},
+		{
+			desc:    "returns a timeout error when the context deadline passes",
+			timeout: 0,
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithTimeout(context.Background(), 100*time.Millisecond)
+			},
+			args:   hang,
+			err:    ErrTimeout,
+			errMsg: "git hang timed out",
+		},
+		{
+			desc:    "returns a canceled error when the context is canceled",
+			timeout: time.Minute,
+			ctx: func() (context.Context, context.CancelFunc) {
+				ctx, cancel := context.WithCancel(context.Background())
+				time.AfterFunc(100*time.Millisecond, cancel)
+				return ctx, cancel
+			},
+			args:   hang,
+			err:    context.Canceled,
+			errMsg: "git hang: context canceled",
+		},
+		{
+			desc:    "returns other failures unchanged",
+			timeout: time.Minute,
+			args:    []string{"not-a-command"},
+			errMsg:  "failed to run git",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			original := interruptGracePeriod
+			interruptGracePeriod = 100 * time.Millisecond
+			defer func() { interruptGracePeriod = original }()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			if tt.ctx != nil {
+				ctx, cancel = tt.ctx()
+			}
+			defer cancel()
+
+			client := NewClient("", WithTimeout(tt.timeout))
+			start := time.Now()
+			_, _, err := client.ExecContext(ctx, tt.args...)
+
+			assert.Less(t, time.Since(start), 3*time.Second)
+			if tt.errMsg == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.errMsg)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+			}
+			if tt.err != ErrTimeout {
+				assert.False(t, errors.Is(err, ErrTimeout))
+			}
+		})
+	}
+}
+
+func TestPushProgress(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	client := NewClient(dir, WithBackend(BackendSystem), WithEnv(testIdentity...))
+	assert.NoError(t, client.Init(ctx))
+	writeFile(t, dir, "foo.txt", "aaa")
+	assert.NoError(t, client.Add(ctx, "."))
+	assert.NoError(t, client.Commit(ctx, "add foo", CommitOptions{NoSign: true, NoVerify: true}))
+	remoteDir := newBareRepo(t, currentTestBranch(t, dir))
+	assert.NoError(t, client.AddRemote(ctx, "origin", "file://"+filepath.ToSlash(remoteDir)))
+
+	var phases []string
+	err := client.Push(ctx, "origin", func(p Progress) {
+		phases = append(phases, p.Phase)
+	})
+	assert.NoError(t, err)
+	assert.Contains(t, phases, "Writing objects")
+}
+
 func TestIsInitialized(t *testing.T) {
 	testutil.InTempDir(t, func(tmpDir string) {
 		assert.False(t, IsInitialized())
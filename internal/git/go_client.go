@@ -0,0 +1,725 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	format "github.com/go-git/go-git/v5/plumbing/format/config"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/client"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/plumbing/transport/server"
+)
+
+var installFileTransport sync.Once
+
+// goClient is a Client implemented with go-git, for use where the git
+// binary is not installed. It supports the operations gh-setup needs
+// (but not Exec), over file://, HTTP(S) and SSH remotes.
+//
+// Commits are never signed and hooks are never run.
+type goClient struct {
+	dir            string
+	env            []string
+	timeout        time.Duration
+	credentials    func() (string, string)
+	credentialsURL string
+}
+
+var _ Client = &goClient{}
+
+func newGoClient(dir string, o options) *goClient {
+	// Serve file:// remotes in-process rather than by running
+	// git-upload-pack and git-receive-pack (which may not be installed).
+	installFileTransport.Do(func() {
+		client.InstallProtocol("file", server.DefaultServer)
+	})
+	return &goClient{
+		dir:            dir,
+		env:            o.env,
+		timeout:        o.timeout,
+		credentials:    o.credentials,
+		credentialsURL: o.credentialsURL,
+	}
+}
+
+func (c *goClient) Add(ctx context.Context, paths ...string) error {
+	_, wt, err := c.worktree()
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		if path == "." {
+			err = wt.AddWithOptions(&gogit.AddOptions{All: true})
+		} else {
+			_, err = wt.Add(path)
+		}
+		if err != nil {
+			return fmt.Errorf("git add %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+func (c *goClient) AddRemote(ctx context.Context, name string, url string) error {
+	repo, err := c.open()
+	if err != nil {
+		return err
+	}
+	_, err = repo.CreateRemote(&config.RemoteConfig{
+		Name: name,
+		URLs: []string{url},
+	})
+	if err != nil {
+		return fmt.Errorf("git remote add %s: %w", name, err)
+	}
+	return nil
+}
+
+func (c *goClient) Checkout(ctx context.Context, branch string, upstream string) error {
+	repo, wt, err := c.worktree()
+	if err != nil {
+		return err
+	}
+	name := plumbing.NewBranchReferenceName(branch)
+	if _, err := repo.Reference(name, false); err == nil {
+		return fmt.Errorf("git checkout: a branch named '%s' already exists", branch)
+	}
+	ref, err := repo.Reference(plumbing.ReferenceName("refs/remotes/"+upstream), true)
+	if err != nil {
+		return fmt.Errorf("git checkout: '%s' is not a commit", upstream)
+	}
+	commit, err := repo.CommitObject(ref.Hash())
+	if err != nil {
+		return fmt.Errorf("git checkout: %w", err)
+	}
+	// go-git's checkout removes untracked files, so write the files of the
+	// commit here, then point the branch, HEAD and index at it.
+	if err := checkoutFiles(wt, commit); err != nil {
+		return fmt.Errorf("git checkout: %w", err)
+	}
+	refs := []*plumbing.Reference{
+		plumbing.NewHashReference(name, commit.Hash),
+		plumbing.NewSymbolicReference(plumbing.HEAD, name),
+	}
+	for _, r := range refs {
+		if err := repo.Storer.SetReference(r); err != nil {
+			return fmt.Errorf("git checkout: %w", err)
+		}
+	}
+	if err := wt.Reset(&gogit.ResetOptions{Commit: commit.Hash, Mode: gogit.MixedReset}); err != nil {
+		return fmt.Errorf("git checkout: %w", err)
+	}
+	return c.SetUpstream(ctx, upstream)
+}
+
+func (c *goClient) Clone(ctx context.Context, url string, progress ProgressFunc) error {
+	root, err := c.root()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	opts := &gogit.CloneOptions{
+		URL:  url,
+		Auth: c.urlAuth(url),
+	}
+	if progress != nil {
+		opts.Progress = newProgressWriter(progress)
+	}
+	_, err = gogit.PlainCloneContext(ctx, root, false, opts)
+	if errors.Is(err, transport.ErrEmptyRemoteRepository) || errors.Is(err, plumbing.ErrReferenceNotFound) {
+		// The remote is empty (or its HEAD points to an unborn branch).
+		// go-git removes the new repo, whereas git leaves it initialized
+		// with just the remote.
+		if err := c.Init(ctx); err != nil {
+			return err
+		}
+		return c.AddRemote(ctx, "origin", url)
+	}
+	return c.wrap(ctx, "git clone", err)
+}
+
+func (c *goClient) Commit(ctx context.Context, message string, opts CommitOptions) error {
+	_, wt, err := c.worktree()
+	if err != nil {
+		return err
+	}
+	_, err = wt.Commit(message, &gogit.CommitOptions{
+		Author:    c.signature("AUTHOR"),
+		Committer: c.signature("COMMITTER"),
+	})
+	if errors.Is(err, gogit.ErrMissingAuthor) {
+		return fmt.Errorf("git commit: %w (set user.name and user.email in your git config)", err)
+	}
+	if err != nil {
+		return fmt.Errorf("git commit: %w", err)
+	}
+	return nil
+}
+
+func (c *goClient) Config(key string) (string, error) {
+	return c.config(key, config.GlobalScope, config.SystemScope)
+}
+
+// config returns the value of key in the local config (if in a repo),
+// falling back to the config of scopes (in order).
+func (c *goClient) config(key string, scopes ...config.Scope) (string, error) {
+	section, subsection, name, err := splitConfigKey(key)
+	if err != nil {
+		return "", err
+	}
+	// The local config (if in a repo) takes precedence over global and system.
+	raws := []*format.Config{}
+	if repo, err := c.open(); err == nil {
+		cfg, err := repo.Config()
+		if err != nil {
+			return "", fmt.Errorf("git config: %w", err)
+		}
+		raws = append(raws, cfg.Raw)
+	}
+	for _, scope := range scopes {
+		cfg, err := config.LoadConfig(scope)
+		if err != nil {
+			return "", fmt.Errorf("git config: %w", err)
+		}
+		raws = append(raws, cfg.Raw)
+	}
+	for _, raw := range raws {
+		if value, ok := configOption(raw, section, subsection, name); ok {
+			return value, nil
+		}
+	}
+	return "", nil
+}
+
+func (c *goClient) Exec(args ...string) (bytes.Buffer, bytes.Buffer, error) {
+	return c.ExecContext(context.Background(), args...)
+}
+
+func (c *goClient) ExecContext(ctx context.Context, args ...string) (bytes.Buffer, bytes.Buffer, error) {
+	name := strings.TrimSpace("git " + subcommand(args))
+	err := fmt.Errorf("%s: %w by the go backend", name, ErrUnsupported)
+	return bytes.Buffer{}, bytes.Buffer{}, err
+}
+
+func (c *goClient) Fetch(ctx context.Context, remote string, progress ProgressFunc) error {
+	repo, err := c.open()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	opts := &gogit.FetchOptions{
+		RemoteName: remote,
+		Auth:       c.auth(repo, remote),
+	}
+	if progress != nil {
+		opts.Progress = newProgressWriter(progress)
+	}
+	err = repo.FetchContext(ctx, opts)
+	if errors.Is(err, gogit.NoErrAlreadyUpToDate) || errors.Is(err, transport.ErrEmptyRemoteRepository) {
+		return nil
+	}
+	return c.wrap(ctx, "git fetch", err)
+}
+
+func (c *goClient) Getenv(key string) string {
+	return getenv(c.env, key)
+}
+
+func (c *goClient) HasCommits() bool {
+	repo, err := c.open()
+	if err != nil {
+		return false
+	}
+	_, err = repo.Head()
+	return err == nil
+}
+
+func (c *goClient) HasRemote(name string) bool {
+	repo, err := c.open()
+	if err != nil {
+		return false
+	}
+	_, err = repo.Remote(name)
+	return err == nil
+}
+
+func (c *goClient) Init(ctx context.Context) error {
+	root, err := c.root()
+	if err != nil {
+		return err
+	}
+	repo, err := gogit.PlainInit(root, false)
+	if errors.Is(err, gogit.ErrRepositoryAlreadyExists) {
+		return nil // same as re-running `git init`
+	}
+	if err != nil {
+		return fmt.Errorf("git init: %w", err)
+	}
+	// Honor init.defaultBranch (go-git always uses master).
+	global, err := config.LoadConfig(config.GlobalScope)
+	if err == nil && global.Init.DefaultBranch != "" {
+		head := plumbing.NewSymbolicReference(
+			plumbing.HEAD, plumbing.NewBranchReferenceName(global.Init.DefaultBranch),
+		)
+		if err := repo.Storer.SetReference(head); err != nil {
+			return fmt.Errorf("git init: %w", err)
+		}
+	}
+	return nil
+}
+
+func (c *goClient) IsDirty() bool {
+	lines, _ := c.StatusLines()
+	return len(lines) > 0
+}
+
+func (c *goClient) IsInitialized() bool {
+	_, err := c.open()
+	return err == nil
+}
+
+func (c *goClient) IsInstalled() bool {
+	return true // nothing to install
+}
+
+func (c *goClient) LocalConfig(key string) (string, error) {
+	return c.config(key)
+}
+
+func (c *goClient) Push(ctx context.Context, remote string, progress ProgressFunc) error {
+	repo, err := c.open()
+	if err != nil {
+		return err
+	}
+	branch, err := currentBranch(repo)
+	if err != nil {
+		return fmt.Errorf("git push: %w", err)
+	}
+	head, err := repo.Head()
+	if err != nil {
+		return fmt.Errorf("git push: %w", err)
+	}
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	opts := &gogit.PushOptions{
+		RemoteName: remote,
+		RefSpecs:   []config.RefSpec{config.RefSpec(fmt.Sprintf("%s:%s", branch, branch))},
+		Auth:       c.auth(repo, remote),
+	}
+	if progress != nil {
+		// Only the remote reports progress (go-git doesn't for the pack it sends).
+		opts.Progress = newProgressWriter(progress)
+	}
+	err = repo.PushContext(ctx, opts)
+	if err != nil && !errors.Is(err, gogit.NoErrAlreadyUpToDate) {
+		return c.wrap(ctx, "git push", err)
+	}
+
+	// Update the remote tracking branch and set it as the upstream
+	// (as `git push -u` does).
+	tracking := plumbing.NewRemoteReferenceName(remote, branch.Short())
+	if err := repo.Storer.SetReference(plumbing.NewHashReference(tracking, head.Hash())); err != nil {
+		return fmt.Errorf("git push: %w", err)
+	}
+	return setUpstream(repo, branch.Short(), remote, branch)
+}
+
+func (c *goClient) RemoteURL(name string) (string, error) {
+	repo, err := c.open()
+	if err != nil {
+		return "", err
+	}
+	remote, err := repo.Remote(name)
+	if err != nil {
+		return "", fmt.Errorf("git remote get-url %s: %w", name, err)
+	}
+	return remote.Config().URLs[0], nil
+}
+
+func (c *goClient) SetConfig(ctx context.Context, key string, value string) error {
+	section, subsection, name, err := splitConfigKey(key)
+	if err != nil {
+		return err
+	}
+	repo, err := c.open()
+	if err != nil {
+		return err
+	}
+	cfg, err := repo.Config()
+	if err != nil {
+		return fmt.Errorf("git config: %w", err)
+	}
+	if subsection == "" {
+		cfg.Raw.Section(section).SetOption(name, value)
+	} else {
+		cfg.Raw.Section(section).Subsection(subsection).SetOption(name, value)
+	}
+	// Marshaling rewrites some sections (e.g. url) from the typed fields,
+	// so reload them from the updated raw config before saving.
+	var buf bytes.Buffer
+	if err := format.NewEncoder(&buf).Encode(cfg.Raw); err != nil {
+		return fmt.Errorf("git config: %w", err)
+	}
+	updated := config.NewConfig()
+	if err := updated.Unmarshal(buf.Bytes()); err != nil {
+		return fmt.Errorf("git config: %w", err)
+	}
+	if err := repo.SetConfig(updated); err != nil {
+		return fmt.Errorf("git config: %w", err)
+	}
+	return nil
+}
+
+func (c *goClient) SetRemoteHead(ctx context.Context, remote string) error {
+	repo, err := c.open()
+	if err != nil {
+		return err
+	}
+	r, err := repo.Remote(remote)
+	if err != nil {
+		return fmt.Errorf("git remote set-head: %w", err)
+	}
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	refs, err := r.ListContext(ctx, &gogit.ListOptions{
+		Auth: c.auth(repo, remote),
+	})
+	if err != nil {
+		return c.wrap(ctx, "git remote set-head", err)
+	}
+	var target plumbing.ReferenceName
+	for _, ref := range refs {
+		if ref.Name() == plumbing.HEAD && ref.Type() == plumbing.SymbolicReference {
+			target = plumbing.NewRemoteReferenceName(remote, ref.Target().Short())
+		}
+	}
+	if target == "" {
+		return fmt.Errorf("git remote set-head: cannot determine remote HEAD")
+	}
+	if _, err := repo.Reference(target, false); err != nil {
+		return fmt.Errorf("git remote set-head: not a valid ref: %s", target)
+	}
+	head := plumbing.NewSymbolicReference(plumbing.NewRemoteHEADReferenceName(remote), target)
+	if err := repo.Storer.SetReference(head); err != nil {
+		return fmt.Errorf("git remote set-head: %w", err)
+	}
+	return nil
+}
+
+func (c *goClient) SetRemoteURL(ctx context.Context, name string, url string) error {
+	repo, err := c.open()
+	if err != nil {
+		return err
+	}
+	cfg, err := repo.Config()
+	if err != nil {
+		return fmt.Errorf("git remote set-url %s: %w", name, err)
+	}
+	remote, ok := cfg.Remotes[name]
+	if !ok {
+		return fmt.Errorf("git remote set-url %s: %w", name, gogit.ErrRemoteNotFound)
+	}
+	remote.URLs = []string{url}
+	if err := repo.SetConfig(cfg); err != nil {
+		return fmt.Errorf("git remote set-url %s: %w", name, err)
+	}
+	return nil
+}
+
+func (c *goClient) SetUpstream(ctx context.Context, upstream string) error {
+	repo, err := c.open()
+	if err != nil {
+		return err
+	}
+	branch, err := currentBranch(repo)
+	if err != nil {
+		return fmt.Errorf("git branch: %w", err)
+	}
+	ref, err := repo.Reference(plumbing.ReferenceName("refs/remotes/"+upstream), true)
+	if err != nil {
+		return fmt.Errorf("git branch: the requested upstream branch '%s' does not exist", upstream)
+	}
+	remotes, err := repo.Remotes()
+	if err != nil {
+		return fmt.Errorf("git branch: %w", err)
+	}
+	for _, r := range remotes {
+		prefix := fmt.Sprintf("refs/remotes/%s/", r.Config().Name)
+		if strings.HasPrefix(ref.Name().String(), prefix) {
+			merge := plumbing.NewBranchReferenceName(strings.TrimPrefix(ref.Name().String(), prefix))
+			return setUpstream(repo, branch.Short(), r.Config().Name, merge)
+		}
+	}
+	return fmt.Errorf("git branch: no remote found for upstream '%s'", upstream)
+}
+
+func (c *goClient) StatusLines() ([]string, error) {
+	_, wt, err := c.worktree()
+	if err != nil {
+		return []string{}, err
+	}
+	status, err := wt.Status()
+	if err != nil {
+		return []string{}, fmt.Errorf("git status: %w", err)
+	}
+	// Match `git status --porcelain`: tracked files first, then untracked.
+	tracked := []string{}
+	untracked := []string{}
+	for path, s := range status {
+		switch {
+		case s.Worktree == gogit.Untracked:
+			untracked = append(untracked, fmt.Sprintf("?? %s", path))
+		case s.Staging != gogit.Unmodified || s.Worktree != gogit.Unmodified:
+			tracked = append(tracked, fmt.Sprintf("%c%c %s", s.Staging, s.Worktree, path))
+		}
+	}
+	sort.Slice(tracked, func(i, j int) bool { return tracked[i][3:] < tracked[j][3:] })
+	sort.Slice(untracked, func(i, j int) bool { return untracked[i][3:] < untracked[j][3:] })
+	return append(tracked, untracked...), nil
+}
+
+// root returns the working dir.
+func (c *goClient) root() (string, error) {
+	if c.dir != "" {
+		return c.dir, nil
+	}
+	return os.Getwd()
+}
+
+// open opens the repo containing the working dir.
+func (c *goClient) open() (*gogit.Repository, error) {
+	root, err := c.root()
+	if err != nil {
+		return nil, err
+	}
+	return gogit.PlainOpenWithOptions(root, &gogit.PlainOpenOptions{
+		DetectDotGit: true,
+	})
+}
+
+func (c *goClient) worktree() (*gogit.Repository, *gogit.Worktree, error) {
+	repo, err := c.open()
+	if err != nil {
+		return nil, nil, err
+	}
+	wt, err := repo.Worktree()
+	if err != nil {
+		return nil, nil, err
+	}
+	return repo, wt, nil
+}
+
+// auth returns the credentials for remote (or nil to use the defaults).
+func (c *goClient) auth(repo *gogit.Repository, remote string) transport.AuthMethod {
+	if c.credentials == nil {
+		return nil
+	}
+	r, err := repo.Remote(remote)
+	if err != nil || len(r.Config().URLs) == 0 {
+		return nil
+	}
+	return c.urlAuth(r.Config().URLs[0])
+}
+
+// urlAuth returns the credentials for url (or nil to use the defaults).
+func (c *goClient) urlAuth(url string) transport.AuthMethod {
+	if c.credentials == nil {
+		return nil
+	}
+	if !strings.HasPrefix(url, c.credentialsURL+"/") {
+		return nil // another host (ssh uses the agent, file needs nothing)
+	}
+	username, password := c.credentials()
+	if password == "" {
+		return nil
+	}
+	return &githttp.BasicAuth{
+		Username: username,
+		Password: password,
+	}
+}
+
+// signature returns the signature set by GIT_<kind>_NAME and GIT_<kind>_EMAIL
+// (or nil to use the git config).
+func (c *goClient) signature(kind string) *object.Signature {
+	name := c.Getenv("GIT_" + kind + "_NAME")
+	email := c.Getenv("GIT_" + kind + "_EMAIL")
+	if name == "" || email == "" {
+		return nil
+	}
+	return &object.Signature{
+		Name:  name,
+		Email: email,
+		When:  time.Now(),
+	}
+}
+
+func (c *goClient) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.timeout > 0 {
+		return context.WithTimeout(ctx, c.timeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+// wrap adds the operation name to err, converting it to a timeout or
+// cancellation error if ctx is done.
+func (c *goClient) wrap(ctx context.Context, name string, err error) error {
+	if err == nil {
+		return nil
+	}
+	if ctx.Err() != nil {
+		return contextError(ctx, name, c.timeout)
+	}
+	return fmt.Errorf("%s: %w", name, err)
+}
+
+// configOption returns the value of an option in raw (and whether it is set).
+func configOption(raw *format.Config, section string, subsection string, name string) (string, bool) {
+	if raw == nil || !raw.HasSection(section) {
+		return "", false
+	}
+	s := raw.Section(section)
+	opts := s.Options
+	if subsection != "" {
+		if !s.HasSubsection(subsection) {
+			return "", false
+		}
+		opts = s.Subsection(subsection).Options
+	}
+	if !opts.Has(name) {
+		return "", false
+	}
+	return opts.Get(name), true
+}
+
+// checkoutFiles writes the files of commit into the worktree,
+// failing if any would overwrite a different (untracked) file.
+func checkoutFiles(wt *gogit.Worktree, commit *object.Commit) error {
+	tree, err := commit.Tree()
+	if err != nil {
+		return err
+	}
+	missing := []*object.File{}
+	conflicts := []string{}
+	err = tree.Files().ForEach(func(f *object.File) error {
+		contents, err := f.Contents()
+		if err != nil {
+			return err
+		}
+		existing, err := worktreeContents(wt, f.Name)
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+			missing = append(missing, f)
+		case err != nil:
+			conflicts = append(conflicts, f.Name) // e.g. a dir
+		case existing != contents:
+			conflicts = append(conflicts, f.Name)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if len(conflicts) > 0 {
+		return fmt.Errorf(
+			"untracked working tree files would be overwritten: %s", strings.Join(conflicts, ", "),
+		)
+	}
+	for _, f := range missing {
+		if err := writeWorktreeFile(wt, f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// worktreeContents returns the contents of the file (or symlink target)
+// at path in the worktree.
+func worktreeContents(wt *gogit.Worktree, path string) (string, error) {
+	info, err := wt.Filesystem.Lstat(path)
+	if err != nil {
+		return "", err
+	}
+	if info.Mode()&os.ModeSymlink != 0 {
+		return wt.Filesystem.Readlink(path)
+	}
+	file, err := wt.Filesystem.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	contents, err := io.ReadAll(file)
+	return string(contents), err
+}
+
+// writeWorktreeFile writes f to the worktree (creating its parent dirs).
+func writeWorktreeFile(wt *gogit.Worktree, f *object.File) error {
+	contents, err := f.Contents()
+	if err != nil {
+		return err
+	}
+	if f.Mode == filemode.Symlink {
+		return wt.Filesystem.Symlink(contents, f.Name)
+	}
+	perm := os.FileMode(0o644)
+	if f.Mode == filemode.Executable {
+		perm = 0o755
+	}
+	file, err := wt.Filesystem.OpenFile(f.Name, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write([]byte(contents)); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// currentBranch returns the branch checked out in repo.
+func currentBranch(repo *gogit.Repository) (plumbing.ReferenceName, error) {
+	head, err := repo.Storer.Reference(plumbing.HEAD)
+	if err != nil {
+		return "", err
+	}
+	if head.Type() != plumbing.SymbolicReference || !head.Target().IsBranch() {
+		return "", errors.New("HEAD is not on a branch")
+	}
+	return head.Target(), nil
+}
+
+// setUpstream sets the remote and merge ref of branch.
+func setUpstream(repo *gogit.Repository, branch string, remote string, merge plumbing.ReferenceName) error {
+	cfg, err := repo.Config()
+	if err != nil {
+		return err
+	}
+	b, ok := cfg.Branches[branch]
+	if !ok {
+		b = &config.Branch{Name: branch}
+		cfg.Branches[branch] = b
+	}
+	b.Remote = remote
+	b.Merge = merge
+	return repo.SetConfig(cfg)
+}
@@ -0,0 +1,46 @@
+package git
+
+import (
+	"context"
+	"testing"
+
+	gogit "github.com/go-git/go-git/v5"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoClient_Exec(t *testing.T) {
+	client := NewClient(t.TempDir(), WithBackend(BackendGo))
+
+	_, _, err := client.Exec("-c", "core.pager=cat", "log")
+	assert.ErrorIs(t, err, ErrUnsupported)
+	assert.EqualError(t, err, "git log: not supported by the go backend")
+}
+
+func TestGoClient_Auth(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	credentials := func() (string, string) {
+		return "x-access-token", "some-token"
+	}
+	client := newGoClient(dir, options{credentials: credentials, credentialsURL: "https://github.com"})
+	require.NoError(t, client.Init(ctx))
+	require.NoError(t, client.AddRemote(ctx, "https", "https://github.com/some-org/some-repo.git"))
+	require.NoError(t, client.AddRemote(ctx, "ssh", "git@github.com:some-org/some-repo.git"))
+	require.NoError(t, client.AddRemote(ctx, "other", "https://git.example.com/some-org/some-repo.git"))
+	repo, err := gogit.PlainOpen(dir)
+	require.NoError(t, err)
+
+	assert.Equal(t, &githttp.BasicAuth{
+		Username: "x-access-token",
+		Password: "some-token",
+	}, client.auth(repo, "https"))
+	assert.Nil(t, client.auth(repo, "ssh"))
+	assert.Nil(t, client.auth(repo, "other"))
+	assert.Nil(t, client.auth(repo, "unknown"))
+
+	// without a token the defaults are used
+	client.credentials = func() (string, string) { return "x-access-token", "" }
+	assert.Nil(t, client.auth(repo, "https"))
+}
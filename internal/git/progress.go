@@ -0,0 +1,75 @@
+package git
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// progressRE matches git progress lines such as
+// "Writing objects:  45% (9/20), 1.20 MiB | 2.00 MiB/s".
+var progressRE = regexp.MustCompile(`^(?:remote: )?([A-Za-z][A-Za-z ]*):\s+(\d+)% \((\d+)/(\d+)\)`)
+
+// Progress is a progress update from a long running git operation.
+type Progress struct {
+	// The phase of the operation (e.g. "Counting objects").
+	Phase string
+	// The percent complete.
+	Percent int
+	// The number of items processed.
+	Current int
+	// The total number of items.
+	Total int
+}
+
+func (p Progress) String() string {
+	return fmt.Sprintf("%s %d%% (%d/%d)", p.Phase, p.Percent, p.Current, p.Total)
+}
+
+// ProgressFunc is called with progress updates.
+type ProgressFunc func(p Progress)
+
+// progressWriter is an io.Writer that parses the progress lines git writes
+// to stderr (each update ends in a carriage return, each phase in a newline)
+// and calls fn for each update.
+type progressWriter struct {
+	fn  ProgressFunc
+	buf []byte
+}
+
+func newProgressWriter(fn ProgressFunc) *progressWriter {
+	return &progressWriter{fn: fn}
+}
+
+func (w *progressWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexAny(w.buf, "\r\n")
+		if i < 0 {
+			break
+		}
+		if progress, ok := parseProgress(string(w.buf[:i])); ok {
+			w.fn(progress)
+		}
+		w.buf = w.buf[i+1:]
+	}
+	return len(p), nil
+}
+
+// parseProgress parses a git progress line.
+func parseProgress(line string) (Progress, bool) {
+	m := progressRE.FindStringSubmatch(line)
+	if m == nil {
+		return Progress{}, false
+	}
+	percent, _ := strconv.Atoi(m[2])
+	current, _ := strconv.Atoi(m[3])
+	total, _ := strconv.Atoi(m[4])
+	return Progress{
+		Phase:   m[1],
+		Percent: percent,
+		Current: current,
+		Total:   total,
+	}, true
+}
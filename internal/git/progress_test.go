@@ -0,0 +1,60 @@
+package git
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProgressWriter(t *testing.T) {
+	tests := []struct {
+		desc     string
+		chunks   []string
+		expected []Progress
+	}{
+		{
+			desc:     "ignores non-progress output",
+			chunks:   []string{"To github.com:org/repo.git\n * [new branch]      HEAD -> main\n"},
+			expected: nil,
+		},
+		{
+			desc: "parses carriage return separated updates",
+			chunks: []string{
+				"Counting objects:  50% (1/2)\rCounting objects: 100% (2/2), done.\n",
+				"Writing objects: 100% (2/2), 215 bytes | 215.00 KiB/s, done.\n",
+			},
+			expected: []Progress{
+				{Phase: "Counting objects", Percent: 50, Current: 1, Total: 2},
+				{Phase: "Counting objects", Percent: 100, Current: 2, Total: 2},
+				{Phase: "Writing objects", Percent: 100, Current: 2, Total: 2},
+			},
+		},
+		{
+			desc:   "parses remote and split updates",
+			chunks: []string{"remote: Compressing obj", "ects:  33% (1/3)\r", "incomplete: 1% (1/100)"},
+			expected: []Progress{
+				{Phase: "Compressing objects", Percent: 33, Current: 1, Total: 3},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var actual []Progress
+			w := newProgressWriter(func(p Progress) {
+				actual = append(actual, p)
+			})
+			for _, chunk := range tt.chunks {
+				n, err := io.WriteString(w, chunk)
+				assert.NoError(t, err)
+				assert.Equal(t, len(chunk), n)
+			}
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestProgress_String(t *testing.T) {
+	p := Progress{Phase: "Writing objects", Percent: 45, Current: 9, Total: 20}
+	assert.Equal(t, "Writing objects 45% (9/20)", p.String())
+}
@@ -2,27 +2,152 @@ package git
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"os"
 	"os/exec"
 	"strings"
+	"time"
 
 	"github.com/cli/safeexec"
 )
 
+const (
+	// A credential helper returning the credentials in the env
+	// (so that they don't show up in the process list).
+	credentialHelper = `!f() { test "$1" = get && ` +
+		`echo "username=${GH_SETUP_GIT_USERNAME}" && echo "password=${GH_SETUP_GIT_PASSWORD}"; }; f`
+)
+
+var (
+	// for stubbing
+	interruptGracePeriod = 5 * time.Second
+)
+
 type systemClient struct {
+	dir            string
+	env            []string
+	gitPath        string
+	timeout        time.Duration
+	credentials    func() (string, string)
+	credentialsURL string
+}
+
+var _ Client = &systemClient{}
+
+func (c *systemClient) Add(ctx context.Context, paths ...string) error {
+	args := append([]string{"add", "--"}, paths...)
+	_, _, err := c.ExecContext(ctx, args...)
+	return err
+}
+
+func (c *systemClient) AddRemote(ctx context.Context, name string, url string) error {
+	_, _, err := c.ExecContext(ctx, "remote", "add", name, url)
+	return err
+}
+
+func (c *systemClient) Checkout(ctx context.Context, branch string, upstream string) error {
+	_, _, err := c.ExecContext(ctx, "checkout", "-b", branch, "--track", upstream)
+	return err
+}
+
+func (c *systemClient) Clone(ctx context.Context, url string, progress ProgressFunc) error {
+	// Clone into the (empty) client dir, so that git runs there like
+	// every other command.
+	if c.dir != "" {
+		if err := os.MkdirAll(c.dir, 0o755); err != nil {
+			return fmt.Errorf("git clone: %w", err)
+		}
+	}
+	if progress == nil {
+		_, _, err := c.ExecContext(ctx, "clone", "--", url, ".")
+		return err
+	}
+	_, _, err := c.exec(ctx, newProgressWriter(progress), "clone", "--progress", "--", url, ".")
+	return err
+}
+
+func (c *systemClient) Commit(ctx context.Context, message string, opts CommitOptions) error {
+	args := []string{"commit", "-m", message}
+	if opts.NoSign {
+		args = append(args, "--no-gpg-sign")
+	}
+	if opts.NoVerify {
+		args = append(args, "--no-verify")
+	}
+	_, _, err := c.ExecContext(ctx, args...)
+	return err
+}
+
+func (c *systemClient) Config(key string) (string, error) {
+	return c.config(key)
+}
+
+// config returns the value of key in the config files selected by flags
+// (e.g. "--local"), or all of them if there are none.
+func (c *systemClient) config(key string, flags ...string) (string, error) {
+	// git exits with 1 for both invalid and unset keys, so check up front.
+	if _, _, _, err := splitConfigKey(key); err != nil {
+		return "", err
+	}
+	args := append([]string{"config"}, flags...)
+	stdout, _, err := c.Exec(append(args, "--get", key)...)
+	exitErr := &exec.ExitError{}
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+		return "", nil // key not set
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(stdout.String()), nil
 }
 
 func (c *systemClient) Exec(args ...string) (bytes.Buffer, bytes.Buffer, error) {
+	return c.ExecContext(context.Background(), args...)
+}
+
+func (c *systemClient) ExecContext(ctx context.Context, args ...string) (bytes.Buffer, bytes.Buffer, error) {
+	return c.exec(ctx, nil, args...)
+}
+
+// exec executes git with args, also writing stderr to progress (if non-nil).
+func (c *systemClient) exec(
+	ctx context.Context, progress io.Writer, args ...string,
+) (bytes.Buffer, bytes.Buffer, error) {
 	var stdout bytes.Buffer
 	var stderr bytes.Buffer
 
-	path, err := path()
+	path, err := c.path()
 	if err != nil {
-		err = fmt.Errorf("could not find git executable in PATH: %w", err)
 		return stdout, stderr, err
 	}
 
-	return run(path, args...)
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	stdout, stderr, err = c.run(ctx, path, progress, args...)
+	if err != nil && ctx.Err() != nil {
+		err = contextError(ctx, strings.TrimSpace("git "+subcommand(args)), c.timeout)
+	}
+	return stdout, stderr, err
+}
+
+func (c *systemClient) Fetch(ctx context.Context, remote string, progress ProgressFunc) error {
+	if progress == nil {
+		_, _, err := c.ExecContext(ctx, "fetch", remote)
+		return err
+	}
+	_, _, err := c.exec(ctx, newProgressWriter(progress), "fetch", "--progress", remote)
+	return err
+}
+
+func (c *systemClient) Getenv(key string) string {
+	return getenv(c.env, key)
 }
 
 func (c *systemClient) HasCommits() bool {
@@ -35,6 +160,11 @@ func (c *systemClient) HasRemote(name string) bool {
 	return err == nil
 }
 
+func (c *systemClient) Init(ctx context.Context) error {
+	_, _, err := c.ExecContext(ctx, "init")
+	return err
+}
+
 func (c *systemClient) IsDirty() bool {
 	lines, _ := c.StatusLines()
 	return len(lines) > 0
@@ -46,10 +176,54 @@ func (c *systemClient) IsInitialized() bool {
 }
 
 func (c *systemClient) IsInstalled() bool {
-	_, err := path()
+	_, err := c.path()
 	return err == nil
 }
 
+func (c *systemClient) LocalConfig(key string) (string, error) {
+	return c.config(key, "--local")
+}
+
+func (c *systemClient) Push(ctx context.Context, remote string, progress ProgressFunc) error {
+	if progress == nil {
+		_, _, err := c.ExecContext(ctx, "push", "-u", remote, "HEAD")
+		return err
+	}
+	_, _, err := c.exec(ctx, newProgressWriter(progress), "push", "--progress", "-u", remote, "HEAD")
+	return err
+}
+
+func (c *systemClient) RemoteURL(name string) (string, error) {
+	stdout, _, err := c.Exec("remote", "get-url", name)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+func (c *systemClient) SetConfig(ctx context.Context, key string, value string) error {
+	if _, _, _, err := splitConfigKey(key); err != nil {
+		return err
+	}
+	_, _, err := c.ExecContext(ctx, "config", "--local", key, value)
+	return err
+}
+
+func (c *systemClient) SetRemoteHead(ctx context.Context, remote string) error {
+	_, _, err := c.ExecContext(ctx, "remote", "set-head", remote, "-a")
+	return err
+}
+
+func (c *systemClient) SetRemoteURL(ctx context.Context, name string, url string) error {
+	_, _, err := c.ExecContext(ctx, "remote", "set-url", name, url)
+	return err
+}
+
+func (c *systemClient) SetUpstream(ctx context.Context, upstream string) error {
+	_, _, err := c.ExecContext(ctx, "branch", "-u", upstream, "HEAD")
+	return err
+}
+
 func (c *systemClient) StatusLines() ([]string, error) {
 	stdout, _, err := c.Exec("status", "--porcelain")
 	if err != nil {
@@ -64,19 +238,111 @@ func (c *systemClient) StatusLines() ([]string, error) {
 	return lines, nil
 }
 
-func path() (string, error) {
-	return safeexec.LookPath("git")
+// subcommand returns the git subcommand in args (skipping any global options).
+func subcommand(args []string) string {
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "-c" || args[i] == "-C":
+			i++ // skip the option value
+		case !strings.HasPrefix(args[i], "-"):
+			return args[i]
+		}
+	}
+	return ""
+}
+
+// credentialHelper returns the args and env that make git use the client
+// credentials for remotes under the credentials URL, in place of the
+// configured credential helpers. Both are empty for commands that don't
+// talk to remotes, or when there are no credentials.
+func (c *systemClient) credentialHelper(args []string) ([]string, []string) {
+	if c.credentials == nil {
+		return nil, nil
+	}
+	switch subcommand(args) {
+	case "clone", "fetch", "ls-remote", "pull", "push":
+	default:
+		return nil, nil
+	}
+	username, password := c.credentials()
+	if password == "" {
+		return nil, nil
+	}
+	key := fmt.Sprintf("credential.%s.helper", c.credentialsURL)
+	// The empty helper clears the configured ones.
+	return []string{"-c", key + "=", "-c", key + "=" + credentialHelper},
+		[]string{"GH_SETUP_GIT_USERNAME=" + username, "GH_SETUP_GIT_PASSWORD=" + password}
+}
+
+// path returns the path to the git executable.
+func (c *systemClient) path() (string, error) {
+	if c.gitPath != "" {
+		path, err := safeexec.LookPath(c.gitPath)
+		if err != nil {
+			return "", fmt.Errorf("could not find git executable %s: %w", c.gitPath, err)
+		}
+		return path, nil
+	}
+	path, err := safeexec.LookPath("git")
+	if err != nil {
+		return "", fmt.Errorf("could not find git executable in PATH: %w", err)
+	}
+	return path, nil
 }
 
-func run(path string, args ...string) (bytes.Buffer, bytes.Buffer, error) {
+// run executes the git binary at path with args in the client dir.
+// If progress is non-nil, stderr is also written to it as git runs.
+//
+// When ctx is done, git is sent an interrupt so that it can clean up
+// (lock files, partially written objects, etc), and is killed if it
+// has not exited after interruptGracePeriod.
+func (c *systemClient) run(
+	ctx context.Context, path string, progress io.Writer, args ...string,
+) (bytes.Buffer, bytes.Buffer, error) {
 	var stdout bytes.Buffer
 	var stderr bytes.Buffer
 
-	cmd := exec.Command(path, args...)
+	credentialArgs, credentialEnv := c.credentialHelper(args)
+	env := append(append([]string{}, c.env...), credentialEnv...)
+	cmd := exec.Command(path, append(credentialArgs, args...)...)
+	cmd.Dir = c.dir
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
+	if progress != nil {
+		cmd.Stderr = io.MultiWriter(&stderr, progress)
+	}
+
+	if err := cmd.Start(); err != nil {
+		err = fmt.Errorf("failed to run git: %w", err)
+		return stdout, stderr, err
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- cmd.Wait()
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		// Interrupt isn't supported on Windows - kill straight away.
+		if cmd.Process.Signal(os.Interrupt) != nil {
+			_ = cmd.Process.Kill()
+		}
+		select {
+		case <-done:
+		case <-time.After(interruptGracePeriod):
+			_ = cmd.Process.Kill()
+		}
+		// The buffers may still be written to by orphaned children
+		// (ssh, credential helpers), so don't hand them back.
+		return bytes.Buffer{}, bytes.Buffer{}, ctx.Err()
+	}
 
-	err := cmd.Run()
 	if err != nil {
 		err = fmt.Errorf("failed to run git: %s. error: %w", stderr.String(), err)
 		return stdout, stderr, err
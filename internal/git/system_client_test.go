@@ -0,0 +1,60 @@
+package git
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSystemClient_Credentials(t *testing.T) {
+	authorizations := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		if auth == "" {
+			w.Header().Set("WWW-Authenticate", `Basic realm="test"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		authorizations = append(authorizations, auth)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	// A personal credential helper that must not be used for the host.
+	globalConfig := filepath.Join(t.TempDir(), "gitconfig")
+	require.NoError(t, os.WriteFile(globalConfig, []byte(
+		"[credential]\n\thelper = \"!f() { echo username=personal; echo password=personal-token; }; f\"\n",
+	), 0600))
+
+	credentials := func() (string, string) {
+		return "x-access-token", "some-token"
+	}
+	client := NewClient(t.TempDir(),
+		WithBackend(BackendSystem),
+		WithCredentials(server.URL, credentials),
+		WithEnv("GIT_CONFIG_GLOBAL="+globalConfig, "GIT_CONFIG_NOSYSTEM=1", "GIT_TERMINAL_PROMPT=0"),
+	)
+	basic := func(username string, password string) string {
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+password))
+	}
+
+	// the credentials are used for the host...
+	_, _, err := client.ExecContext(context.Background(), "ls-remote", server.URL+"/some-org/some-repo.git")
+	assert.Error(t, err)
+	assert.Equal(t, []string{basic("x-access-token", "some-token")}, authorizations)
+
+	// ...but not for other hosts
+	authorizations = []string{}
+	other := strings.Replace(server.URL, "127.0.0.1", "localhost", 1)
+	_, _, err = client.ExecContext(context.Background(), "ls-remote", other+"/some-org/some-repo.git")
+	assert.Error(t, err)
+	assert.Equal(t, []string{basic("personal", "personal-token")}, authorizations)
+}
@@ -0,0 +1,195 @@
+// Package scaffold renders template dirs of project files
+// (README.md, CODEOWNERS, issue templates, etc).
+package scaffold
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/twelvelabs/termite/conf"
+)
+
+const (
+	// ManifestName is the name of the (optional) manifest in the template dir.
+	ManifestName = "gh-setup.yaml"
+	// TemplateExt is the extension of the files rendered with text/template.
+	// Other files are copied as is (so that, for example, workflows using
+	// `${{ }}` expressions don't need escaping).
+	TemplateExt = ".tmpl"
+)
+
+// Manifest describes a template.
+type Manifest struct {
+	// Custom prompts, whose answers are available to the template
+	// as {{ .Answers.<name> }}.
+	Prompts []Prompt `yaml:"prompts" validate:"dive"`
+}
+
+// Prompt is a custom prompt defined by a template.
+type Prompt struct {
+	// The name of the answer.
+	Name string `yaml:"name" validate:"required"`
+	// The prompt message.
+	// Defaults to Name.
+	Message string `yaml:"message"`
+	// The default answer.
+	Default string `yaml:"default"`
+	// The allowed answers (prompted as a select, rather than an input).
+	Options []string `yaml:"options"`
+}
+
+// Data is the data the templates are rendered with.
+type Data struct {
+	// The repo name.
+	Name string
+	// The repo owner.
+	Owner string
+	// The repo description.
+	Description string
+	// The GitHub login of the current user.
+	User string
+	// The git user.name of the current user.
+	UserName string
+	// The current year.
+	Year int
+	// The answers to the custom prompts.
+	Answers map[string]string
+}
+
+// Repo returns the full name of the repo ("owner/name").
+func (d Data) Repo() string {
+	return fmt.Sprintf("%s/%s", d.Owner, d.Name)
+}
+
+// File is a rendered file.
+type File struct {
+	// The path relative to the root of the project (slash separated).
+	Path    string
+	Content []byte
+	Mode    fs.FileMode
+}
+
+// Template is a dir of project files.
+type Template struct {
+	Dir      string
+	Manifest *Manifest
+}
+
+// Load loads the template in dir.
+func Load(dir string) (*Template, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("template: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("template: %s is not a directory", dir)
+	}
+	manifest, err := conf.NewLoader(&Manifest{}, filepath.Join(dir, ManifestName)).Load()
+	if err != nil {
+		return nil, fmt.Errorf("template: %s: %w", ManifestName, err)
+	}
+	return &Template{
+		Dir:      dir,
+		Manifest: manifest,
+	}, nil
+}
+
+// Render renders the files in the template (sorted by path).
+// Paths may contain template actions (e.g. "cmd/{{ .Name }}/main.go"),
+// and the TemplateExt is removed from those of rendered files.
+func (t *Template) Render(data Data) ([]File, error) {
+	files := []File{}
+	err := filepath.WalkDir(t.Dir, func(src string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(t.Dir, src)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if entry.IsDir() {
+			if rel == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if rel == ManifestName {
+			return nil
+		}
+		if entry.Type()&fs.ModeSymlink != 0 {
+			// Could copy any file on the machine into the project.
+			return fmt.Errorf("template: %s: symlinks are not supported", rel)
+		}
+		file, err := t.renderFile(src, entry, rel, data)
+		if err != nil {
+			return fmt.Errorf("template: %s: %w", rel, err)
+		}
+		files = append(files, file)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Path < files[j].Path
+	})
+	return files, nil
+}
+
+func (t *Template) renderFile(src string, entry fs.DirEntry, rel string, data Data) (File, error) {
+	info, err := entry.Info()
+	if err != nil {
+		return File{}, err
+	}
+	if !info.Mode().IsRegular() {
+		return File{}, fmt.Errorf("not a regular file")
+	}
+	content, err := os.ReadFile(src)
+	if err != nil {
+		return File{}, err
+	}
+	if strings.HasSuffix(rel, TemplateExt) {
+		rel = strings.TrimSuffix(rel, TemplateExt)
+		content, err = execute(rel, string(content), data)
+		if err != nil {
+			return File{}, err
+		}
+	}
+	if strings.Contains(rel, "{{") {
+		rendered, err := execute(rel, rel, data)
+		if err != nil {
+			return File{}, err
+		}
+		rel = string(rendered)
+	}
+	rel = path.Clean(rel)
+	if path.IsAbs(rel) || filepath.IsAbs(rel) || rel == ".." || strings.HasPrefix(rel, "../") {
+		return File{}, fmt.Errorf("the rendered path %s is outside the project", rel)
+	}
+	return File{
+		Path:    rel,
+		Content: content,
+		Mode:    info.Mode().Perm(),
+	}, nil
+}
+
+// execute renders text with data, failing on missing answers.
+func execute(name string, text string, data Data) ([]byte, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	buf := &bytes.Buffer{}
+	if err := tmpl.Execute(buf, data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
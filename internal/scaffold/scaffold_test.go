@@ -0,0 +1,160 @@
+package scaffold
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+func testData() Data {
+	return Data{
+		Name:        "some-repo",
+		Owner:       "some-org",
+		Description: "Does some things.",
+		User:        "some-user",
+		UserName:    "Some User",
+		Year:        2023,
+		Answers: map[string]string{
+			"license": "Apache-2.0",
+			"team":    "core",
+		},
+	}
+}
+
+func TestLoad(t *testing.T) {
+	tmpl, err := Load(filepath.Join("testdata", "template"))
+	assert.NoError(t, err)
+	assert.Equal(t, &Manifest{
+		Prompts: []Prompt{
+			{Name: "license", Message: "License", Default: "MIT", Options: []string{"MIT", "Apache-2.0"}},
+			{Name: "team", Message: "The team that owns the repo", Default: "maintainers"},
+		},
+	}, tmpl.Manifest)
+
+	// The manifest is optional.
+	dir := t.TempDir()
+	tmpl, err = Load(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, &Manifest{}, tmpl.Manifest)
+
+	_, err = Load(filepath.Join("testdata", "missing"))
+	assert.ErrorContains(t, err, "template:")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ManifestName), []byte("prompts:\n  - message: Nameless\n"), 0600))
+	_, err = Load(dir)
+	assert.ErrorContains(t, err, "gh-setup.yaml")
+}
+
+func TestTemplate_Render(t *testing.T) {
+	tmpl, err := Load(filepath.Join("testdata", "template"))
+	require.NoError(t, err)
+
+	files, err := tmpl.Render(testData())
+	require.NoError(t, err)
+
+	golden := filepath.Join("testdata", "golden")
+	if *update {
+		require.NoError(t, os.RemoveAll(golden))
+		for _, file := range files {
+			path := filepath.Join(golden, filepath.FromSlash(file.Path))
+			require.NoError(t, os.MkdirAll(filepath.Dir(path), 0750))
+			require.NoError(t, os.WriteFile(path, file.Content, 0600))
+		}
+	}
+
+	paths := []string{}
+	for _, file := range files {
+		paths = append(paths, file.Path)
+		expected, err := os.ReadFile(filepath.Join(golden, filepath.FromSlash(file.Path)))
+		if assert.NoError(t, err, file.Path) {
+			assert.Equal(t, string(expected), string(file.Content), file.Path)
+		}
+	}
+	assert.Equal(t, []string{
+		".editorconfig",
+		".github/CODEOWNERS",
+		".github/ISSUE_TEMPLATE/bug_report.md",
+		".github/dependabot.yml",
+		".github/pull_request_template.md",
+		".github/workflows/ci.yml",
+		"CONTRIBUTING.md",
+		"LICENSE",
+		"README.md",
+		"SECURITY.md",
+		"docs/some-repo.md",
+	}, paths)
+}
+
+func TestTemplate_Render_Errors(t *testing.T) {
+	tests := []struct {
+		desc     string
+		files    map[string]string
+		symlinks map[string]string
+		answers  map[string]string
+		err      string
+	}{
+		{
+			desc:  "invalid templates",
+			files: map[string]string{"README.md.tmpl": "# {{ .Name"},
+			err:   "template: README.md:",
+		},
+		{
+			desc:  "unknown fields",
+			files: map[string]string{"README.md.tmpl": "# {{ .Missing }}"},
+			err:   "can't evaluate field Missing",
+		},
+		{
+			desc:  "missing answers",
+			files: map[string]string{"README.md.tmpl": "# {{ .Answers.missing }}"},
+			err:   "map has no entry for key \"missing\"",
+		},
+		{
+			desc:  "invalid paths",
+			files: map[string]string{"{{ .Name": ""},
+			err:   "unclosed action",
+		},
+		{
+			desc:    "paths outside the project",
+			files:   map[string]string{"{{ .Answers.name }}.md": ""},
+			answers: map[string]string{"name": "../../README"},
+			err:     "the rendered path ../../README.md is outside the project",
+		},
+		{
+			desc:    "absolute paths",
+			files:   map[string]string{"{{ .Answers.name }}.md": ""},
+			answers: map[string]string{"name": "/tmp/README"},
+			err:     "the rendered path /tmp/README.md is outside the project",
+		},
+		{
+			desc:     "symlinks",
+			symlinks: map[string]string{"README.md": "../../../.ssh/id_rsa"},
+			err:      "template: README.md: symlinks are not supported",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tt.files {
+				require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0600))
+			}
+			for name, target := range tt.symlinks {
+				require.NoError(t, os.Symlink(target, filepath.Join(dir, name)))
+			}
+			tmpl, err := Load(dir)
+			require.NoError(t, err)
+
+			data := testData()
+			if tt.answers != nil {
+				data.Answers = tt.answers
+			}
+			_, err = tmpl.Render(data)
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
@@ -0,0 +1,133 @@
+// Package sshkey finds the SSH public keys available to the current user.
+package sshkey
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+// Key is an SSH public key.
+type Key struct {
+	// The key in authorized_keys format, without the comment ("ssh-ed25519 AAAA...").
+	PublicKey string
+	// The key comment (usually user@host).
+	Comment string
+	// The SHA256 fingerprint ("SHA256:...").
+	Fingerprint string
+	// The public key file (empty for keys only loaded in ssh-agent).
+	Path string
+}
+
+// Parse parses a public key in authorized_keys format.
+func Parse(authorizedKey string) (*Key, error) {
+	pub, comment, _, _, err := ssh.ParseAuthorizedKey([]byte(authorizedKey))
+	if err != nil {
+		return nil, fmt.Errorf("invalid ssh public key: %w", err)
+	}
+	return newKey(pub, comment), nil
+}
+
+// Fingerprint returns the SHA256 fingerprint of a public key
+// in authorized_keys format (as shown by `ssh-keygen -l`).
+func Fingerprint(authorizedKey string) (string, error) {
+	key, err := Parse(authorizedKey)
+	if err != nil {
+		return "", err
+	}
+	return key.Fingerprint, nil
+}
+
+// Local returns the public keys in ~/.ssh and those loaded in ssh-agent.
+// Keys that are in both are only returned once (with their path).
+func Local() ([]*Key, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+	keys, err := FromDir(filepath.Join(home, ".ssh"))
+	if err != nil {
+		return nil, err
+	}
+	// A stale or unreachable agent is common enough that it isn't an error.
+	agentKeys, _ := FromAgent(os.Getenv("SSH_AUTH_SOCK"))
+	for _, key := range agentKeys {
+		if Find(keys, key.Fingerprint) == nil {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+// FromDir returns the public keys in the *.pub files in dir.
+// Files that are not public keys are skipped.
+func FromDir(dir string) ([]*Key, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.pub"))
+	if err != nil {
+		return nil, err
+	}
+	keys := []*Key{}
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		key, err := Parse(string(content))
+		if err != nil {
+			continue
+		}
+		key.Path = path
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// FromAgent returns the public keys loaded in the ssh-agent listening on socket.
+// Returns no keys if socket is empty.
+func FromAgent(socket string) ([]*Key, error) {
+	keys := []*Key{}
+	if socket == "" {
+		return keys, nil
+	}
+	conn, err := net.Dial("unix", socket)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to ssh-agent: %w", err)
+	}
+	defer conn.Close()
+
+	agentKeys, err := agent.NewClient(conn).List()
+	if err != nil {
+		return nil, fmt.Errorf("unable to list ssh-agent keys: %w", err)
+	}
+	for _, agentKey := range agentKeys {
+		pub, err := ssh.ParsePublicKey(agentKey.Marshal())
+		if err != nil {
+			continue
+		}
+		keys = append(keys, newKey(pub, agentKey.Comment))
+	}
+	return keys, nil
+}
+
+// Find returns the key in keys with fingerprint (or nil if none).
+func Find(keys []*Key, fingerprint string) *Key {
+	for _, key := range keys {
+		if key.Fingerprint == fingerprint {
+			return key
+		}
+	}
+	return nil
+}
+
+func newKey(pub ssh.PublicKey, comment string) *Key {
+	return &Key{
+		PublicKey:   strings.TrimSpace(string(ssh.MarshalAuthorizedKey(pub))),
+		Comment:     comment,
+		Fingerprint: ssh.FingerprintSHA256(pub),
+	}
+}
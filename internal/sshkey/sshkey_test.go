@@ -0,0 +1,140 @@
+package sshkey
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+const (
+	ed25519Fingerprint = "SHA256:LbGkCRuoTKKHnKICMhw7MwmMl/TdOd9mAhJrsC+P93U"
+	rsaFingerprint     = "SHA256:H414HUwS5Zoyjv0/s/62ToOFzEds4OGRV365q/rhbfU"
+)
+
+func readFixture(t *testing.T, name string) string {
+	t.Helper()
+	content, err := os.ReadFile(filepath.Join("testdata", "ssh", name))
+	require.NoError(t, err)
+	return string(content)
+}
+
+func TestFingerprint(t *testing.T) {
+	tests := []struct {
+		desc     string
+		key      string
+		expected string
+		err      string
+	}{
+		{
+			desc:     "returns the fingerprint of ed25519 keys",
+			key:      readFixture(t, "id_ed25519.pub"),
+			expected: ed25519Fingerprint,
+		},
+		{
+			desc:     "returns the fingerprint of rsa keys",
+			key:      readFixture(t, "id_rsa.pub"),
+			expected: rsaFingerprint,
+		},
+		{
+			desc:     "ignores the comment",
+			key:      strings.Join(strings.Fields(readFixture(t, "id_ed25519.pub"))[:2], " "),
+			expected: ed25519Fingerprint,
+		},
+		{
+			desc: "returns an error for invalid keys",
+			key:  "not a key",
+			err:  "invalid ssh public key",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			actual, err := Fingerprint(tt.key)
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestFromDir(t *testing.T) {
+	keys, err := FromDir(filepath.Join("testdata", "ssh"))
+	assert.NoError(t, err)
+	require.Len(t, keys, 2) // notes.pub is skipped
+
+	assert.Equal(t, ed25519Fingerprint, keys[0].Fingerprint)
+	assert.Equal(t, "ed25519@example.com", keys[0].Comment)
+	assert.Equal(t, filepath.Join("testdata", "ssh", "id_ed25519.pub"), keys[0].Path)
+	assert.True(t, strings.HasPrefix(keys[0].PublicKey, "ssh-ed25519 AAAA"))
+	assert.NotContains(t, keys[0].PublicKey, "example.com")
+
+	assert.Equal(t, rsaFingerprint, keys[1].Fingerprint)
+	assert.NotNil(t, Find(keys, rsaFingerprint))
+	assert.Nil(t, Find(keys, "SHA256:unknown"))
+
+	keys, err = FromDir(filepath.Join("testdata", "missing"))
+	assert.NoError(t, err)
+	assert.Empty(t, keys)
+}
+
+func TestFromAgent(t *testing.T) {
+	keys, err := FromAgent("")
+	assert.NoError(t, err)
+	assert.Empty(t, keys)
+
+	_, err = FromAgent(filepath.Join(t.TempDir(), "missing.sock"))
+	assert.ErrorContains(t, err, "unable to connect to ssh-agent")
+
+	socket := startAgent(t)
+	keys, err = FromAgent(socket)
+	assert.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.Equal(t, "agent@example.com", keys[0].Comment)
+	assert.Equal(t, "", keys[0].Path)
+	fingerprint, err := Fingerprint(keys[0].PublicKey)
+	assert.NoError(t, err)
+	assert.Equal(t, fingerprint, keys[0].Fingerprint)
+}
+
+// startAgent starts an in-process ssh-agent holding a single key
+// and returns its socket.
+func startAgent(t *testing.T) string {
+	t.Helper()
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	keyring := agent.NewKeyring()
+	require.NoError(t, keyring.Add(agent.AddedKey{PrivateKey: private, Comment: "agent@example.com"}))
+
+	// Unix socket paths are limited in length, so avoid t.TempDir().
+	dir, err := os.MkdirTemp("", "agent")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	socket := filepath.Join(dir, "agent.sock")
+	listener, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				_ = agent.ServeAgent(keyring, conn)
+				conn.Close()
+			}()
+		}
+	}()
+	return socket
+}
@@ -0,0 +1,275 @@
+// Package workflow generates GitHub Actions CI workflows
+// for the project types it can detect.
+package workflow
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+// Project types.
+const (
+	TypeGo     = "go"
+	TypeNode   = "node"
+	TypePython = "python"
+	TypeRust   = "rust"
+	TypeDocker = "docker"
+)
+
+// The templates use [[ ]] delimiters so that GitHub expressions
+// (${{ }}) don't need escaping.
+const (
+	leftDelim  = "[["
+	rightDelim = "]]"
+)
+
+var (
+	//go:embed templates/*.yml
+	builtins embed.FS
+
+	goVersionRE       = regexp.MustCompile(`(?m)^go\s+(\S+)`)
+	versionRE         = regexp.MustCompile(`\d+(?:\.\d+)*`)
+	requiresPythonRE  = regexp.MustCompile(`(?m)^requires-python\s*=\s*["']([^"']*)["']`)
+	rustChannelRE     = regexp.MustCompile(`(?m)^channel\s*=\s*["']([^"']*)["']`)
+	rustVersionRE     = regexp.MustCompile(`(?m)^rust-version\s*=\s*["']([^"']*)["']`)
+	errVersionMissing = errors.New("version not found")
+)
+
+// Project is a detected project type.
+type Project struct {
+	// The project type (TypeGo, TypeNode, etc).
+	Type string
+	// The language version read from the project
+	// (or the latest stable version when it isn't pinned).
+	Version string
+}
+
+// Path returns the path of the workflow for the project
+// (relative to the root of the repo).
+func (p Project) Path() string {
+	return ".github/workflows/" + p.Type + ".yml"
+}
+
+// detector detects a project type in a dir.
+type detector struct {
+	Type string
+	// Files (any of which) mark the dir as a project of Type.
+	Files []string
+	// Reads the version from the project.
+	Version func(dir string) (string, error)
+	// The version used when the project doesn't pin one.
+	DefaultVersion string
+}
+
+var detectors = []detector{
+	{
+		Type:           TypeGo,
+		Files:          []string{"go.mod"},
+		Version:        goVersion,
+		DefaultVersion: "stable",
+	},
+	{
+		Type:           TypeNode,
+		Files:          []string{"package.json"},
+		Version:        nodeVersion,
+		DefaultVersion: "lts/*",
+	},
+	{
+		Type:           TypePython,
+		Files:          []string{"pyproject.toml", "setup.py", "requirements.txt"},
+		Version:        pythonVersion,
+		DefaultVersion: "3.x",
+	},
+	{
+		Type:           TypeRust,
+		Files:          []string{"Cargo.toml"},
+		Version:        rustVersion,
+		DefaultVersion: "stable",
+	},
+	{
+		Type:  TypeDocker,
+		Files: []string{"Dockerfile"},
+	},
+}
+
+// Detect returns the projects in dir (in a fixed order: go, node, python,
+// rust, then docker).
+func Detect(dir string) ([]Project, error) {
+	projects := []Project{}
+	for _, d := range detectors {
+		if !anyExists(dir, d.Files) {
+			continue
+		}
+		project := Project{
+			Type:    d.Type,
+			Version: d.DefaultVersion,
+		}
+		if d.Version != nil {
+			version, err := d.Version(dir)
+			switch {
+			case err == nil:
+				project.Version = version
+			case !errors.Is(err, errVersionMissing):
+				return nil, fmt.Errorf("workflow: %s: %w", d.Type, err)
+			}
+		}
+		projects = append(projects, project)
+	}
+	return projects, nil
+}
+
+// Render renders the workflow for project. The template is read from
+// "<type>.yml" in overrides when it exists there, otherwise the built-in
+// template is used. Overrides may be empty.
+func Render(project Project, overrides string) ([]byte, error) {
+	var content []byte
+	var err error
+	name := project.Type + ".yml"
+	if overrides != "" {
+		content, err = os.ReadFile(filepath.Join(overrides, name))
+		if err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("workflow: %w", err)
+		}
+	}
+	if content == nil {
+		content, err = builtins.ReadFile("templates/" + name)
+		if err != nil {
+			return nil, fmt.Errorf("workflow: no template for %s projects", project.Type)
+		}
+	}
+
+	tmpl, err := template.New(name).Delims(leftDelim, rightDelim).Option("missingkey=error").Parse(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("workflow: %w", err)
+	}
+	buf := &bytes.Buffer{}
+	if err := tmpl.Execute(buf, project); err != nil {
+		return nil, fmt.Errorf("workflow: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// goVersion returns the go directive in go.mod.
+func goVersion(dir string) (string, error) {
+	return match(filepath.Join(dir, "go.mod"), goVersionRE)
+}
+
+// nodeVersion returns the version in .nvmrc or .node-version,
+// falling back to engines.node in package.json.
+func nodeVersion(dir string) (string, error) {
+	for _, name := range []string{".nvmrc", ".node-version"} {
+		version, err := firstLine(filepath.Join(dir, name))
+		if !errors.Is(err, errVersionMissing) {
+			return strings.TrimPrefix(version, "v"), err
+		}
+	}
+	content, err := os.ReadFile(filepath.Join(dir, "package.json"))
+	if err != nil {
+		return "", err
+	}
+	pkg := struct {
+		Engines struct {
+			Node string `json:"node"`
+		} `json:"engines"`
+	}{}
+	if err := json.Unmarshal(content, &pkg); err != nil {
+		return "", fmt.Errorf("package.json: %w", err)
+	}
+	// Use the minimum version of ranges like ">=18" or "^18.12".
+	if version := versionRE.FindString(pkg.Engines.Node); version != "" {
+		return version, nil
+	}
+	return "", errVersionMissing
+}
+
+// pythonVersion returns the version in .python-version,
+// falling back to the minimum requires-python version in pyproject.toml.
+func pythonVersion(dir string) (string, error) {
+	version, err := firstLine(filepath.Join(dir, ".python-version"))
+	if !errors.Is(err, errVersionMissing) {
+		return version, err
+	}
+	requires, err := match(filepath.Join(dir, "pyproject.toml"), requiresPythonRE)
+	if err != nil {
+		return "", err
+	}
+	if version := versionRE.FindString(requires); version != "" {
+		return version, nil
+	}
+	return "", errVersionMissing
+}
+
+// rustVersion returns the toolchain channel in rust-toolchain(.toml),
+// falling back to rust-version in Cargo.toml.
+func rustVersion(dir string) (string, error) {
+	version, err := match(filepath.Join(dir, "rust-toolchain.toml"), rustChannelRE)
+	if !errors.Is(err, errVersionMissing) {
+		return version, err
+	}
+	// The legacy format is either just the channel, or TOML.
+	content, err := os.ReadFile(filepath.Join(dir, "rust-toolchain"))
+	switch {
+	case err == nil:
+		if m := rustChannelRE.FindSubmatch(content); m != nil {
+			return string(m[1]), nil
+		}
+		if version := strings.TrimSpace(string(content)); version != "" && !strings.Contains(version, "\n") {
+			return version, nil
+		}
+	case !os.IsNotExist(err):
+		return "", err
+	}
+	return match(filepath.Join(dir, "Cargo.toml"), rustVersionRE)
+}
+
+// match returns the first submatch of re in the file at path.
+// Returns errVersionMissing if there is no file or match.
+func match(path string, re *regexp.Regexp) (string, error) {
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return "", errVersionMissing
+	}
+	if err != nil {
+		return "", err
+	}
+	m := re.FindSubmatch(content)
+	if m == nil {
+		return "", errVersionMissing
+	}
+	return string(m[1]), nil
+}
+
+// firstLine returns the first line of the file at path.
+// Returns errVersionMissing if there is no file or it's empty.
+func firstLine(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return "", errVersionMissing
+	}
+	if err != nil {
+		return "", err
+	}
+	line, _, _ := strings.Cut(strings.TrimSpace(string(content)), "\n")
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return "", errVersionMissing
+	}
+	return line, nil
+}
+
+func anyExists(dir string, names []string) bool {
+	for _, name := range names {
+		if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+			return true
+		}
+	}
+	return false
+}
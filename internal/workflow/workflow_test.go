@@ -0,0 +1,174 @@
+package workflow
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		desc     string
+		files    map[string]string
+		expected []Project
+		err      string
+	}{
+		{
+			desc:     "returns nothing for unknown projects",
+			files:    map[string]string{"README.md": ""},
+			expected: []Project{},
+		},
+		{
+			desc:     "reads the go version from go.mod",
+			files:    map[string]string{"go.mod": "module example.com/foo\n\ngo 1.20\n"},
+			expected: []Project{{Type: TypeGo, Version: "1.20"}},
+		},
+		{
+			desc:     "defaults the go version",
+			files:    map[string]string{"go.mod": "module example.com/foo\n"},
+			expected: []Project{{Type: TypeGo, Version: "stable"}},
+		},
+		{
+			desc: "reads the node version from .nvmrc",
+			files: map[string]string{
+				"package.json": `{"engines": {"node": ">=16"}}`,
+				".nvmrc":       "v18.12.1\n",
+			},
+			expected: []Project{{Type: TypeNode, Version: "18.12.1"}},
+		},
+		{
+			desc:     "reads the node version from package.json",
+			files:    map[string]string{"package.json": `{"engines": {"node": ">=18"}}`},
+			expected: []Project{{Type: TypeNode, Version: "18"}},
+		},
+		{
+			desc:     "defaults the node version",
+			files:    map[string]string{"package.json": `{"name": "foo"}`},
+			expected: []Project{{Type: TypeNode, Version: "lts/*"}},
+		},
+		{
+			desc:  "returns invalid package.json errors",
+			files: map[string]string{"package.json": `{`},
+			err:   "workflow: node: package.json:",
+		},
+		{
+			desc: "reads the python version from .python-version",
+			files: map[string]string{
+				"pyproject.toml":  "[project]\nrequires-python = \">=3.8\"\n",
+				".python-version": "3.11.4\n",
+			},
+			expected: []Project{{Type: TypePython, Version: "3.11.4"}},
+		},
+		{
+			desc:     "reads the python version from pyproject.toml",
+			files:    map[string]string{"pyproject.toml": "[project]\nrequires-python = \">=3.10\"\n"},
+			expected: []Project{{Type: TypePython, Version: "3.10"}},
+		},
+		{
+			desc:     "defaults the python version",
+			files:    map[string]string{"requirements.txt": "requests\n"},
+			expected: []Project{{Type: TypePython, Version: "3.x"}},
+		},
+		{
+			desc: "reads the rust version from rust-toolchain.toml",
+			files: map[string]string{
+				"Cargo.toml":          "[package]\nrust-version = \"1.65\"\n",
+				"rust-toolchain.toml": "[toolchain]\nchannel = \"1.70.0\"\n",
+			},
+			expected: []Project{{Type: TypeRust, Version: "1.70.0"}},
+		},
+		{
+			desc: "reads the rust version from rust-toolchain",
+			files: map[string]string{
+				"Cargo.toml":     "[package]\n",
+				"rust-toolchain": "nightly-2023-06-01\n",
+			},
+			expected: []Project{{Type: TypeRust, Version: "nightly-2023-06-01"}},
+		},
+		{
+			desc:     "reads the rust version from Cargo.toml",
+			files:    map[string]string{"Cargo.toml": "[package]\nrust-version = \"1.65\"\n"},
+			expected: []Project{{Type: TypeRust, Version: "1.65"}},
+		},
+		{
+			desc: "returns all the projects",
+			files: map[string]string{
+				"Dockerfile": "FROM scratch\n",
+				"go.mod":     "module example.com/foo\n\ngo 1.19\n",
+			},
+			expected: []Project{{Type: TypeGo, Version: "1.19"}, {Type: TypeDocker}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tt.files {
+				require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0600))
+			}
+
+			actual, err := Detect(dir)
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	projects := []Project{
+		{Type: TypeGo, Version: "1.20"},
+		{Type: TypeNode, Version: "18"},
+		{Type: TypePython, Version: "3.11"},
+		{Type: TypeRust, Version: "stable"},
+		{Type: TypeDocker},
+	}
+	for _, project := range projects {
+		t.Run(project.Type, func(t *testing.T) {
+			actual, err := Render(project, "")
+			require.NoError(t, err)
+
+			golden := filepath.Join("testdata", "golden", project.Type+".yml")
+			if *update {
+				require.NoError(t, os.MkdirAll(filepath.Dir(golden), 0750))
+				require.NoError(t, os.WriteFile(golden, actual, 0600))
+			}
+			expected, err := os.ReadFile(golden)
+			require.NoError(t, err)
+			assert.Equal(t, string(expected), string(actual))
+		})
+	}
+}
+
+func TestRender_Overrides(t *testing.T) {
+	overrides := t.TempDir()
+	require.NoError(t, os.WriteFile(
+		filepath.Join(overrides, "go.yml"),
+		[]byte("go-version: [[ .Version ]]\nruns-on: ${{ matrix.os }}\n"),
+		0600,
+	))
+	require.NoError(t, os.WriteFile(filepath.Join(overrides, "rust.yml"), []byte("[[ .Missing ]]"), 0600))
+
+	actual, err := Render(Project{Type: TypeGo, Version: "1.20"}, overrides)
+	assert.NoError(t, err)
+	assert.Equal(t, "go-version: 1.20\nruns-on: ${{ matrix.os }}\n", string(actual))
+
+	// Falls back to the built-in templates.
+	actual, err = Render(Project{Type: TypeNode, Version: "18"}, overrides)
+	assert.NoError(t, err)
+	assert.Contains(t, string(actual), `node-version: "18"`)
+
+	_, err = Render(Project{Type: TypeRust, Version: "stable"}, overrides)
+	assert.ErrorContains(t, err, "can't evaluate field Missing")
+
+	_, err = Render(Project{Type: "cobol"}, "")
+	assert.ErrorContains(t, err, "workflow: no template for cobol projects")
+}
@@ -1,8 +1,10 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
 
 	"github.com/twelvelabs/gh-setup/internal/cmd"
 	"github.com/twelvelabs/gh-setup/internal/core"
@@ -14,8 +16,19 @@ func main() {
 		fmt.Println(err)
 		os.Exit(1)
 	}
+
+	// Cancel (rather than exit) on Ctrl-C so that running git commands
+	// are interrupted and the progress indicator is cleaned up.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	go func() {
+		// Restore the default behavior, so that a second Ctrl-C exits.
+		<-ctx.Done()
+		stop()
+	}()
 	command := cmd.NewRootCmd(app)
-	if err := command.Execute(); err != nil {
+	err = command.ExecuteContext(ctx)
+	stop()
+	if err != nil {
 		os.Exit(1)
 	}
 }